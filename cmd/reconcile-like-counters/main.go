@@ -0,0 +1,50 @@
+// Command reconcile-like-counters recomputes the like counters of every user from their likes
+// and reports the users whose stored counters have drifted. With --fix, it also corrects them.
+// It exits with status 1 when drift is found and not fixed, so it can run as a scheduled check.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"muzz-backend-challenge/internal/config"
+	"muzz-backend-challenge/internal/db"
+	"muzz-backend-challenge/pkg/repository"
+	"os"
+)
+
+func main() {
+	fix := flag.Bool("fix", false, "replace drifted counters with the recomputed ones")
+	flag.Parse()
+
+	config.InitConfig()
+
+	dbConn, err := db.ConnectDB()
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer dbConn.Close()
+
+	drifts, err := repository.NewLikeCounterRepository(dbConn).ReconcileLikeCounters(context.Background(), *fix)
+	if err != nil {
+		log.Fatalf("Failed to reconcile like counters: %v", err)
+	}
+
+	for _, drift := range drifts {
+		log.Printf("User %s: stored received=%d new_received=%d matches=%d, actual received=%d new_received=%d matches=%d",
+			drift.UserID,
+			drift.Stored.Received, drift.Stored.NewReceived, drift.Stored.Matches,
+			drift.Actual.Received, drift.Actual.NewReceived, drift.Actual.Matches)
+	}
+
+	switch {
+	case len(drifts) == 0:
+		log.Println("Like counters are in sync")
+	case *fix:
+		log.Printf("Fixed the like counters of %d users", len(drifts))
+	default:
+		log.Printf("Like counters of %d users have drifted, run with --fix to correct them", len(drifts))
+		dbConn.Close()
+		os.Exit(1)
+	}
+}
@@ -1,47 +1,227 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"flag"
 	"log"
 	"muzz-backend-challenge/internal/config"
 	"muzz-backend-challenge/internal/db"
+	"muzz-backend-challenge/pkg/cache"
+	"muzz-backend-challenge/pkg/events"
+	"muzz-backend-challenge/pkg/outbox"
 	explore "muzz-backend-challenge/pkg/proto"
 	"muzz-backend-challenge/pkg/repository"
+	"muzz-backend-challenge/pkg/webhook"
 	"net"
+	"os"
+	"time"
 
 	"muzz-backend-challenge/pkg/service"
 
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	storage := flag.String("storage", "postgres", "where data is stored: postgres, or memory to run without a database")
+	flag.Parse()
+
 	config.InitConfig()
 
-	dbConn, err := db.ConnectDB()
+	lis, err := net.Listen("tcp", ":8089")
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		log.Fatalf("cannot create listener: %s", err)
 	}
-	defer dbConn.Close()
 
-	if err := db.RunMigrations(dbConn); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+	serviceRegistrar := grpc.NewServer()
+	// The webhook admin API can subscribe to every match event, so it gets its own server on
+	// ADMIN_ADDR, which only listens on loopback unless configured otherwise
+	adminRegistrar := grpc.NewServer()
+	var (
+		exploreRepository repository.ExploreRepository
+		outboxRepository  repository.OutboxRepository
+		userRepository    repository.UserRepository
+		eventsBackend     events.Backend
+		webhookPublisher  outbox.EventPublisher
+	)
+
+	switch *storage {
+	case "postgres":
+		dbConn, err := db.ConnectDB()
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer dbConn.Close()
+
+		if err := db.RunMigrations(dbConn); err != nil {
+			log.Fatalf("Failed to run migrations: %v", err)
+		}
+
+		if viper.GetBool("LOAD_MOCK_DATA") {
+			if err := db.LoadMockData(dbConn); err != nil {
+				log.Fatalf("Failed to load mock data: %v", err)
+			}
+		}
+
+		webhookRepository := repository.NewWebhookRepository(dbConn)
+		webhookDispatcher := webhook.NewDispatcher(
+			webhookRepository,
+			webhook.WithHTTPClient(webhook.NewHTTPClient(viper.GetDuration("WEBHOOK_TIMEOUT"))),
+			webhook.WithMaxAttempts(viper.GetInt("WEBHOOK_MAX_ATTEMPTS")),
+		)
+		go webhookDispatcher.Run(context.Background())
+		explore.RegisterWebhookAdminServiceServer(adminRegistrar, service.NewWebhookAdminService(webhookRepository))
+		go serveAdmin(adminRegistrar)
+
+		exploreRepository = repository.NewExploreRepository(dbConn)
+		outboxRepository = repository.NewOutboxRepository(dbConn)
+		userRepository = repository.NewUserRepository(dbConn)
+		eventsBackend = newEventsBackend(dbConn)
+		webhookPublisher = webhook.NewPublisher(webhookRepository)
+	case "memory":
+		memoryRepository := repository.NewMemoryRepository()
+		if viper.GetBool("LOAD_MOCK_DATA") {
+			if err := db.LoadMemoryMockData(memoryRepository); err != nil {
+				log.Fatalf("Failed to load mock data: %v", err)
+			}
+		}
+		log.Println("Using in-memory storage: data is lost on exit and webhooks are disabled")
+
+		exploreRepository = memoryRepository
+		outboxRepository = memoryRepository
+		userRepository = memoryRepository
+		eventsBackend = events.NewLocalBackend()
+	default:
+		log.Fatalf("Unknown storage: %s", *storage)
 	}
 
-	if err := db.LoadMockData(dbConn); err != nil {
-		log.Fatalf("Failed to load mock data: %v", err)
+	if likesCache := newLikesCache(); likesCache != nil {
+		cacheTTL := repository.WithCacheTTL(viper.GetDuration("CACHE_TTL"))
+		exploreRepository = repository.NewCachingExploreRepository(exploreRepository, likesCache, cacheTTL)
+		userRepository = repository.NewCachingUserRepository(userRepository, likesCache)
 	}
 
-	lis, err := net.Listen("tcp", ":8089")
-	if err != nil {
-		log.Fatalf("cannot create listener: %s", err)
+	eventHub := events.NewHub(eventsBackend)
+	go func() {
+		if err := eventHub.Run(context.Background()); err != nil {
+			log.Fatalf("Explore events hub stopped: %v", err)
+		}
+	}()
+
+	outboxPublisher := newOutboxPublisher()
+	if webhookPublisher != nil {
+		outboxPublisher = outbox.NewFanOutPublisher(outboxPublisher, webhookPublisher)
 	}
+	outboxRelay := outbox.NewRelay(
+		outboxRepository,
+		outboxPublisher,
+		outbox.WithPollInterval(viper.GetDuration("OUTBOX_POLL_INTERVAL")),
+	)
+	go outboxRelay.Run(context.Background())
 
-	serviceRegistrar := grpc.NewServer()
-	exploreRepository := repository.NewExploreRepository(dbConn)
-	exploreService := service.NewExploreService(exploreRepository)
+	exploreService := service.NewExploreService(
+		exploreRepository,
+		service.WithDefaultPageSize(viper.GetInt("EXPLORE_DEFAULT_PAGE_SIZE")),
+		service.WithMaxPageSize(viper.GetInt("EXPLORE_MAX_PAGE_SIZE")),
+		service.WithEventHub(eventHub),
+		service.WithIdempotencyKeyTTL(viper.GetDuration("IDEMPOTENCY_KEY_TTL")),
+		service.WithMaxBatchDecisions(viper.GetInt("EXPLORE_MAX_BATCH_DECISIONS")),
+	)
+	go purgeExpiredRows(context.Background(), exploreRepository, outboxRepository,
+		viper.GetDuration("IDEMPOTENCY_KEY_TTL"), viper.GetDuration("OUTBOX_RETENTION"))
 
 	explore.RegisterExploreServiceServer(serviceRegistrar, exploreService)
+	explore.RegisterUserServiceServer(serviceRegistrar, service.NewUserService(userRepository))
 	err = serviceRegistrar.Serve(lis)
 	if err != nil {
 		log.Fatalf("Impossible to serve: %s", err)
 	}
 }
+
+// serveAdmin serves the admin API on ADMIN_ADDR.
+func serveAdmin(adminRegistrar *grpc.Server) {
+	lis, err := net.Listen("tcp", viper.GetString("ADMIN_ADDR"))
+	if err != nil {
+		log.Fatalf("cannot create admin listener: %s", err)
+	}
+	if err := adminRegistrar.Serve(lis); err != nil {
+		log.Fatalf("Impossible to serve admin API: %s", err)
+	}
+}
+
+// newEventsBackend picks the explore events backend from EXPLORE_EVENTS_BACKEND. The local
+// backend only reaches subscribers connected to this replica; postgres reaches all replicas.
+func newEventsBackend(dbConn *sql.DB) events.Backend {
+	switch backend := viper.GetString("EXPLORE_EVENTS_BACKEND"); backend {
+	case "local":
+		return events.NewLocalBackend()
+	case "postgres":
+		return events.NewPostgresBackend(dbConn, db.ConnectionString())
+	default:
+		log.Fatalf("Unknown explore events backend: %s", backend)
+		return nil
+	}
+}
+
+// newLikesCache picks the cache for like counts and likers from CACHE_BACKEND: none, which
+// yields nil, an LRU cache in this replica's memory, or Redis at REDIS_ADDR, which all
+// replicas share.
+func newLikesCache() cache.Cache {
+	switch backend := viper.GetString("CACHE_BACKEND"); backend {
+	case "none":
+		return nil
+	case "memory":
+		return cache.NewLRUCache(viper.GetInt("CACHE_LRU_SIZE"))
+	case "redis":
+		return cache.NewRedisCache(redis.NewClient(&redis.Options{Addr: viper.GetString("REDIS_ADDR")}))
+	default:
+		log.Fatalf("Unknown cache backend: %s", backend)
+		return nil
+	}
+}
+
+// newOutboxPublisher picks where outbox events are published from OUTBOX_PUBLISHER: stdout,
+// or a file of JSON lines at OUTBOX_FILE_PATH.
+func newOutboxPublisher() outbox.EventPublisher {
+	switch publisher := viper.GetString("OUTBOX_PUBLISHER"); publisher {
+	case "stdout":
+		return outbox.NewWriterPublisher(os.Stdout)
+	case "file":
+		file, err := os.OpenFile(viper.GetString("OUTBOX_FILE_PATH"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			log.Fatalf("Failed to open outbox file: %v", err)
+		}
+		return outbox.NewWriterPublisher(file)
+	default:
+		log.Fatalf("Unknown outbox publisher: %s", publisher)
+		return nil
+	}
+}
+
+// purgeExpiredRows deletes expired PutDecision idempotency keys and outbox events published
+// more than outboxRetention ago every hour until ctx is done.
+func purgeExpiredRows(
+	ctx context.Context,
+	exploreRepository repository.ExploreRepository,
+	outboxRepository repository.OutboxRepository,
+	ttl, outboxRetention time.Duration,
+) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := exploreRepository.DeleteExpiredIdempotencyKeys(ctx, ttl); err != nil {
+				log.Printf("Failed to purge idempotency keys: %v", err)
+			}
+			if _, err := outboxRepository.DeletePublishedOutboxEvents(ctx, outboxRetention); err != nil {
+				log.Printf("Failed to purge published outbox events: %v", err)
+			}
+		}
+	}
+}
@@ -25,6 +25,25 @@ func InitConfig() {
 	if err := bindEnvVariables(envVars); err != nil {
 		log.Fatalf("Error binding environment variable: %v, it might be empty", err)
 	}
+
+	// Optional settings fall back to these defaults when not set in the environment
+	viper.SetDefault("LOAD_MOCK_DATA", true)
+	viper.SetDefault("EXPLORE_DEFAULT_PAGE_SIZE", 10)
+	viper.SetDefault("EXPLORE_MAX_PAGE_SIZE", 100)
+	viper.SetDefault("EXPLORE_MAX_BATCH_DECISIONS", 100)
+	viper.SetDefault("EXPLORE_EVENTS_BACKEND", "local")
+	viper.SetDefault("OUTBOX_PUBLISHER", "stdout")
+	viper.SetDefault("OUTBOX_FILE_PATH", "outbox-events.jsonl")
+	viper.SetDefault("OUTBOX_POLL_INTERVAL", "1s")
+	viper.SetDefault("OUTBOX_RETENTION", "168h")
+	viper.SetDefault("IDEMPOTENCY_KEY_TTL", "24h")
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 10)
+	viper.SetDefault("WEBHOOK_TIMEOUT", "10s")
+	viper.SetDefault("ADMIN_ADDR", "127.0.0.1:8090")
+	viper.SetDefault("CACHE_BACKEND", "none")
+	viper.SetDefault("CACHE_TTL", "30s")
+	viper.SetDefault("CACHE_LRU_SIZE", 10000)
+	viper.SetDefault("REDIS_ADDR", "localhost:6379")
 }
 
 func bindEnvVariables(vars []string) error {
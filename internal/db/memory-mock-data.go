@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+// mockUsernames are the usernames of the users of mock/insert_mock_data.sql.
+var mockUsernames = []string{
+	"Alice", "Bob", "Charlie", "David", "Eva", "Frank", "Grace", "Hannah", "Ivy", "Jack",
+	"Katherine", "Liam", "Mia", "Noah", "Olivia", "Paul", "Quincy", "Rachel", "Sam", "Tina",
+}
+
+// mockUserIDs are the IDs of the users in mockUsernames.
+var mockUserIDs = func() []string {
+	userIDs := make([]string, len(mockUsernames))
+	for i := range userIDs {
+		userIDs[i] = fmt.Sprintf("00000000-0000-0000-0000-%012d", i+1)
+	}
+	return userIDs
+}()
+
+// mockLikes are the likes of mock/insert_mock_data.sql, as indexes into mockUserIDs.
+var mockLikes = [][2]int{
+	{0, 1},   // Alice likes Bob
+	{1, 0},   // Bob likes Alice (mutual)
+	{2, 3},   // Charlie likes David
+	{3, 2},   // David likes Charlie (mutual)
+	{4, 5},   // Eva likes Frank
+	{6, 7},   // Grace likes Hannah
+	{7, 6},   // Hannah likes Grace (mutual)
+	{8, 9},   // Ivy likes Jack
+	{10, 11}, // Katherine likes Liam
+	{11, 10}, // Liam likes Katherine (mutual)
+	{12, 13}, // Mia likes Noah
+	{14, 15}, // Olivia likes Paul
+	{16, 17}, // Quincy likes Rachel
+	{17, 16}, // Rachel likes Quincy (mutual)
+	{18, 19}, // Sam likes Tina
+	{1, 2},   // Bob likes Charlie
+	{3, 0},   // David likes Alice
+	{5, 8},   // Frank likes Ivy
+	{9, 4},   // Jack likes Eva
+	{12, 10}, // Mia likes Katherine
+	{13, 14}, // Noah likes Olivia
+}
+
+// LoadMemoryMockData loads the same users and likes as LoadMockData into an in-memory
+// repository, along with a random sample of passes.
+func LoadMemoryMockData(repo *repository.MemoryRepository) error {
+	for i, userID := range mockUserIDs {
+		repo.AddUser(userID, mockUsernames[i])
+	}
+
+	ctx := context.Background()
+	err := repo.WithTx(ctx, func(tx repository.Tx) error {
+		decided := make(map[repository.UserPair]bool)
+		for _, like := range mockLikes {
+			pair := repository.UserPair{ActorUserID: mockUserIDs[like[0]], RecipientUserID: mockUserIDs[like[1]]}
+			if err := repo.InsertLike(ctx, tx, pair.ActorUserID, pair.RecipientUserID); err != nil {
+				return err
+			}
+			if err := repo.InsertDecision(ctx, tx, pair.ActorUserID, pair.RecipientUserID, true); err != nil {
+				return err
+			}
+			decided[pair] = true
+		}
+
+		// Leave most pairs undecided to simulate users who haven't seen each other yet
+		passes := 0
+		for _, actorUserID := range mockUserIDs {
+			for _, recipientUserID := range mockUserIDs {
+				pair := repository.UserPair{ActorUserID: actorUserID, RecipientUserID: recipientUserID}
+				if actorUserID == recipientUserID || decided[pair] || rand.Float64() <= 0.7 || passes == 100 {
+					continue
+				}
+				if err := repo.InsertDecision(ctx, tx, actorUserID, recipientUserID, false); err != nil {
+					return err
+				}
+				passes++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Println("Mock data loaded successfully!")
+	return nil
+}
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_likes_recipient_created_actor;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_likes_recipient_created_actor ON likes(recipient_user_id, created_at DESC, actor_user_id DESC);
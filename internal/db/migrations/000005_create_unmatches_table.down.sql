@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_unmatches_actor_recipient;
+DROP TABLE IF EXISTS unmatches;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS unmatches (
+    id SERIAL PRIMARY KEY,
+    actor_user_id UUID NOT NULL,
+    recipient_user_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (actor_user_id) REFERENCES users(user_id),
+    FOREIGN KEY (recipient_user_id) REFERENCES users(user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_unmatches_actor_recipient ON unmatches(actor_user_id, recipient_user_id);
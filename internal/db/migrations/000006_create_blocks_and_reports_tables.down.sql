@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_reports_reported_user_id;
+DROP TABLE IF EXISTS reports;
+DROP INDEX IF EXISTS idx_blocks_blocked_blocker;
+DROP TABLE IF EXISTS blocks;
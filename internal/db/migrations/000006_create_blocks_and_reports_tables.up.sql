@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS blocks (
+    blocker_user_id UUID NOT NULL,
+    blocked_user_id UUID NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blocker_user_id, blocked_user_id),
+    FOREIGN KEY (blocker_user_id) REFERENCES users(user_id),
+    FOREIGN KEY (blocked_user_id) REFERENCES users(user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_blocks_blocked_blocker ON blocks(blocked_user_id, blocker_user_id);
+
+CREATE TABLE IF NOT EXISTS reports (
+    id SERIAL PRIMARY KEY,
+    reporter_user_id UUID NOT NULL,
+    reported_user_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (reporter_user_id) REFERENCES users(user_id),
+    FOREIGN KEY (reported_user_id) REFERENCES users(user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_reported_user_id ON reports(reported_user_id);
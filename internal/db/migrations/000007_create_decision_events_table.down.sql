@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_decision_events_actor_recipient;
+DROP TABLE IF EXISTS decision_events;
+DROP INDEX IF EXISTS idx_decisions_actor_updated_recipient;
+ALTER TABLE decisions DROP COLUMN IF EXISTS updated_at;
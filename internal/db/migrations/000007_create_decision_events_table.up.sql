@@ -0,0 +1,16 @@
+ALTER TABLE decisions ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+UPDATE decisions SET updated_at = created_at WHERE created_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_decisions_actor_updated_recipient ON decisions(actor_user_id, updated_at DESC, recipient_user_id DESC);
+
+CREATE TABLE IF NOT EXISTS decision_events (
+    id BIGSERIAL PRIMARY KEY,
+    actor_user_id UUID NOT NULL,
+    recipient_user_id UUID NOT NULL,
+    liked_recipient BOOLEAN NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (actor_user_id) REFERENCES users(user_id),
+    FOREIGN KEY (recipient_user_id) REFERENCES users(user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_decision_events_actor_recipient ON decision_events(actor_user_id, recipient_user_id);
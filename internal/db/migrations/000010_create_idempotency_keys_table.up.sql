@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    actor_user_id UUID NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    recipient_user_id UUID NOT NULL,
+    liked_recipient BOOLEAN NOT NULL,
+    mutual_likes BOOLEAN,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (actor_user_id, idempotency_key),
+    FOREIGN KEY (actor_user_id) REFERENCES users(user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys(created_at);
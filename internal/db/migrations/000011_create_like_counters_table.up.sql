@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS like_counters (
+    user_id UUID PRIMARY KEY,
+    received BIGINT NOT NULL DEFAULT 0,
+    new_received BIGINT NOT NULL DEFAULT 0,
+    matches BIGINT NOT NULL DEFAULT 0,
+    FOREIGN KEY (user_id) REFERENCES users(user_id)
+);
+
+-- Count the likes recorded before the counters existed
+INSERT INTO like_counters (user_id, received, new_received, matches)
+SELECT likes.recipient_user_id,
+       COUNT(*),
+       COUNT(*) FILTER (WHERE decisions.actor_user_id IS NULL),
+       COUNT(given.actor_user_id)
+FROM likes
+LEFT JOIN likes given
+  ON given.actor_user_id = likes.recipient_user_id
+ AND given.recipient_user_id = likes.actor_user_id
+LEFT JOIN decisions
+  ON decisions.actor_user_id = likes.recipient_user_id
+ AND decisions.recipient_user_id = likes.actor_user_id
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM unmatches
+    WHERE (unmatches.actor_user_id = likes.actor_user_id AND unmatches.recipient_user_id = likes.recipient_user_id)
+       OR (unmatches.actor_user_id = likes.recipient_user_id AND unmatches.recipient_user_id = likes.actor_user_id)
+)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM blocks
+    WHERE (blocks.blocker_user_id = likes.actor_user_id AND blocks.blocked_user_id = likes.recipient_user_id)
+       OR (blocks.blocker_user_id = likes.recipient_user_id AND blocks.blocked_user_id = likes.actor_user_id)
+)
+GROUP BY likes.recipient_user_id
+ON CONFLICT (user_id) DO NOTHING;
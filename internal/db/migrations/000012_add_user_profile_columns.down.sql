@@ -0,0 +1,4 @@
+ALTER TABLE users DROP COLUMN IF EXISTS bio;
+ALTER TABLE users DROP COLUMN IF EXISTS gender;
+ALTER TABLE users DROP COLUMN IF EXISTS birthdate;
+ALTER TABLE users DROP COLUMN IF EXISTS display_name;
@@ -0,0 +1,4 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS display_name VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS birthdate DATE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS gender VARCHAR(16) CHECK (gender IN ('female', 'male', 'non_binary'));
+ALTER TABLE users ADD COLUMN IF NOT EXISTS bio TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DELETE FROM reports WHERE reporter_user_id IS NULL OR reported_user_id IS NULL;
+ALTER TABLE reports ALTER COLUMN reported_user_id SET NOT NULL;
+ALTER TABLE reports ALTER COLUMN reporter_user_id SET NOT NULL;
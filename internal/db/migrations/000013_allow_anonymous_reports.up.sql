@@ -0,0 +1,3 @@
+-- Reports outlive the users they name: deleting a user's data clears their ID from reports
+ALTER TABLE reports ALTER COLUMN reporter_user_id DROP NOT NULL;
+ALTER TABLE reports ALTER COLUMN reported_user_id DROP NOT NULL;
@@ -0,0 +1,2 @@
+-- Published events are purged once they are older than OUTBOX_RETENTION
+CREATE INDEX IF NOT EXISTS idx_outbox_published ON outbox(published_at) WHERE published_at IS NOT NULL;
@@ -1,7 +1,13 @@
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
+DELETE FROM like_counters;
 DELETE FROM likes;
 DELETE FROM decisions;
+DELETE FROM unmatches;
+DELETE FROM blocks;
+DELETE FROM reports;
+DELETE FROM decision_events;
+DELETE FROM idempotency_keys;
 DELETE FROM users;
 
 -- Insert mock data into users table with UUIDs
@@ -83,3 +89,18 @@ WHERE
 )
   AND random() > 0.7 -- Randomly include some users with liked = FALSE
 LIMIT 100; -- Limit the number of inserts for testing
+
+-- Count the mock likes, as the repository does for likes recorded through the API
+INSERT INTO like_counters (user_id, received, new_received, matches)
+SELECT likes.recipient_user_id,
+       COUNT(*),
+       COUNT(*) FILTER (WHERE decisions.actor_user_id IS NULL),
+       COUNT(given.actor_user_id)
+FROM likes
+LEFT JOIN likes given
+  ON given.actor_user_id = likes.recipient_user_id
+ AND given.recipient_user_id = likes.actor_user_id
+LEFT JOIN decisions
+  ON decisions.actor_user_id = likes.recipient_user_id
+ AND decisions.recipient_user_id = likes.actor_user_id
+GROUP BY likes.recipient_user_id;
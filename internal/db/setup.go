@@ -12,15 +12,20 @@ import (
 	"os"
 )
 
-func ConnectDB() (*sql.DB, error) {
+// ConnectionString builds the Postgres connection string from the configured environment.
+func ConnectionString() string {
 	dbHost := viper.GetString("POSTGRES_HOST")
 	dbPort := viper.GetString("POSTGRES_PORT")
 	dbUser := viper.GetString("POSTGRES_USER")
 	dbPassword := viper.GetString("POSTGRES_PASSWORD")
 	dbName := viper.GetString("POSTGRES_DB")
 
-	connectionString := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
 		dbHost, dbPort, dbUser, dbPassword, dbName)
+}
+
+func ConnectDB() (*sql.DB, error) {
+	connectionString := ConnectionString()
 
 	log.Printf("Connection URL: %s", connectionString)
 
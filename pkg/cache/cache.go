@@ -0,0 +1,22 @@
+// Package cache provides the key-value caches used to serve hot reads without a database query.
+//
+// Values are opaque bytes stored under string keys with a time to live. LRUCache keeps them
+// in the memory of a single process, while RedisCache shares them between every server
+// replica through Redis.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores values for a limited time. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, and false if there is none or it has expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key until ttl, which must be positive, has passed, replacing any
+	// previous value.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes the values stored under the keys. Missing keys are ignored.
+	Delete(ctx context.Context, keys ...string) error
+}
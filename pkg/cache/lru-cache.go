@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// LRUCache is an in-process cache that holds up to a fixed number of values and evicts the
+// least recently used one to make room for a new one. Expired values are removed when they
+// are next read.
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	// order holds the entries from most to least recently used
+	order *list.List
+	now   func() time.Time
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRUCache creates an LRU cache holding at most capacity values. A capacity below one is
+// treated as one.
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: max(capacity, 1),
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+// Get returns the value stored under key and marks it as recently used.
+func (c *LRUCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(element)
+		return nil, false, nil
+	}
+
+	c.order.MoveToFront(element)
+	return slices.Clone(entry.value), true, nil
+}
+
+// Set stores value under key, evicting the least recently used value if the cache is full.
+func (c *LRUCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &lruEntry{key: key, value: slices.Clone(value), expiresAt: c.now().Add(ttl)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return nil
+	}
+
+	c.entries[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+// Delete removes the values stored under the keys.
+func (c *LRUCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.remove(element)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of values held, including expired ones not yet removed.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *LRUCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).key)
+}
@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(2)
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, cache.Set(ctx, "b", []byte("2"), time.Minute))
+
+	// Reading a makes b the least recently used
+	_, ok, err := cache.Get(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.NoError(t, cache.Set(ctx, "c", []byte("3"), time.Minute))
+
+	_, ok, _ = cache.Get(ctx, "b")
+	assert.False(t, ok)
+	value, ok, _ := cache.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestLRUCache_ExpiresValues(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(10)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+
+	now = now.Add(59 * time.Second)
+	_, ok, _ := cache.Get(ctx, "a")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok, _ = cache.Get(ctx, "a")
+	assert.False(t, ok)
+	assert.Zero(t, cache.Len(), "expired values are removed when read")
+}
+
+func TestLRUCache_SetReplacesAndDeleteRemoves(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(10)
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, cache.Set(ctx, "a", []byte("2"), time.Minute))
+	value, _, _ := cache.Get(ctx, "a")
+	assert.Equal(t, []byte("2"), value)
+
+	// Values are copied, so callers cannot change what is cached
+	value[0] = 'x'
+	value, _, _ = cache.Get(ctx, "a")
+	assert.Equal(t, []byte("2"), value)
+
+	require.NoError(t, cache.Delete(ctx, "a", "missing"))
+	_, ok, _ := cache.Get(ctx, "a")
+	assert.False(t, ok)
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRedisPrefix is prepended to every key stored by a RedisCache, so the cache can share
+// a Redis database with other data.
+const DefaultRedisPrefix = "explore:"
+
+// RedisCache stores values in Redis, where every server replica sees the same values and
+// invalidations. Expiry is left to Redis.
+type RedisCache struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisCache creates a cache on top of the given Redis client.
+func NewRedisCache(client redis.UniversalClient) *RedisCache {
+	return &RedisCache{client: client, prefix: DefaultRedisPrefix}
+}
+
+// Get returns the value stored under key.
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get %s from redis: %w", key, err)
+	}
+	return value, true, nil
+}
+
+// Set stores value under key with the given time to live.
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.client.Set(ctx, c.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to set %s in redis: %w", key, err)
+	}
+	return nil
+}
+
+// Delete removes the values stored under the keys in a single command.
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + key
+	}
+	if err := c.client.Del(ctx, prefixed...).Err(); err != nil {
+		return fmt.Errorf("failed to delete keys from redis: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRedis(t *testing.T) (*RedisCache, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisCache(client), server
+}
+
+func TestRedisCache_SetGetDelete(t *testing.T) {
+	ctx := context.Background()
+	cache, server := setupRedis(t)
+
+	_, ok, err := cache.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, cache.Set(ctx, "b", []byte("2"), time.Minute))
+	assert.True(t, server.Exists(DefaultRedisPrefix+"a"), "keys are prefixed")
+
+	value, ok, err := cache.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+
+	require.NoError(t, cache.Delete(ctx, "a", "b", "missing"))
+	_, ok, err = cache.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRedisCache_ExpiresValues(t *testing.T) {
+	ctx := context.Background()
+	cache, server := setupRedis(t)
+
+	require.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	server.FastForward(time.Minute)
+
+	_, ok, err := cache.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRedisCache_ReportsUnavailableServer(t *testing.T) {
+	ctx := context.Background()
+	cache, server := setupRedis(t)
+	server.Close()
+
+	_, _, err := cache.Get(ctx, "a")
+	assert.Error(t, err)
+	assert.Error(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+}
@@ -0,0 +1,100 @@
+// Package events provides an in-process pub/sub hub for real-time explore events.
+//
+// Events are published through a Backend, which decides how they reach the hubs that
+// deliver them to subscribers. LocalBackend keeps everything inside one process, while
+// PostgresBackend fans events out to every server replica using LISTEN/NOTIFY.
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	explore "muzz-backend-challenge/pkg/proto"
+)
+
+// subscriberBuffer is the number of events queued per subscriber before new ones are dropped.
+const subscriberBuffer = 16
+
+// Event is an explore event addressed to a single user.
+type Event struct {
+	UserID  string
+	Payload *explore.ExploreEvent
+}
+
+// Backend transports published events to every hub listening on it.
+type Backend interface {
+	// Publish sends the event to all listeners, including those on other replicas.
+	Publish(ctx context.Context, event Event) error
+	// Listen calls deliver for every event published on the backend until ctx is done.
+	Listen(ctx context.Context, deliver func(Event)) error
+}
+
+// Hub delivers events from a Backend to the subscribers of each user.
+type Hub struct {
+	backend     Backend
+	mu          sync.RWMutex
+	subscribers map[string]map[chan *explore.ExploreEvent]struct{}
+}
+
+// NewHub creates a new Hub on top of the given backend. Run must be called for
+// subscribers to receive events.
+func NewHub(backend Backend) *Hub {
+	return &Hub{
+		backend:     backend,
+		subscribers: make(map[string]map[chan *explore.ExploreEvent]struct{}),
+	}
+}
+
+// Run listens on the backend and dispatches events to subscribers until ctx is done.
+func (h *Hub) Run(ctx context.Context) error {
+	return h.backend.Listen(ctx, h.dispatch)
+}
+
+// Publish sends an event to the given user's subscribers through the backend.
+func (h *Hub) Publish(ctx context.Context, userID string, event *explore.ExploreEvent) error {
+	return h.backend.Publish(ctx, Event{UserID: userID, Payload: event})
+}
+
+// Subscribe registers interest in the events addressed to the given user. The returned
+// function must be called to release the subscription; it closes the channel.
+func (h *Hub) Subscribe(userID string) (<-chan *explore.ExploreEvent, func()) {
+	events := make(chan *explore.ExploreEvent, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan *explore.ExploreEvent]struct{})
+	}
+	h.subscribers[userID][events] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[userID], events)
+			if len(h.subscribers[userID]) == 0 {
+				delete(h.subscribers, userID)
+			}
+			h.mu.Unlock()
+			close(events)
+		})
+	}
+
+	return events, cancel
+}
+
+// dispatch hands an event to every subscriber of its user. Subscribers that are not
+// keeping up miss the event rather than blocking the hub.
+func (h *Hub) dispatch(event Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for subscriber := range h.subscribers[event.UserID] {
+		select {
+		case subscriber <- event.Payload:
+		default:
+			log.Printf("Dropping explore event for slow subscriber of user %s", event.UserID)
+		}
+	}
+}
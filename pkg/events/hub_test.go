@@ -0,0 +1,98 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	explore "muzz-backend-challenge/pkg/proto"
+)
+
+func likeReceived(actorID string) *explore.ExploreEvent {
+	return &explore.ExploreEvent{
+		UnixTimestamp: 1700000000,
+		Event: &explore.ExploreEvent_LikeReceived_{
+			LikeReceived: &explore.ExploreEvent_LikeReceived{ActorId: actorID},
+		},
+	}
+}
+
+func startHub(t *testing.T) *Hub {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	hub := NewHub(NewLocalBackend())
+	go hub.Run(ctx)
+	return hub
+}
+
+// receive publishes until the event arrives, since the hub starts listening asynchronously.
+func receive(t *testing.T, hub *Hub, events <-chan *explore.ExploreEvent, userID string, event *explore.ExploreEvent) *explore.ExploreEvent {
+	var received *explore.ExploreEvent
+	require.Eventually(t, func() bool {
+		require.NoError(t, hub.Publish(context.Background(), userID, event))
+		select {
+		case received = <-events:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+	return received
+}
+
+func TestHubDeliversToSubscribersOfUser(t *testing.T) {
+	hub := startHub(t)
+
+	events, cancel := hub.Subscribe("user-1")
+	defer cancel()
+	otherEvents, otherCancel := hub.Subscribe("user-2")
+	defer otherCancel()
+
+	event := likeReceived("user-3")
+	received := receive(t, hub, events, "user-1", event)
+
+	assert.True(t, proto.Equal(event, received))
+	assert.Empty(t, otherEvents)
+}
+
+func TestHubCancelClosesSubscription(t *testing.T) {
+	hub := startHub(t)
+
+	events, cancel := hub.Subscribe("user-1")
+	cancel()
+	cancel()
+
+	_, ok := <-events
+	assert.False(t, ok)
+
+	// Publishing to a user without subscribers is a no-op
+	assert.NoError(t, hub.Publish(context.Background(), "user-1", likeReceived("user-3")))
+}
+
+func TestHubDropsEventsForSlowSubscribers(t *testing.T) {
+	hub := NewHub(NewLocalBackend())
+
+	events, cancel := hub.Subscribe("user-1")
+	defer cancel()
+
+	for i := 0; i < subscriberBuffer+5; i++ {
+		hub.dispatch(Event{UserID: "user-1", Payload: likeReceived("user-3")})
+	}
+
+	assert.Len(t, events, subscriberBuffer)
+}
+
+func TestDecodeNotification(t *testing.T) {
+	event, err := decodeNotification(`{"user_id":"user-1","event":{"unixTimestamp":"1700000000","likeReceived":{"actorId":"user-3"}}}`)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", event.UserID)
+	assert.True(t, proto.Equal(likeReceived("user-3"), event.Payload))
+
+	_, err = decodeNotification("not json")
+	assert.Error(t, err)
+}
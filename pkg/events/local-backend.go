@@ -0,0 +1,45 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// LocalBackend delivers events to the hubs listening on it within the same process.
+type LocalBackend struct {
+	mu        sync.RWMutex
+	listeners map[int]func(Event)
+	nextID    int
+}
+
+// NewLocalBackend creates a new in-process backend.
+func NewLocalBackend() *LocalBackend {
+	return &LocalBackend{listeners: make(map[int]func(Event))}
+}
+
+// Publish delivers the event to every current listener.
+func (b *LocalBackend) Publish(_ context.Context, event Event) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, deliver := range b.listeners {
+		deliver(event)
+	}
+	return nil
+}
+
+// Listen registers deliver and blocks until ctx is done.
+func (b *LocalBackend) Listen(ctx context.Context, deliver func(Event)) error {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.listeners[id] = deliver
+	b.mu.Unlock()
+
+	<-ctx.Done()
+
+	b.mu.Lock()
+	delete(b.listeners, id)
+	b.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,117 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/encoding/protojson"
+	explore "muzz-backend-challenge/pkg/proto"
+)
+
+// DefaultChannel is the Postgres notification channel used for explore events.
+const DefaultChannel = "explore_events"
+
+const (
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+	listenerPingInterval = 90 * time.Second
+)
+
+// notification is the JSON payload sent through NOTIFY.
+type notification struct {
+	UserID string          `json:"user_id"`
+	Event  json.RawMessage `json:"event"`
+}
+
+// PostgresBackend fans events out to every replica through Postgres LISTEN/NOTIFY.
+//
+// Events are published with pg_notify on the shared database connection and received on a
+// dedicated listener connection. Events sent while a listener is reconnecting are lost, which
+// is acceptable for real-time notifications that clients can recover by listing.
+type PostgresBackend struct {
+	db               *sql.DB
+	connectionString string
+	channel          string
+}
+
+// NewPostgresBackend creates a backend that publishes through db and listens on a separate
+// connection opened with connectionString.
+func NewPostgresBackend(db *sql.DB, connectionString string) *PostgresBackend {
+	return &PostgresBackend{db: db, connectionString: connectionString, channel: DefaultChannel}
+}
+
+// Publish sends the event to every listening replica.
+func (b *PostgresBackend) Publish(ctx context.Context, event Event) error {
+	payload, err := protojson.Marshal(event.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	message, err := json.Marshal(notification{UserID: event.UserID, Event: payload})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	if _, err := b.db.ExecContext(ctx, "SELECT pg_notify($1, $2)", b.channel, string(message)); err != nil {
+		return fmt.Errorf("failed to notify: %w", err)
+	}
+	return nil
+}
+
+// Listen receives notifications and calls deliver for each event until ctx is done.
+func (b *PostgresBackend) Listen(ctx context.Context, deliver func(Event)) error {
+	listener := pq.NewListener(b.connectionString, listenerMinReconnect, listenerMaxReconnect,
+		func(eventType pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Printf("Explore events listener error: %v", err)
+			}
+		})
+	defer listener.Close()
+
+	if err := listener.Listen(b.channel); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", b.channel, err)
+	}
+
+	ticker := time.NewTicker(listenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			// A nil notification means the connection was re-established
+			if n == nil {
+				continue
+			}
+			event, err := decodeNotification(n.Extra)
+			if err != nil {
+				log.Printf("Dropping malformed explore event: %v", err)
+				continue
+			}
+			deliver(event)
+		case <-ticker.C:
+			go listener.Ping()
+		}
+	}
+}
+
+// decodeNotification parses a NOTIFY payload produced by Publish.
+func decodeNotification(payload string) (Event, error) {
+	var message notification
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		return Event{}, err
+	}
+
+	event := &explore.ExploreEvent{}
+	if err := protojson.Unmarshal(message.Event, event); err != nil {
+		return Event{}, err
+	}
+
+	return Event{UserID: message.UserID, Payload: event}, nil
+}
@@ -0,0 +1,30 @@
+// Package outbox relays events written to the transactional outbox to an EventPublisher.
+//
+// Events are queued in the same transaction as the change they describe, so they are never
+// lost when the change commits and never emitted when it rolls back. A Relay claims pending
+// events and publishes them with at-least-once delivery: consumers must tolerate duplicates,
+// which they can detect through the event ID.
+package outbox
+
+// Event types written to the outbox.
+const (
+	// EventDecisionRecorded is emitted for every decision recorded by PutDecision.
+	EventDecisionRecorded = "decision.recorded"
+	// EventMatchCreated is emitted when a like makes two users match.
+	EventMatchCreated = "match.created"
+)
+
+// DecisionRecorded is the payload of an EventDecisionRecorded event.
+type DecisionRecorded struct {
+	ActorUserID     string `json:"actor_user_id"`
+	RecipientUserID string `json:"recipient_user_id"`
+	LikedRecipient  bool   `json:"liked_recipient"`
+	UnixTimestamp   uint64 `json:"unix_timestamp"`
+}
+
+// MatchCreated is the payload of an EventMatchCreated event. UserIDs holds the user whose
+// like completed the match first.
+type MatchCreated struct {
+	UserIDs       [2]string `json:"user_ids"`
+	UnixTimestamp uint64    `json:"unix_timestamp"`
+}
@@ -0,0 +1,31 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+
+	"muzz-backend-challenge/pkg/repository"
+)
+
+// FanOutPublisher publishes every event to several publishers.
+type FanOutPublisher struct {
+	publishers []EventPublisher
+}
+
+// NewFanOutPublisher creates a publisher that forwards events to each of publishers.
+func NewFanOutPublisher(publishers ...EventPublisher) *FanOutPublisher {
+	return &FanOutPublisher{publishers: publishers}
+}
+
+// Publish forwards the event to every publisher, even if some fail. The event is reported as
+// failed if any publisher fails, so it is retried on all of them: publishers already receive
+// events at least once and must tolerate the duplicates.
+func (p *FanOutPublisher) Publish(ctx context.Context, event repository.OutboxEvent) error {
+	var errs []error
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
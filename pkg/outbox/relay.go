@@ -0,0 +1,165 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"muzz-backend-challenge/pkg/repository"
+)
+
+const (
+	// DefaultBatchSize is the number of events claimed per batch.
+	DefaultBatchSize = 100
+	// DefaultPollInterval is how long the relay waits after draining the outbox.
+	DefaultPollInterval = time.Second
+	// DefaultLease is how long a claimed event stays hidden from other relays.
+	DefaultLease = 30 * time.Second
+	// DefaultBaseBackoff is the delay before the first retry of a failed event.
+	DefaultBaseBackoff = time.Second
+	// DefaultMaxBackoff caps the delay between retries of a failed event.
+	DefaultMaxBackoff = 5 * time.Minute
+)
+
+// EventPublisher delivers outbox events to the outside world. Publish may be called more
+// than once for the same event.
+type EventPublisher interface {
+	Publish(ctx context.Context, event repository.OutboxEvent) error
+}
+
+// Relay moves events from the outbox to an EventPublisher.
+type Relay struct {
+	store        repository.OutboxRepository
+	publisher    EventPublisher
+	batchSize    int
+	pollInterval time.Duration
+	lease        time.Duration
+	baseBackoff  time.Duration
+	maxBackoff   time.Duration
+}
+
+// Option configures optional settings of a Relay.
+type Option func(*Relay)
+
+// WithBatchSize sets the number of events claimed per batch.
+func WithBatchSize(size int) Option {
+	return func(relay *Relay) {
+		if size > 0 {
+			relay.batchSize = size
+		}
+	}
+}
+
+// WithPollInterval sets how long the relay waits before polling an empty outbox again.
+func WithPollInterval(interval time.Duration) Option {
+	return func(relay *Relay) {
+		if interval > 0 {
+			relay.pollInterval = interval
+		}
+	}
+}
+
+// WithLease sets how long a claimed event stays hidden from other relays. It must be longer
+// than publishing a batch takes, or events will be published twice.
+func WithLease(lease time.Duration) Option {
+	return func(relay *Relay) {
+		if lease > 0 {
+			relay.lease = lease
+		}
+	}
+}
+
+// WithBackoff sets the delay before the first retry of a failed event and the cap the
+// doubling delay never exceeds.
+func WithBackoff(base, max time.Duration) Option {
+	return func(relay *Relay) {
+		if base > 0 && max >= base {
+			relay.baseBackoff = base
+			relay.maxBackoff = max
+		}
+	}
+}
+
+// NewRelay creates a new Relay draining store into publisher.
+func NewRelay(store repository.OutboxRepository, publisher EventPublisher, opts ...Option) *Relay {
+	relay := &Relay{
+		store:        store,
+		publisher:    publisher,
+		batchSize:    DefaultBatchSize,
+		pollInterval: DefaultPollInterval,
+		lease:        DefaultLease,
+		baseBackoff:  DefaultBaseBackoff,
+		maxBackoff:   DefaultMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(relay)
+	}
+	return relay
+}
+
+// Run relays batches until ctx is done. Full batches are followed immediately by the next
+// one; otherwise the relay waits for the poll interval.
+func (relay *Relay) Run(ctx context.Context) {
+	for {
+		relayed, err := relay.RelayBatch(ctx)
+		if err != nil {
+			log.Printf("Failed to relay outbox events: %v", err)
+		}
+
+		if err == nil && relayed == relay.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(relay.pollInterval):
+		}
+	}
+}
+
+// RelayBatch claims a batch of pending events and publishes them in order. Failed events are
+// scheduled for a retry with exponential backoff. It returns the number of events claimed.
+func (relay *Relay) RelayBatch(ctx context.Context) (int, error) {
+	events, err := relay.store.ClaimOutboxEvents(ctx, relay.batchSize, relay.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, event := range events {
+		if err := relay.publisher.Publish(ctx, event); err != nil {
+			retryIn := relay.backoff(event.Attempts)
+			log.Printf("Failed to publish outbox event %d (attempt %d), retrying in %s: %v", event.ID, event.Attempts, retryIn, err)
+			if err := relay.store.MarkOutboxEventFailed(ctx, event.ID, retryIn, err.Error()); err != nil {
+				// The lease expires and the event is retried anyway
+				log.Printf("Failed to mark outbox event %d failed: %v", event.ID, err)
+			}
+			continue
+		}
+
+		if err := relay.store.MarkOutboxEventPublished(ctx, event.ID); err != nil {
+			// The lease expires and the event is published again
+			log.Printf("Failed to mark outbox event %d published: %v", event.ID, err)
+		}
+	}
+
+	return len(events), nil
+}
+
+// backoff returns the delay before retrying an event that has failed the given number of attempts.
+func (relay *Relay) backoff(attempts int) time.Duration {
+	return Backoff(attempts, relay.baseBackoff, relay.maxBackoff)
+}
+
+// Backoff returns the delay before retrying an operation that has failed the given number of
+// attempts: the base delay doubled for each attempt after the first, capped at max.
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	return delay
+}
@@ -0,0 +1,174 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+// fakeStore is an in-memory outbox that records how events were settled.
+type fakeStore struct {
+	mu        sync.Mutex
+	pending   []repository.OutboxEvent
+	claimErr  error
+	published []int64
+	failed    map[int64]time.Duration
+}
+
+func (s *fakeStore) ClaimOutboxEvents(_ context.Context, limit int, _ time.Duration) ([]repository.OutboxEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.claimErr != nil {
+		return nil, s.claimErr
+	}
+	if limit > len(s.pending) {
+		limit = len(s.pending)
+	}
+	claimed := s.pending[:limit]
+	s.pending = s.pending[limit:]
+	for i := range claimed {
+		claimed[i].Attempts++
+	}
+	return claimed, nil
+}
+
+// publishedIDs returns the IDs marked published so far.
+func (s *fakeStore) publishedIDs() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]int64(nil), s.published...)
+}
+
+func (s *fakeStore) MarkOutboxEventPublished(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.published = append(s.published, id)
+	return nil
+}
+
+func (s *fakeStore) MarkOutboxEventFailed(_ context.Context, id int64, retryIn time.Duration, _ string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failed == nil {
+		s.failed = make(map[int64]time.Duration)
+	}
+	s.failed[id] = retryIn
+	return nil
+}
+
+func (s *fakeStore) DeletePublishedOutboxEvents(context.Context, time.Duration) (int64, error) {
+	return 0, nil
+}
+
+// fakePublisher records published events and fails for the IDs in failing.
+type fakePublisher struct {
+	failing map[int64]bool
+	events  []repository.OutboxEvent
+}
+
+func (p *fakePublisher) Publish(_ context.Context, event repository.OutboxEvent) error {
+	if p.failing[event.ID] {
+		return errors.New("endpoint down")
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestRelayBatch(t *testing.T) {
+	store := &fakeStore{pending: []repository.OutboxEvent{
+		{ID: 1, EventType: EventDecisionRecorded},
+		{ID: 2, EventType: EventMatchCreated},
+		{ID: 3, EventType: EventDecisionRecorded},
+	}}
+	publisher := &fakePublisher{failing: map[int64]bool{2: true}}
+	relay := NewRelay(store, publisher, WithBatchSize(2), WithBackoff(time.Second, time.Minute))
+
+	relayed, err := relay.RelayBatch(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, relayed)
+	assert.Equal(t, []int64{1}, store.published)
+	assert.Equal(t, map[int64]time.Duration{2: time.Second}, store.failed)
+	require.Len(t, publisher.events, 1)
+	assert.Equal(t, int64(1), publisher.events[0].ID)
+	assert.Len(t, store.pending, 1)
+}
+
+func TestRelayBatch_ClaimError(t *testing.T) {
+	store := &fakeStore{claimErr: errors.New("database down")}
+	relay := NewRelay(store, &fakePublisher{})
+
+	relayed, err := relay.RelayBatch(context.Background())
+
+	assert.Error(t, err)
+	assert.Zero(t, relayed)
+}
+
+func TestRelayBackoff(t *testing.T) {
+	relay := NewRelay(&fakeStore{}, &fakePublisher{}, WithBackoff(time.Second, 10*time.Second))
+
+	assert.Equal(t, time.Second, relay.backoff(1))
+	assert.Equal(t, 2*time.Second, relay.backoff(2))
+	assert.Equal(t, 8*time.Second, relay.backoff(4))
+	assert.Equal(t, 10*time.Second, relay.backoff(5))
+	assert.Equal(t, 10*time.Second, relay.backoff(100))
+}
+
+func TestRelayRun_StopsWhenContextIsDone(t *testing.T) {
+	store := &fakeStore{pending: []repository.OutboxEvent{{ID: 1}}}
+	publisher := &fakePublisher{}
+	relay := NewRelay(store, publisher, WithPollInterval(time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return len(store.publishedIDs()) == 1 }, time.Second, time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Equal(t, []int64{1}, store.publishedIDs())
+}
+
+func TestWriterPublisher(t *testing.T) {
+	var buffer bytes.Buffer
+	publisher := NewWriterPublisher(&buffer)
+
+	err := publisher.Publish(context.Background(), repository.OutboxEvent{
+		ID:        7,
+		EventType: EventMatchCreated,
+		Payload:   []byte(`{"user_ids":["a","b"],"unix_timestamp":1}`),
+		Attempts:  1,
+		CreatedAt: time.Unix(1700000000, 0),
+	})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":7,"type":"match.created","attempts":1,"created_at":1700000000,"payload":{"user_ids":["a","b"],"unix_timestamp":1}}`,
+		buffer.String())
+	assert.Equal(t, byte('\n'), buffer.Bytes()[buffer.Len()-1])
+}
+
+func TestFanOutPublisher(t *testing.T) {
+	healthy := &fakePublisher{}
+	failing := &fakePublisher{failing: map[int64]bool{1: true}}
+	publisher := NewFanOutPublisher(failing, healthy)
+
+	err := publisher.Publish(context.Background(), repository.OutboxEvent{ID: 1})
+
+	assert.Error(t, err)
+	require.Len(t, healthy.events, 1, "a failing publisher must not stop the others")
+}
@@ -0,0 +1,54 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"muzz-backend-challenge/pkg/repository"
+)
+
+// WriterPublisher writes each event as a line of JSON. It is meant for local testing, with
+// os.Stdout or a file as the writer.
+type WriterPublisher struct {
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// envelope is the JSON representation of an event written by WriterPublisher.
+type envelope struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	Attempts  int             `json:"attempts"`
+	CreatedAt int64           `json:"created_at"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// NewWriterPublisher creates a publisher writing to w.
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{writer: w}
+}
+
+// Publish writes the event to the underlying writer.
+func (p *WriterPublisher) Publish(_ context.Context, event repository.OutboxEvent) error {
+	line, err := json.Marshal(envelope{
+		ID:        event.ID,
+		Type:      event.EventType,
+		Attempts:  event.Attempts,
+		CreatedAt: event.CreatedAt.Unix(),
+		Payload:   event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox event: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.writer.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write outbox event: %w", err)
+	}
+	return nil
+}
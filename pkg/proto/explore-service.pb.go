@@ -20,6 +20,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DecisionFilter int32
+
+const (
+	DecisionFilter_DECISION_FILTER_ALL    DecisionFilter = 0
+	DecisionFilter_DECISION_FILTER_LIKES  DecisionFilter = 1
+	DecisionFilter_DECISION_FILTER_PASSES DecisionFilter = 2
+)
+
+// Enum value maps for DecisionFilter.
+var (
+	DecisionFilter_name = map[int32]string{
+		0: "DECISION_FILTER_ALL",
+		1: "DECISION_FILTER_LIKES",
+		2: "DECISION_FILTER_PASSES",
+	}
+	DecisionFilter_value = map[string]int32{
+		"DECISION_FILTER_ALL":    0,
+		"DECISION_FILTER_LIKES":  1,
+		"DECISION_FILTER_PASSES": 2,
+	}
+)
+
+func (x DecisionFilter) Enum() *DecisionFilter {
+	p := new(DecisionFilter)
+	*p = x
+	return p
+}
+
+func (x DecisionFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DecisionFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_explore_service_proto_enumTypes[0].Descriptor()
+}
+
+func (DecisionFilter) Type() protoreflect.EnumType {
+	return &file_explore_service_proto_enumTypes[0]
+}
+
+func (x DecisionFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DecisionFilter.Descriptor instead.
+func (DecisionFilter) EnumDescriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{0}
+}
+
 type ListLikedYouRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -27,6 +76,10 @@ type ListLikedYouRequest struct {
 
 	RecipientUserId string  `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
 	PaginationToken *string `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	// Number of likers to return. Defaults to the server's page size when unset.
+	PageSize *uint32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	// Return each liker's profile along with them, saving a GetUser call per liker.
+	IncludeProfile bool `protobuf:"varint,4,opt,name=include_profile,json=includeProfile,proto3" json:"include_profile,omitempty"`
 }
 
 func (x *ListLikedYouRequest) Reset() {
@@ -75,6 +128,20 @@ func (x *ListLikedYouRequest) GetPaginationToken() string {
 	return ""
 }
 
+func (x *ListLikedYouRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListLikedYouRequest) GetIncludeProfile() bool {
+	if x != nil {
+		return x.IncludeProfile
+	}
+	return false
+}
+
 type ListLikedYouResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -130,6 +197,96 @@ func (x *ListLikedYouResponse) GetNextPaginationToken() string {
 	return ""
 }
 
+// The public profile of a liker, as in the User message of the UserService.
+type LikerProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username    string `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	DisplayName string `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	// Date of birth as YYYY-MM-DD, empty when unknown.
+	Birthdate string `protobuf:"bytes,3,opt,name=birthdate,proto3" json:"birthdate,omitempty"`
+	Gender    Gender `protobuf:"varint,4,opt,name=gender,proto3,enum=explore.Gender" json:"gender,omitempty"`
+	Bio       string `protobuf:"bytes,5,opt,name=bio,proto3" json:"bio,omitempty"`
+	// When the liker joined.
+	UnixTimestamp uint64 `protobuf:"varint,6,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+}
+
+func (x *LikerProfile) Reset() {
+	*x = LikerProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LikerProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikerProfile) ProtoMessage() {}
+
+func (x *LikerProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikerProfile.ProtoReflect.Descriptor instead.
+func (*LikerProfile) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LikerProfile) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LikerProfile) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *LikerProfile) GetBirthdate() string {
+	if x != nil {
+		return x.Birthdate
+	}
+	return ""
+}
+
+func (x *LikerProfile) GetGender() Gender {
+	if x != nil {
+		return x.Gender
+	}
+	return Gender_GENDER_UNSPECIFIED
+}
+
+func (x *LikerProfile) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *LikerProfile) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
 type CountLikedYouRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -141,7 +298,7 @@ type CountLikedYouRequest struct {
 func (x *CountLikedYouRequest) Reset() {
 	*x = CountLikedYouRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_explore_service_proto_msgTypes[2]
+		mi := &file_explore_service_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -154,7 +311,7 @@ func (x *CountLikedYouRequest) String() string {
 func (*CountLikedYouRequest) ProtoMessage() {}
 
 func (x *CountLikedYouRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_explore_service_proto_msgTypes[2]
+	mi := &file_explore_service_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -167,7 +324,7 @@ func (x *CountLikedYouRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountLikedYouRequest.ProtoReflect.Descriptor instead.
 func (*CountLikedYouRequest) Descriptor() ([]byte, []int) {
-	return file_explore_service_proto_rawDescGZIP(), []int{2}
+	return file_explore_service_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CountLikedYouRequest) GetRecipientUserId() string {
@@ -188,7 +345,7 @@ type CountLikedYouResponse struct {
 func (x *CountLikedYouResponse) Reset() {
 	*x = CountLikedYouResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_explore_service_proto_msgTypes[3]
+		mi := &file_explore_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -201,7 +358,7 @@ func (x *CountLikedYouResponse) String() string {
 func (*CountLikedYouResponse) ProtoMessage() {}
 
 func (x *CountLikedYouResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_explore_service_proto_msgTypes[3]
+	mi := &file_explore_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -214,7 +371,7 @@ func (x *CountLikedYouResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CountLikedYouResponse.ProtoReflect.Descriptor instead.
 func (*CountLikedYouResponse) Descriptor() ([]byte, []int) {
-	return file_explore_service_proto_rawDescGZIP(), []int{3}
+	return file_explore_service_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CountLikedYouResponse) GetCount() uint64 {
@@ -232,12 +389,15 @@ type PutDecisionRequest struct {
 	ActorUserId     string `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
 	RecipientUserId string `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
 	LikedRecipient  bool   `protobuf:"varint,3,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	// Client-generated key identifying this decision. Retrying with the same key returns the
+	// original response without recording the decision again.
+	IdempotencyKey *string `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
 }
 
 func (x *PutDecisionRequest) Reset() {
 	*x = PutDecisionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_explore_service_proto_msgTypes[4]
+		mi := &file_explore_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -250,7 +410,7 @@ func (x *PutDecisionRequest) String() string {
 func (*PutDecisionRequest) ProtoMessage() {}
 
 func (x *PutDecisionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_explore_service_proto_msgTypes[4]
+	mi := &file_explore_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -263,7 +423,7 @@ func (x *PutDecisionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutDecisionRequest.ProtoReflect.Descriptor instead.
 func (*PutDecisionRequest) Descriptor() ([]byte, []int) {
-	return file_explore_service_proto_rawDescGZIP(), []int{4}
+	return file_explore_service_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PutDecisionRequest) GetActorUserId() string {
@@ -287,6 +447,13 @@ func (x *PutDecisionRequest) GetLikedRecipient() bool {
 	return false
 }
 
+func (x *PutDecisionRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
 type PutDecisionResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -298,7 +465,7 @@ type PutDecisionResponse struct {
 func (x *PutDecisionResponse) Reset() {
 	*x = PutDecisionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_explore_service_proto_msgTypes[5]
+		mi := &file_explore_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -311,7 +478,7 @@ func (x *PutDecisionResponse) String() string {
 func (*PutDecisionResponse) ProtoMessage() {}
 
 func (x *PutDecisionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_explore_service_proto_msgTypes[5]
+	mi := &file_explore_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,7 +491,7 @@ func (x *PutDecisionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PutDecisionResponse.ProtoReflect.Descriptor instead.
 func (*PutDecisionResponse) Descriptor() ([]byte, []int) {
-	return file_explore_service_proto_rawDescGZIP(), []int{5}
+	return file_explore_service_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PutDecisionResponse) GetMutualLikes() bool {
@@ -334,32 +501,33 @@ func (x *PutDecisionResponse) GetMutualLikes() bool {
 	return false
 }
 
-type ListLikedYouResponse_Liker struct {
+type PutDecisionsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ActorId       string `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
-	UnixTimestamp uint64 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	Decisions []*PutDecisionRequest `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	// When set, no decision is recorded unless all of them can be.
+	AllOrNothing bool `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
 }
 
-func (x *ListLikedYouResponse_Liker) Reset() {
-	*x = ListLikedYouResponse_Liker{}
+func (x *PutDecisionsRequest) Reset() {
+	*x = PutDecisionsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_explore_service_proto_msgTypes[6]
+		mi := &file_explore_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListLikedYouResponse_Liker) String() string {
+func (x *PutDecisionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListLikedYouResponse_Liker) ProtoMessage() {}
+func (*PutDecisionsRequest) ProtoMessage() {}
 
-func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
-	mi := &file_explore_service_proto_msgTypes[6]
+func (x *PutDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -370,183 +538,2028 @@ func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListLikedYouResponse_Liker.ProtoReflect.Descriptor instead.
-func (*ListLikedYouResponse_Liker) Descriptor() ([]byte, []int) {
-	return file_explore_service_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use PutDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*PutDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListLikedYouResponse_Liker) GetActorId() string {
+func (x *PutDecisionsRequest) GetDecisions() []*PutDecisionRequest {
 	if x != nil {
-		return x.ActorId
+		return x.Decisions
 	}
-	return ""
+	return nil
 }
 
-func (x *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
+func (x *PutDecisionsRequest) GetAllOrNothing() bool {
 	if x != nil {
-		return x.UnixTimestamp
+		return x.AllOrNothing
 	}
-	return 0
+	return false
 }
 
-var File_explore_service_proto protoreflect.FileDescriptor
+type PutDecisionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_explore_service_proto_rawDesc = []byte{
-	0x0a, 0x15, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65,
-	0x22, 0x86, 0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f,
-	0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x69,
-	0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x73,
-	0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x10, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65,
-	0x6e, 0x88, 0x01, 0x01, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xf1, 0x01, 0x0a, 0x14, 0x4c, 0x69,
-	0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x3b, 0x0a, 0x06, 0x6c, 0x69, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x72, 0x52, 0x06, 0x6c, 0x69, 0x6b, 0x65, 0x72, 0x73, 0x12,
-	0x37, 0x0a, 0x15, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00,
-	0x52, 0x13, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x1a, 0x49, 0x0a, 0x05, 0x4c, 0x69, 0x6b, 0x65,
-	0x72, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e,
-	0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67,
-	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x42, 0x0a,
-	0x14, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
-	0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49,
-	0x64, 0x22, 0x2d, 0x0a, 0x15, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59,
-	0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x22, 0x8d, 0x01, 0x0a, 0x12, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72,
-	0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x61, 0x63, 0x74, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x72,
-	0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e,
-	0x74, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x69, 0x6b, 0x65, 0x64,
-	0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x0e, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74,
-	0x22, 0x38, 0x0a, 0x13, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x75, 0x74, 0x75, 0x61,
-	0x6c, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x6d,
-	0x75, 0x74, 0x75, 0x61, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x32, 0xc7, 0x02, 0x0a, 0x0e, 0x45,
-	0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a,
-	0x0c, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x12, 0x1c, 0x2e,
-	0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65,
-	0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78,
-	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59,
-	0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x4c, 0x69,
-	0x73, 0x74, 0x4e, 0x65, 0x77, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x12, 0x1c, 0x2e,
-	0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65,
-	0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78,
-	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59,
-	0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x12, 0x1d, 0x2e, 0x65, 0x78,
-	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64,
-	0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x65, 0x78, 0x70,
-	0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59,
-	0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x50, 0x75,
-	0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x65, 0x78, 0x70, 0x6c,
-	0x6f, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65,
-	0x2e, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2a, 0x5a, 0x28, 0x6d, 0x75, 0x7a, 0x7a, 0x2d, 0x62, 0x61, 0x63,
-	0x6b, 0x65, 0x6e, 0x64, 0x2d, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x2f, 0x70,
-	0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// One result per decision, in request order.
+	Results []*PutDecisionsResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-var (
-	file_explore_service_proto_rawDescOnce sync.Once
-	file_explore_service_proto_rawDescData = file_explore_service_proto_rawDesc
-)
+func (x *PutDecisionsResponse) Reset() {
+	*x = PutDecisionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_explore_service_proto_rawDescGZIP() []byte {
-	file_explore_service_proto_rawDescOnce.Do(func() {
-		file_explore_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_explore_service_proto_rawDescData)
-	})
-	return file_explore_service_proto_rawDescData
+func (x *PutDecisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_explore_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_explore_service_proto_goTypes = []any{
-	(*ListLikedYouRequest)(nil),        // 0: explore.ListLikedYouRequest
-	(*ListLikedYouResponse)(nil),       // 1: explore.ListLikedYouResponse
-	(*CountLikedYouRequest)(nil),       // 2: explore.CountLikedYouRequest
-	(*CountLikedYouResponse)(nil),      // 3: explore.CountLikedYouResponse
-	(*PutDecisionRequest)(nil),         // 4: explore.PutDecisionRequest
-	(*PutDecisionResponse)(nil),        // 5: explore.PutDecisionResponse
-	(*ListLikedYouResponse_Liker)(nil), // 6: explore.ListLikedYouResponse.Liker
+func (*PutDecisionsResponse) ProtoMessage() {}
+
+func (x *PutDecisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_explore_service_proto_depIdxs = []int32{
-	6, // 0: explore.ListLikedYouResponse.likers:type_name -> explore.ListLikedYouResponse.Liker
-	0, // 1: explore.ExploreService.ListLikedYou:input_type -> explore.ListLikedYouRequest
-	0, // 2: explore.ExploreService.ListNewLikedYou:input_type -> explore.ListLikedYouRequest
-	2, // 3: explore.ExploreService.CountLikedYou:input_type -> explore.CountLikedYouRequest
-	4, // 4: explore.ExploreService.PutDecision:input_type -> explore.PutDecisionRequest
-	1, // 5: explore.ExploreService.ListLikedYou:output_type -> explore.ListLikedYouResponse
-	1, // 6: explore.ExploreService.ListNewLikedYou:output_type -> explore.ListLikedYouResponse
-	3, // 7: explore.ExploreService.CountLikedYou:output_type -> explore.CountLikedYouResponse
-	5, // 8: explore.ExploreService.PutDecision:output_type -> explore.PutDecisionResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+
+// Deprecated: Use PutDecisionsResponse.ProtoReflect.Descriptor instead.
+func (*PutDecisionsResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{8}
 }
 
-func init() { file_explore_service_proto_init() }
-func file_explore_service_proto_init() {
-	if File_explore_service_proto != nil {
-		return
+func (x *PutDecisionsResponse) GetResults() []*PutDecisionsResponse_Result {
+	if x != nil {
+		return x.Results
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_explore_service_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*ListLikedYouRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_explore_service_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*ListLikedYouResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+type ListMatchesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId          string  `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaginationToken *string `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+}
+
+func (x *ListMatchesRequest) Reset() {
+	*x = ListMatchesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesRequest) ProtoMessage() {}
+
+func (x *ListMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_explore_service_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*CountLikedYouRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesRequest.ProtoReflect.Descriptor instead.
+func (*ListMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListMatchesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListMatchesRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListMatchesRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+type ListMatchesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Matches             []*ListMatchesResponse_Match `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	NextPaginationToken *string                      `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+}
+
+func (x *ListMatchesResponse) Reset() {
+	*x = ListMatchesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesResponse) ProtoMessage() {}
+
+func (x *ListMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesResponse.ProtoReflect.Descriptor instead.
+func (*ListMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListMatchesResponse) GetMatches() []*ListMatchesResponse_Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *ListMatchesResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type CountMatchesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *CountMatchesRequest) Reset() {
+	*x = CountMatchesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountMatchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountMatchesRequest) ProtoMessage() {}
+
+func (x *CountMatchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountMatchesRequest.ProtoReflect.Descriptor instead.
+func (*CountMatchesRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CountMatchesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CountMatchesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *CountMatchesResponse) Reset() {
+	*x = CountMatchesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountMatchesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountMatchesResponse) ProtoMessage() {}
+
+func (x *CountMatchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountMatchesResponse.ProtoReflect.Descriptor instead.
+func (*CountMatchesResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CountMatchesResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type UnmatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorUserId     string `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	RecipientUserId string `protobuf:"bytes,2,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+}
+
+func (x *UnmatchRequest) Reset() {
+	*x = UnmatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnmatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmatchRequest) ProtoMessage() {}
+
+func (x *UnmatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmatchRequest.ProtoReflect.Descriptor instead.
+func (*UnmatchRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UnmatchRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *UnmatchRequest) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+type UnmatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UnmatchResponse) Reset() {
+	*x = UnmatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnmatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmatchResponse) ProtoMessage() {}
+
+func (x *UnmatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmatchResponse.ProtoReflect.Descriptor instead.
+func (*UnmatchResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{14}
+}
+
+type BlockUserRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorUserId   string `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	BlockedUserId string `protobuf:"bytes,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+}
+
+func (x *BlockUserRequest) Reset() {
+	*x = BlockUserRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserRequest) ProtoMessage() {}
+
+func (x *BlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserRequest.ProtoReflect.Descriptor instead.
+func (*BlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *BlockUserRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *BlockUserRequest) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+type BlockUserResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BlockUserResponse) Reset() {
+	*x = BlockUserResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockUserResponse) ProtoMessage() {}
+
+func (x *BlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockUserResponse.ProtoReflect.Descriptor instead.
+func (*BlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{16}
+}
+
+type UnblockUserRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorUserId   string `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	BlockedUserId string `protobuf:"bytes,2,opt,name=blocked_user_id,json=blockedUserId,proto3" json:"blocked_user_id,omitempty"`
+}
+
+func (x *UnblockUserRequest) Reset() {
+	*x = UnblockUserRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnblockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserRequest) ProtoMessage() {}
+
+func (x *UnblockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnblockUserRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnblockUserRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *UnblockUserRequest) GetBlockedUserId() string {
+	if x != nil {
+		return x.BlockedUserId
+	}
+	return ""
+}
+
+type UnblockUserResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UnblockUserResponse) Reset() {
+	*x = UnblockUserResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnblockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnblockUserResponse) ProtoMessage() {}
+
+func (x *UnblockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnblockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnblockUserResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{18}
+}
+
+type ReportUserRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorUserId    string `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	ReportedUserId string `protobuf:"bytes,2,opt,name=reported_user_id,json=reportedUserId,proto3" json:"reported_user_id,omitempty"`
+	Reason         string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *ReportUserRequest) Reset() {
+	*x = ReportUserRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportUserRequest) ProtoMessage() {}
+
+func (x *ReportUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportUserRequest.ProtoReflect.Descriptor instead.
+func (*ReportUserRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ReportUserRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *ReportUserRequest) GetReportedUserId() string {
+	if x != nil {
+		return x.ReportedUserId
+	}
+	return ""
+}
+
+func (x *ReportUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReportUserResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ReportUserResponse) Reset() {
+	*x = ReportUserResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReportUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportUserResponse) ProtoMessage() {}
+
+func (x *ReportUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportUserResponse.ProtoReflect.Descriptor instead.
+func (*ReportUserResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{20}
+}
+
+type ListDecisionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorUserId     string         `protobuf:"bytes,1,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	PaginationToken *string        `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32        `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+	Filter          DecisionFilter `protobuf:"varint,4,opt,name=filter,proto3,enum=explore.DecisionFilter" json:"filter,omitempty"`
+}
+
+func (x *ListDecisionsRequest) Reset() {
+	*x = ListDecisionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDecisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDecisionsRequest) ProtoMessage() {}
+
+func (x *ListDecisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDecisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListDecisionsRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListDecisionsRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *ListDecisionsRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *ListDecisionsRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDecisionsRequest) GetFilter() DecisionFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return DecisionFilter_DECISION_FILTER_ALL
+}
+
+type ListDecisionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Decisions           []*ListDecisionsResponse_Decision `protobuf:"bytes,1,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	NextPaginationToken *string                           `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+}
+
+func (x *ListDecisionsResponse) Reset() {
+	*x = ListDecisionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDecisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDecisionsResponse) ProtoMessage() {}
+
+func (x *ListDecisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDecisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListDecisionsResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListDecisionsResponse) GetDecisions() []*ListDecisionsResponse_Decision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+func (x *ListDecisionsResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type GetExploreFeedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId          string  `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaginationToken *string `protobuf:"bytes,2,opt,name=pagination_token,json=paginationToken,proto3,oneof" json:"pagination_token,omitempty"`
+	PageSize        *uint32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3,oneof" json:"page_size,omitempty"`
+}
+
+func (x *GetExploreFeedRequest) Reset() {
+	*x = GetExploreFeedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExploreFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExploreFeedRequest) ProtoMessage() {}
+
+func (x *GetExploreFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExploreFeedRequest.ProtoReflect.Descriptor instead.
+func (*GetExploreFeedRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetExploreFeedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetExploreFeedRequest) GetPaginationToken() string {
+	if x != nil && x.PaginationToken != nil {
+		return *x.PaginationToken
+	}
+	return ""
+}
+
+func (x *GetExploreFeedRequest) GetPageSize() uint32 {
+	if x != nil && x.PageSize != nil {
+		return *x.PageSize
+	}
+	return 0
+}
+
+type GetExploreFeedResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Candidates in the order of the server's ranking strategy, by default users who liked the
+	// requester first. A pagination token only resumes the feed in the order it was issued for.
+	Candidates          []*GetExploreFeedResponse_Candidate `protobuf:"bytes,1,rep,name=candidates,proto3" json:"candidates,omitempty"`
+	NextPaginationToken *string                             `protobuf:"bytes,2,opt,name=next_pagination_token,json=nextPaginationToken,proto3,oneof" json:"next_pagination_token,omitempty"`
+}
+
+func (x *GetExploreFeedResponse) Reset() {
+	*x = GetExploreFeedResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExploreFeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExploreFeedResponse) ProtoMessage() {}
+
+func (x *GetExploreFeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExploreFeedResponse.ProtoReflect.Descriptor instead.
+func (*GetExploreFeedResponse) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetExploreFeedResponse) GetCandidates() []*GetExploreFeedResponse_Candidate {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+func (x *GetExploreFeedResponse) GetNextPaginationToken() string {
+	if x != nil && x.NextPaginationToken != nil {
+		return *x.NextPaginationToken
+	}
+	return ""
+}
+
+type SubscribeExploreEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *SubscribeExploreEventsRequest) Reset() {
+	*x = SubscribeExploreEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeExploreEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeExploreEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeExploreEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeExploreEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeExploreEventsRequest) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SubscribeExploreEventsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ExploreEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UnixTimestamp uint64 `protobuf:"varint,1,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	// Types that are assignable to Event:
+	//	*ExploreEvent_LikeReceived_
+	//	*ExploreEvent_MatchCreated_
+	Event isExploreEvent_Event `protobuf_oneof:"event"`
+}
+
+func (x *ExploreEvent) Reset() {
+	*x = ExploreEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExploreEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExploreEvent) ProtoMessage() {}
+
+func (x *ExploreEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExploreEvent.ProtoReflect.Descriptor instead.
+func (*ExploreEvent) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ExploreEvent) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (m *ExploreEvent) GetEvent() isExploreEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *ExploreEvent) GetLikeReceived() *ExploreEvent_LikeReceived {
+	if x, ok := x.GetEvent().(*ExploreEvent_LikeReceived_); ok {
+		return x.LikeReceived
+	}
+	return nil
+}
+
+func (x *ExploreEvent) GetMatchCreated() *ExploreEvent_MatchCreated {
+	if x, ok := x.GetEvent().(*ExploreEvent_MatchCreated_); ok {
+		return x.MatchCreated
+	}
+	return nil
+}
+
+type isExploreEvent_Event interface {
+	isExploreEvent_Event()
+}
+
+type ExploreEvent_LikeReceived_ struct {
+	LikeReceived *ExploreEvent_LikeReceived `protobuf:"bytes,2,opt,name=like_received,json=likeReceived,proto3,oneof"`
+}
+
+type ExploreEvent_MatchCreated_ struct {
+	MatchCreated *ExploreEvent_MatchCreated `protobuf:"bytes,3,opt,name=match_created,json=matchCreated,proto3,oneof"`
+}
+
+func (*ExploreEvent_LikeReceived_) isExploreEvent_Event() {}
+
+func (*ExploreEvent_MatchCreated_) isExploreEvent_Event() {}
+
+type ListLikedYouResponse_Liker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorId       string `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	UnixTimestamp uint64 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+	// Only set when the request has include_profile.
+	Profile *LikerProfile `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+}
+
+func (x *ListLikedYouResponse_Liker) Reset() {
+	*x = ListLikedYouResponse_Liker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListLikedYouResponse_Liker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLikedYouResponse_Liker) ProtoMessage() {}
+
+func (x *ListLikedYouResponse_Liker) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLikedYouResponse_Liker.ProtoReflect.Descriptor instead.
+func (*ListLikedYouResponse_Liker) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *ListLikedYouResponse_Liker) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *ListLikedYouResponse_Liker) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+func (x *ListLikedYouResponse_Liker) GetProfile() *LikerProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+type PutDecisionsResponse_Error struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A google.rpc.Code value, as returned by PutDecision for the same failure.
+	Code    int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *PutDecisionsResponse_Error) Reset() {
+	*x = PutDecisionsResponse_Error{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutDecisionsResponse_Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutDecisionsResponse_Error) ProtoMessage() {}
+
+func (x *PutDecisionsResponse_Error) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutDecisionsResponse_Error.ProtoReflect.Descriptor instead.
+func (*PutDecisionsResponse_Error) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *PutDecisionsResponse_Error) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *PutDecisionsResponse_Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PutDecisionsResponse_Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MutualLikes bool `protobuf:"varint,1,opt,name=mutual_likes,json=mutualLikes,proto3" json:"mutual_likes,omitempty"`
+	// Set when the decision was not recorded.
+	Error *PutDecisionsResponse_Error `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *PutDecisionsResponse_Result) Reset() {
+	*x = PutDecisionsResponse_Result{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutDecisionsResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutDecisionsResponse_Result) ProtoMessage() {}
+
+func (x *PutDecisionsResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutDecisionsResponse_Result.ProtoReflect.Descriptor instead.
+func (*PutDecisionsResponse_Result) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{8, 1}
+}
+
+func (x *PutDecisionsResponse_Result) GetMutualLikes() bool {
+	if x != nil {
+		return x.MutualLikes
+	}
+	return false
+}
+
+func (x *PutDecisionsResponse_Result) GetError() *PutDecisionsResponse_Error {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type ListMatchesResponse_Match struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Time of the later of the two likes that formed the match.
+	UnixTimestamp uint64 `protobuf:"varint,2,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+}
+
+func (x *ListMatchesResponse_Match) Reset() {
+	*x = ListMatchesResponse_Match{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListMatchesResponse_Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchesResponse_Match) ProtoMessage() {}
+
+func (x *ListMatchesResponse_Match) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchesResponse_Match.ProtoReflect.Descriptor instead.
+func (*ListMatchesResponse_Match) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{10, 0}
+}
+
+func (x *ListMatchesResponse_Match) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListMatchesResponse_Match) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type ListDecisionsResponse_Decision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RecipientUserId string `protobuf:"bytes,1,opt,name=recipient_user_id,json=recipientUserId,proto3" json:"recipient_user_id,omitempty"`
+	LikedRecipient  bool   `protobuf:"varint,2,opt,name=liked_recipient,json=likedRecipient,proto3" json:"liked_recipient,omitempty"`
+	// Time of the latest decision on the recipient.
+	UnixTimestamp uint64 `protobuf:"varint,3,opt,name=unix_timestamp,json=unixTimestamp,proto3" json:"unix_timestamp,omitempty"`
+}
+
+func (x *ListDecisionsResponse_Decision) Reset() {
+	*x = ListDecisionsResponse_Decision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDecisionsResponse_Decision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDecisionsResponse_Decision) ProtoMessage() {}
+
+func (x *ListDecisionsResponse_Decision) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDecisionsResponse_Decision.ProtoReflect.Descriptor instead.
+func (*ListDecisionsResponse_Decision) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{22, 0}
+}
+
+func (x *ListDecisionsResponse_Decision) GetRecipientUserId() string {
+	if x != nil {
+		return x.RecipientUserId
+	}
+	return ""
+}
+
+func (x *ListDecisionsResponse_Decision) GetLikedRecipient() bool {
+	if x != nil {
+		return x.LikedRecipient
+	}
+	return false
+}
+
+func (x *ListDecisionsResponse_Decision) GetUnixTimestamp() uint64 {
+	if x != nil {
+		return x.UnixTimestamp
+	}
+	return 0
+}
+
+type GetExploreFeedResponse_Candidate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId   string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// Set when the candidate has liked the requesting user.
+	LikedYou bool `protobuf:"varint,3,opt,name=liked_you,json=likedYou,proto3" json:"liked_you,omitempty"`
+}
+
+func (x *GetExploreFeedResponse_Candidate) Reset() {
+	*x = GetExploreFeedResponse_Candidate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExploreFeedResponse_Candidate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExploreFeedResponse_Candidate) ProtoMessage() {}
+
+func (x *GetExploreFeedResponse_Candidate) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExploreFeedResponse_Candidate.ProtoReflect.Descriptor instead.
+func (*GetExploreFeedResponse_Candidate) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{24, 0}
+}
+
+func (x *GetExploreFeedResponse_Candidate) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetExploreFeedResponse_Candidate) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GetExploreFeedResponse_Candidate) GetLikedYou() bool {
+	if x != nil {
+		return x.LikedYou
+	}
+	return false
+}
+
+// Sent to the recipient when another user likes them.
+type ExploreEvent_LikeReceived struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ActorId string `protobuf:"bytes,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+}
+
+func (x *ExploreEvent_LikeReceived) Reset() {
+	*x = ExploreEvent_LikeReceived{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExploreEvent_LikeReceived) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExploreEvent_LikeReceived) ProtoMessage() {}
+
+func (x *ExploreEvent_LikeReceived) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExploreEvent_LikeReceived.ProtoReflect.Descriptor instead.
+func (*ExploreEvent_LikeReceived) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{26, 0}
+}
+
+func (x *ExploreEvent_LikeReceived) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+// Sent to both users when a like results in a mutual match.
+type ExploreEvent_MatchCreated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+}
+
+func (x *ExploreEvent_MatchCreated) Reset() {
+	*x = ExploreEvent_MatchCreated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_explore_service_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExploreEvent_MatchCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExploreEvent_MatchCreated) ProtoMessage() {}
+
+func (x *ExploreEvent_MatchCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_explore_service_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExploreEvent_MatchCreated.ProtoReflect.Descriptor instead.
+func (*ExploreEvent_MatchCreated) Descriptor() ([]byte, []int) {
+	return file_explore_service_proto_rawDescGZIP(), []int{26, 1}
+}
+
+func (x *ExploreEvent_MatchCreated) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+var File_explore_service_proto protoreflect.FileDescriptor
+
+var file_explore_service_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65,
+	0x1a, 0x12, 0x75, 0x73, 0x65, 0x72, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdf, 0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b,
+	0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11,
+	0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
+	0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x10, 0x70, 0x61, 0x67, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x50, 0x72, 0x6f, 0x66,
+	0x69, 0x6c, 0x65, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0xa2, 0x02, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x4c,
+	0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3b, 0x0a, 0x06, 0x6c, 0x69, 0x6b, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x23, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69,
+	0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4c,
+	0x69, 0x6b, 0x65, 0x72, 0x52, 0x06, 0x6c, 0x69, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x37, 0x0a, 0x15,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x13, 0x6e,
+	0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x88, 0x01, 0x01, 0x1a, 0x7a, 0x0a, 0x05, 0x4c, 0x69, 0x6b, 0x65, 0x72, 0x12, 0x19,
+	0x0a, 0x08, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x6e, 0x69,
+	0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x2f, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x6b, 0x65,
+	0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xcd, 0x01, 0x0a, 0x0c,
+	0x4c, 0x69, 0x6b, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70,
+	0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x62,
+	0x69, 0x72, 0x74, 0x68, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x62, 0x69, 0x72, 0x74, 0x68, 0x64, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x67, 0x65, 0x6e,
+	0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x65, 0x78, 0x70, 0x6c,
+	0x6f, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x06, 0x67, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x62, 0x69, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x75, 0x6e,
+	0x69, 0x78, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x42, 0x0a, 0x14, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74,
+	0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22,
+	0x2d, 0x0a, 0x15, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xcf,
+	0x01, 0x0a, 0x12, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x5f, 0x72,
+	0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x6c, 0x69, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x2c,
+	0x0a, 0x0f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70,
+	0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4b, 0x65, 0x79, 0x88, 0x01, 0x01, 0x42, 0x12, 0x0a, 0x10,
+	0x5f, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79,
+	0x22, 0x38, 0x0a, 0x13, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x75, 0x74, 0x75, 0x61,
+	0x6c, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x6d,
+	0x75, 0x74, 0x75, 0x61, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x22, 0x76, 0x0a, 0x13, 0x50, 0x75,
+	0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x39, 0x0a, 0x09, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x50,
+	0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x09, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x0a, 0x0e,
+	0x61, 0x6c, 0x6c, 0x5f, 0x6f, 0x72, 0x5f, 0x6e, 0x6f, 0x74, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x4f, 0x72, 0x4e, 0x6f, 0x74, 0x68, 0x69,
+	0x6e, 0x67, 0x22, 0xf5, 0x01, 0x0a, 0x14, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x65,
+	0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x1a, 0x35, 0x0a, 0x05, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x1a, 0x66, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x6d, 0x75, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x6d, 0x75, 0x74, 0x75, 0x61, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x12,
+	0x39, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xa2, 0x01, 0x0a, 0x12, 0x4c,
+	0x69, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x10, 0x70, 0x61,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52,
+	0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x42, 0x13, 0x0a, 0x11,
+	0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22,
+	0xef, 0x01, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f,
+	0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x07, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x15, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x13, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x1a, 0x47,
+	0x0a, 0x05, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x25, 0x0a, 0x0e, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x22, 0x2e, 0x0a, 0x13, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x22, 0x2c, 0x0a, 0x14, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x60, 0x0a, 0x0e, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
+	0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x22, 0x11, 0x0a, 0x0f, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5e, 0x0a, 0x10, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x55, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65,
+	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x60, 0x0a, 0x12, 0x55, 0x6e, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x22, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x55, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x5f, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x65, 0x64, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x15, 0x0a, 0x13, 0x55,
+	0x6e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x79, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x55,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x14, 0x0a,
+	0x12, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0xe0, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0d,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x2e, 0x0a, 0x10, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0f, 0x70, 0x61,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01,
+	0x12, 0x20, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x88,
+	0x01, 0x01, 0x12, 0x2f, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x44, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x70, 0x61, 0x67,
+	0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0xba, 0x02, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x45, 0x0a, 0x09, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x37, 0x0a, 0x15, 0x6e, 0x65, 0x78, 0x74, 0x5f,
+	0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x13, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01,
+	0x1a, 0x86, 0x01, 0x0a, 0x08, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a,
+	0x11, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69,
+	0x65, 0x6e, 0x74, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6c, 0x69, 0x6b,
+	0x65, 0x64, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0e, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
+	0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x78,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0xa5, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x6c, 0x6f,
+	0x72, 0x65, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x10, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x0f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x08, 0x70, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x70, 0x61, 0x67,
+	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x0c, 0x0a,
+	0x0a, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x95, 0x02, 0x0a, 0x16,
+	0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x65, 0x78, 0x70,
+	0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x46,
+	0x65, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x73, 0x12, 0x37, 0x0a, 0x15, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x48, 0x00, 0x52, 0x13, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x88, 0x01, 0x01, 0x1a, 0x5d, 0x0a, 0x09, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x6c, 0x69, 0x6b, 0x65, 0x64, 0x5f, 0x79, 0x6f, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x42, 0x18, 0x0a, 0x16, 0x5f, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x38, 0x0a, 0x1d, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0xa8, 0x02,
+	0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x25,
+	0x0a, 0x0e, 0x75, 0x6e, 0x69, 0x78, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x75, 0x6e, 0x69, 0x78, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x49, 0x0a, 0x0d, 0x6c, 0x69, 0x6b, 0x65, 0x5f, 0x72, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x65,
+	0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
+	0x48, 0x00, 0x52, 0x0c, 0x6c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64,
+	0x12, 0x49, 0x0a, 0x0d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72,
+	0x65, 0x2e, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x1a, 0x29, 0x0a, 0x0c, 0x4c,
+	0x69, 0x6b, 0x65, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x49, 0x64, 0x1a, 0x27, 0x0a, 0x0c, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x42,
+	0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2a, 0x60, 0x0a, 0x0e, 0x44, 0x65, 0x63, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x45,
+	0x43, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x41, 0x4c,
+	0x4c, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x44, 0x45, 0x43, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x5f,
+	0x46, 0x49, 0x4c, 0x54, 0x45, 0x52, 0x5f, 0x4c, 0x49, 0x4b, 0x45, 0x53, 0x10, 0x01, 0x12, 0x1a,
+	0x0a, 0x16, 0x44, 0x45, 0x43, 0x49, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x46, 0x49, 0x4c, 0x54, 0x45,
+	0x52, 0x5f, 0x50, 0x41, 0x53, 0x53, 0x45, 0x53, 0x10, 0x02, 0x32, 0x8f, 0x09, 0x0a, 0x0e, 0x45,
+	0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b, 0x0a,
+	0x0c, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x12, 0x1c, 0x2e,
+	0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65,
+	0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59,
+	0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0f, 0x4c, 0x69,
+	0x73, 0x74, 0x4e, 0x65, 0x77, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x12, 0x1c, 0x2e,
+	0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65,
+	0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59,
+	0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0d, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x12, 0x1d, 0x2e, 0x65, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64,
+	0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x65, 0x78, 0x70,
+	0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59,
+	0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x10, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x4e, 0x65, 0x77, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x12, 0x1d,
+	0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69,
+	0x6b, 0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x6b,
+	0x65, 0x64, 0x59, 0x6f, 0x75, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a,
+	0x0b, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x2e, 0x65,
+	0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x78, 0x70, 0x6c,
+	0x6f, 0x72, 0x65, 0x2e, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x0c, 0x50, 0x75, 0x74, 0x44, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72,
+	0x65, 0x2e, 0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e,
+	0x50, 0x75, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d,
+	0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b,
+	0x0a, 0x0c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73, 0x12, 0x1c,
+	0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x65,
+	0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x07, 0x55,
+	0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x17, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65,
+	0x2e, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x6e, 0x6d, 0x61, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65,
+	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a,
+	0x0b, 0x55, 0x6e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1b, 0x2e, 0x65,
+	0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x55, 0x6e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x78, 0x70, 0x6c,
+	0x6f, 0x72, 0x65, 0x2e, 0x55, 0x6e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0a, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1b, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e,
+	0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x1d, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x63,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x46, 0x65, 0x65, 0x64,
+	0x12, 0x1e, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x59, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26, 0x2e, 0x65, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45,
+	0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x2e, 0x45, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42, 0x2a, 0x5a, 0x28,
+	0x6d, 0x75, 0x7a, 0x7a, 0x2d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2d, 0x63, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x3b, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_explore_service_proto_rawDescOnce sync.Once
+	file_explore_service_proto_rawDescData = file_explore_service_proto_rawDesc
+)
+
+func file_explore_service_proto_rawDescGZIP() []byte {
+	file_explore_service_proto_rawDescOnce.Do(func() {
+		file_explore_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_explore_service_proto_rawDescData)
+	})
+	return file_explore_service_proto_rawDescData
+}
+
+var file_explore_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_explore_service_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_explore_service_proto_goTypes = []any{
+	(DecisionFilter)(0),                      // 0: explore.DecisionFilter
+	(*ListLikedYouRequest)(nil),              // 1: explore.ListLikedYouRequest
+	(*ListLikedYouResponse)(nil),             // 2: explore.ListLikedYouResponse
+	(*LikerProfile)(nil),                     // 3: explore.LikerProfile
+	(*CountLikedYouRequest)(nil),             // 4: explore.CountLikedYouRequest
+	(*CountLikedYouResponse)(nil),            // 5: explore.CountLikedYouResponse
+	(*PutDecisionRequest)(nil),               // 6: explore.PutDecisionRequest
+	(*PutDecisionResponse)(nil),              // 7: explore.PutDecisionResponse
+	(*PutDecisionsRequest)(nil),              // 8: explore.PutDecisionsRequest
+	(*PutDecisionsResponse)(nil),             // 9: explore.PutDecisionsResponse
+	(*ListMatchesRequest)(nil),               // 10: explore.ListMatchesRequest
+	(*ListMatchesResponse)(nil),              // 11: explore.ListMatchesResponse
+	(*CountMatchesRequest)(nil),              // 12: explore.CountMatchesRequest
+	(*CountMatchesResponse)(nil),             // 13: explore.CountMatchesResponse
+	(*UnmatchRequest)(nil),                   // 14: explore.UnmatchRequest
+	(*UnmatchResponse)(nil),                  // 15: explore.UnmatchResponse
+	(*BlockUserRequest)(nil),                 // 16: explore.BlockUserRequest
+	(*BlockUserResponse)(nil),                // 17: explore.BlockUserResponse
+	(*UnblockUserRequest)(nil),               // 18: explore.UnblockUserRequest
+	(*UnblockUserResponse)(nil),              // 19: explore.UnblockUserResponse
+	(*ReportUserRequest)(nil),                // 20: explore.ReportUserRequest
+	(*ReportUserResponse)(nil),               // 21: explore.ReportUserResponse
+	(*ListDecisionsRequest)(nil),             // 22: explore.ListDecisionsRequest
+	(*ListDecisionsResponse)(nil),            // 23: explore.ListDecisionsResponse
+	(*GetExploreFeedRequest)(nil),            // 24: explore.GetExploreFeedRequest
+	(*GetExploreFeedResponse)(nil),           // 25: explore.GetExploreFeedResponse
+	(*SubscribeExploreEventsRequest)(nil),    // 26: explore.SubscribeExploreEventsRequest
+	(*ExploreEvent)(nil),                     // 27: explore.ExploreEvent
+	(*ListLikedYouResponse_Liker)(nil),       // 28: explore.ListLikedYouResponse.Liker
+	(*PutDecisionsResponse_Error)(nil),       // 29: explore.PutDecisionsResponse.Error
+	(*PutDecisionsResponse_Result)(nil),      // 30: explore.PutDecisionsResponse.Result
+	(*ListMatchesResponse_Match)(nil),        // 31: explore.ListMatchesResponse.Match
+	(*ListDecisionsResponse_Decision)(nil),   // 32: explore.ListDecisionsResponse.Decision
+	(*GetExploreFeedResponse_Candidate)(nil), // 33: explore.GetExploreFeedResponse.Candidate
+	(*ExploreEvent_LikeReceived)(nil),        // 34: explore.ExploreEvent.LikeReceived
+	(*ExploreEvent_MatchCreated)(nil),        // 35: explore.ExploreEvent.MatchCreated
+	(Gender)(0),                              // 36: explore.Gender
+}
+var file_explore_service_proto_depIdxs = []int32{
+	28, // 0: explore.ListLikedYouResponse.likers:type_name -> explore.ListLikedYouResponse.Liker
+	36, // 1: explore.LikerProfile.gender:type_name -> explore.Gender
+	6,  // 2: explore.PutDecisionsRequest.decisions:type_name -> explore.PutDecisionRequest
+	30, // 3: explore.PutDecisionsResponse.results:type_name -> explore.PutDecisionsResponse.Result
+	31, // 4: explore.ListMatchesResponse.matches:type_name -> explore.ListMatchesResponse.Match
+	0,  // 5: explore.ListDecisionsRequest.filter:type_name -> explore.DecisionFilter
+	32, // 6: explore.ListDecisionsResponse.decisions:type_name -> explore.ListDecisionsResponse.Decision
+	33, // 7: explore.GetExploreFeedResponse.candidates:type_name -> explore.GetExploreFeedResponse.Candidate
+	34, // 8: explore.ExploreEvent.like_received:type_name -> explore.ExploreEvent.LikeReceived
+	35, // 9: explore.ExploreEvent.match_created:type_name -> explore.ExploreEvent.MatchCreated
+	3,  // 10: explore.ListLikedYouResponse.Liker.profile:type_name -> explore.LikerProfile
+	29, // 11: explore.PutDecisionsResponse.Result.error:type_name -> explore.PutDecisionsResponse.Error
+	1,  // 12: explore.ExploreService.ListLikedYou:input_type -> explore.ListLikedYouRequest
+	1,  // 13: explore.ExploreService.ListNewLikedYou:input_type -> explore.ListLikedYouRequest
+	4,  // 14: explore.ExploreService.CountLikedYou:input_type -> explore.CountLikedYouRequest
+	4,  // 15: explore.ExploreService.CountNewLikedYou:input_type -> explore.CountLikedYouRequest
+	6,  // 16: explore.ExploreService.PutDecision:input_type -> explore.PutDecisionRequest
+	8,  // 17: explore.ExploreService.PutDecisions:input_type -> explore.PutDecisionsRequest
+	10, // 18: explore.ExploreService.ListMatches:input_type -> explore.ListMatchesRequest
+	12, // 19: explore.ExploreService.CountMatches:input_type -> explore.CountMatchesRequest
+	14, // 20: explore.ExploreService.Unmatch:input_type -> explore.UnmatchRequest
+	16, // 21: explore.ExploreService.BlockUser:input_type -> explore.BlockUserRequest
+	18, // 22: explore.ExploreService.UnblockUser:input_type -> explore.UnblockUserRequest
+	20, // 23: explore.ExploreService.ReportUser:input_type -> explore.ReportUserRequest
+	22, // 24: explore.ExploreService.ListDecisions:input_type -> explore.ListDecisionsRequest
+	24, // 25: explore.ExploreService.GetExploreFeed:input_type -> explore.GetExploreFeedRequest
+	26, // 26: explore.ExploreService.SubscribeExploreEvents:input_type -> explore.SubscribeExploreEventsRequest
+	2,  // 27: explore.ExploreService.ListLikedYou:output_type -> explore.ListLikedYouResponse
+	2,  // 28: explore.ExploreService.ListNewLikedYou:output_type -> explore.ListLikedYouResponse
+	5,  // 29: explore.ExploreService.CountLikedYou:output_type -> explore.CountLikedYouResponse
+	5,  // 30: explore.ExploreService.CountNewLikedYou:output_type -> explore.CountLikedYouResponse
+	7,  // 31: explore.ExploreService.PutDecision:output_type -> explore.PutDecisionResponse
+	9,  // 32: explore.ExploreService.PutDecisions:output_type -> explore.PutDecisionsResponse
+	11, // 33: explore.ExploreService.ListMatches:output_type -> explore.ListMatchesResponse
+	13, // 34: explore.ExploreService.CountMatches:output_type -> explore.CountMatchesResponse
+	15, // 35: explore.ExploreService.Unmatch:output_type -> explore.UnmatchResponse
+	17, // 36: explore.ExploreService.BlockUser:output_type -> explore.BlockUserResponse
+	19, // 37: explore.ExploreService.UnblockUser:output_type -> explore.UnblockUserResponse
+	21, // 38: explore.ExploreService.ReportUser:output_type -> explore.ReportUserResponse
+	23, // 39: explore.ExploreService.ListDecisions:output_type -> explore.ListDecisionsResponse
+	25, // 40: explore.ExploreService.GetExploreFeed:output_type -> explore.GetExploreFeedResponse
+	27, // 41: explore.ExploreService.SubscribeExploreEvents:output_type -> explore.ExploreEvent
+	27, // [27:42] is the sub-list for method output_type
+	12, // [12:27] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_explore_service_proto_init() }
+func file_explore_service_proto_init() {
+	if File_explore_service_proto != nil {
+		return
+	}
+	file_user_service_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_explore_service_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ListLikedYouRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ListLikedYouResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*LikerProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*CountLikedYouRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*CountLikedYouResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*PutDecisionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*PutDecisionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
 				return &v.unknownFields
 			default:
 				return nil
 			}
 		}
-		file_explore_service_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*CountLikedYouResponse); i {
+		file_explore_service_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*PutDecisionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -557,8 +2570,8 @@ func file_explore_service_proto_init() {
 				return nil
 			}
 		}
-		file_explore_service_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*PutDecisionRequest); i {
+		file_explore_service_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*PutDecisionsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -569,8 +2582,8 @@ func file_explore_service_proto_init() {
 				return nil
 			}
 		}
-		file_explore_service_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*PutDecisionResponse); i {
+		file_explore_service_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*ListMatchesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -581,7 +2594,211 @@ func file_explore_service_proto_init() {
 				return nil
 			}
 		}
-		file_explore_service_proto_msgTypes[6].Exporter = func(v any, i int) any {
+		file_explore_service_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*ListMatchesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*CountMatchesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*CountMatchesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*UnmatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*UnmatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*BlockUserResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*UnblockUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*UnblockUserResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*ReportUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*ReportUserResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*ListDecisionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*ListDecisionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*GetExploreFeedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*GetExploreFeedResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*SubscribeExploreEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*ExploreEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[27].Exporter = func(v any, i int) any {
 			switch v := v.(*ListLikedYouResponse_Liker); i {
 			case 0:
 				return &v.state
@@ -593,21 +2810,117 @@ func file_explore_service_proto_init() {
 				return nil
 			}
 		}
+		file_explore_service_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*PutDecisionsResponse_Error); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*PutDecisionsResponse_Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*ListMatchesResponse_Match); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*ListDecisionsResponse_Decision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*GetExploreFeedResponse_Candidate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*ExploreEvent_LikeReceived); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_explore_service_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*ExploreEvent_MatchCreated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_explore_service_proto_msgTypes[0].OneofWrappers = []any{}
 	file_explore_service_proto_msgTypes[1].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[5].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[9].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[10].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[21].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[22].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[23].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[24].OneofWrappers = []any{}
+	file_explore_service_proto_msgTypes[26].OneofWrappers = []any{
+		(*ExploreEvent_LikeReceived_)(nil),
+		(*ExploreEvent_MatchCreated_)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_explore_service_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_explore_service_proto_goTypes,
 		DependencyIndexes: file_explore_service_proto_depIdxs,
+		EnumInfos:         file_explore_service_proto_enumTypes,
 		MessageInfos:      file_explore_service_proto_msgTypes,
 	}.Build()
 	File_explore_service_proto = out.File
@@ -19,10 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	ExploreService_ListLikedYou_FullMethodName    = "/explore.ExploreService/ListLikedYou"
-	ExploreService_ListNewLikedYou_FullMethodName = "/explore.ExploreService/ListNewLikedYou"
-	ExploreService_CountLikedYou_FullMethodName   = "/explore.ExploreService/CountLikedYou"
-	ExploreService_PutDecision_FullMethodName     = "/explore.ExploreService/PutDecision"
+	ExploreService_ListLikedYou_FullMethodName           = "/explore.ExploreService/ListLikedYou"
+	ExploreService_ListNewLikedYou_FullMethodName        = "/explore.ExploreService/ListNewLikedYou"
+	ExploreService_CountLikedYou_FullMethodName          = "/explore.ExploreService/CountLikedYou"
+	ExploreService_CountNewLikedYou_FullMethodName       = "/explore.ExploreService/CountNewLikedYou"
+	ExploreService_PutDecision_FullMethodName            = "/explore.ExploreService/PutDecision"
+	ExploreService_PutDecisions_FullMethodName           = "/explore.ExploreService/PutDecisions"
+	ExploreService_ListMatches_FullMethodName            = "/explore.ExploreService/ListMatches"
+	ExploreService_CountMatches_FullMethodName           = "/explore.ExploreService/CountMatches"
+	ExploreService_Unmatch_FullMethodName                = "/explore.ExploreService/Unmatch"
+	ExploreService_BlockUser_FullMethodName              = "/explore.ExploreService/BlockUser"
+	ExploreService_UnblockUser_FullMethodName            = "/explore.ExploreService/UnblockUser"
+	ExploreService_ReportUser_FullMethodName             = "/explore.ExploreService/ReportUser"
+	ExploreService_ListDecisions_FullMethodName          = "/explore.ExploreService/ListDecisions"
+	ExploreService_GetExploreFeed_FullMethodName         = "/explore.ExploreService/GetExploreFeed"
+	ExploreService_SubscribeExploreEvents_FullMethodName = "/explore.ExploreService/SubscribeExploreEvents"
 )
 
 // ExploreServiceClient is the client API for ExploreService service.
@@ -32,7 +43,18 @@ type ExploreServiceClient interface {
 	ListLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error)
 	ListNewLikedYou(ctx context.Context, in *ListLikedYouRequest, opts ...grpc.CallOption) (*ListLikedYouResponse, error)
 	CountLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error)
+	CountNewLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error)
 	PutDecision(ctx context.Context, in *PutDecisionRequest, opts ...grpc.CallOption) (*PutDecisionResponse, error)
+	PutDecisions(ctx context.Context, in *PutDecisionsRequest, opts ...grpc.CallOption) (*PutDecisionsResponse, error)
+	ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error)
+	CountMatches(ctx context.Context, in *CountMatchesRequest, opts ...grpc.CallOption) (*CountMatchesResponse, error)
+	Unmatch(ctx context.Context, in *UnmatchRequest, opts ...grpc.CallOption) (*UnmatchResponse, error)
+	BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error)
+	UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error)
+	ReportUser(ctx context.Context, in *ReportUserRequest, opts ...grpc.CallOption) (*ReportUserResponse, error)
+	ListDecisions(ctx context.Context, in *ListDecisionsRequest, opts ...grpc.CallOption) (*ListDecisionsResponse, error)
+	GetExploreFeed(ctx context.Context, in *GetExploreFeedRequest, opts ...grpc.CallOption) (*GetExploreFeedResponse, error)
+	SubscribeExploreEvents(ctx context.Context, in *SubscribeExploreEventsRequest, opts ...grpc.CallOption) (ExploreService_SubscribeExploreEventsClient, error)
 }
 
 type exploreServiceClient struct {
@@ -73,6 +95,16 @@ func (c *exploreServiceClient) CountLikedYou(ctx context.Context, in *CountLiked
 	return out, nil
 }
 
+func (c *exploreServiceClient) CountNewLikedYou(ctx context.Context, in *CountLikedYouRequest, opts ...grpc.CallOption) (*CountLikedYouResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountLikedYouResponse)
+	err := c.cc.Invoke(ctx, ExploreService_CountNewLikedYou_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *exploreServiceClient) PutDecision(ctx context.Context, in *PutDecisionRequest, opts ...grpc.CallOption) (*PutDecisionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PutDecisionResponse)
@@ -83,6 +115,129 @@ func (c *exploreServiceClient) PutDecision(ctx context.Context, in *PutDecisionR
 	return out, nil
 }
 
+func (c *exploreServiceClient) PutDecisions(ctx context.Context, in *PutDecisionsRequest, opts ...grpc.CallOption) (*PutDecisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PutDecisionsResponse)
+	err := c.cc.Invoke(ctx, ExploreService_PutDecisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ListMatches(ctx context.Context, in *ListMatchesRequest, opts ...grpc.CallOption) (*ListMatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMatchesResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) CountMatches(ctx context.Context, in *CountMatchesRequest, opts ...grpc.CallOption) (*CountMatchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountMatchesResponse)
+	err := c.cc.Invoke(ctx, ExploreService_CountMatches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) Unmatch(ctx context.Context, in *UnmatchRequest, opts ...grpc.CallOption) (*UnmatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnmatchResponse)
+	err := c.cc.Invoke(ctx, ExploreService_Unmatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) BlockUser(ctx context.Context, in *BlockUserRequest, opts ...grpc.CallOption) (*BlockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BlockUserResponse)
+	err := c.cc.Invoke(ctx, ExploreService_BlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) UnblockUser(ctx context.Context, in *UnblockUserRequest, opts ...grpc.CallOption) (*UnblockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnblockUserResponse)
+	err := c.cc.Invoke(ctx, ExploreService_UnblockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ReportUser(ctx context.Context, in *ReportUserRequest, opts ...grpc.CallOption) (*ReportUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportUserResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ReportUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) ListDecisions(ctx context.Context, in *ListDecisionsRequest, opts ...grpc.CallOption) (*ListDecisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDecisionsResponse)
+	err := c.cc.Invoke(ctx, ExploreService_ListDecisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) GetExploreFeed(ctx context.Context, in *GetExploreFeedRequest, opts ...grpc.CallOption) (*GetExploreFeedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetExploreFeedResponse)
+	err := c.cc.Invoke(ctx, ExploreService_GetExploreFeed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *exploreServiceClient) SubscribeExploreEvents(ctx context.Context, in *SubscribeExploreEventsRequest, opts ...grpc.CallOption) (ExploreService_SubscribeExploreEventsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ExploreService_ServiceDesc.Streams[0], ExploreService_SubscribeExploreEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &exploreServiceSubscribeExploreEventsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExploreService_SubscribeExploreEventsClient interface {
+	Recv() (*ExploreEvent, error)
+	grpc.ClientStream
+}
+
+type exploreServiceSubscribeExploreEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *exploreServiceSubscribeExploreEventsClient) Recv() (*ExploreEvent, error) {
+	m := new(ExploreEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ExploreServiceServer is the server API for ExploreService service.
 // All implementations must embed UnimplementedExploreServiceServer
 // for forward compatibility
@@ -90,7 +245,18 @@ type ExploreServiceServer interface {
 	ListLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error)
 	ListNewLikedYou(context.Context, *ListLikedYouRequest) (*ListLikedYouResponse, error)
 	CountLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error)
+	CountNewLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error)
 	PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error)
+	PutDecisions(context.Context, *PutDecisionsRequest) (*PutDecisionsResponse, error)
+	ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error)
+	CountMatches(context.Context, *CountMatchesRequest) (*CountMatchesResponse, error)
+	Unmatch(context.Context, *UnmatchRequest) (*UnmatchResponse, error)
+	BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error)
+	UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error)
+	ReportUser(context.Context, *ReportUserRequest) (*ReportUserResponse, error)
+	ListDecisions(context.Context, *ListDecisionsRequest) (*ListDecisionsResponse, error)
+	GetExploreFeed(context.Context, *GetExploreFeedRequest) (*GetExploreFeedResponse, error)
+	SubscribeExploreEvents(*SubscribeExploreEventsRequest, ExploreService_SubscribeExploreEventsServer) error
 	mustEmbedUnimplementedExploreServiceServer()
 }
 
@@ -107,9 +273,42 @@ func (UnimplementedExploreServiceServer) ListNewLikedYou(context.Context, *ListL
 func (UnimplementedExploreServiceServer) CountLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CountLikedYou not implemented")
 }
+func (UnimplementedExploreServiceServer) CountNewLikedYou(context.Context, *CountLikedYouRequest) (*CountLikedYouResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountNewLikedYou not implemented")
+}
 func (UnimplementedExploreServiceServer) PutDecision(context.Context, *PutDecisionRequest) (*PutDecisionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PutDecision not implemented")
 }
+func (UnimplementedExploreServiceServer) PutDecisions(context.Context, *PutDecisionsRequest) (*PutDecisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutDecisions not implemented")
+}
+func (UnimplementedExploreServiceServer) ListMatches(context.Context, *ListMatchesRequest) (*ListMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatches not implemented")
+}
+func (UnimplementedExploreServiceServer) CountMatches(context.Context, *CountMatchesRequest) (*CountMatchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountMatches not implemented")
+}
+func (UnimplementedExploreServiceServer) Unmatch(context.Context, *UnmatchRequest) (*UnmatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unmatch not implemented")
+}
+func (UnimplementedExploreServiceServer) BlockUser(context.Context, *BlockUserRequest) (*BlockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedExploreServiceServer) UnblockUser(context.Context, *UnblockUserRequest) (*UnblockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockUser not implemented")
+}
+func (UnimplementedExploreServiceServer) ReportUser(context.Context, *ReportUserRequest) (*ReportUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportUser not implemented")
+}
+func (UnimplementedExploreServiceServer) ListDecisions(context.Context, *ListDecisionsRequest) (*ListDecisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDecisions not implemented")
+}
+func (UnimplementedExploreServiceServer) GetExploreFeed(context.Context, *GetExploreFeedRequest) (*GetExploreFeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExploreFeed not implemented")
+}
+func (UnimplementedExploreServiceServer) SubscribeExploreEvents(*SubscribeExploreEventsRequest, ExploreService_SubscribeExploreEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeExploreEvents not implemented")
+}
 func (UnimplementedExploreServiceServer) mustEmbedUnimplementedExploreServiceServer() {}
 
 // UnsafeExploreServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -177,6 +376,24 @@ func _ExploreService_CountLikedYou_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExploreService_CountNewLikedYou_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountLikedYouRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).CountNewLikedYou(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_CountNewLikedYou_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).CountNewLikedYou(ctx, req.(*CountLikedYouRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ExploreService_PutDecision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PutDecisionRequest)
 	if err := dec(in); err != nil {
@@ -195,6 +412,189 @@ func _ExploreService_PutDecision_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExploreService_PutDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).PutDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_PutDecisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).PutDecisions(ctx, req.(*PutDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ListMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListMatches(ctx, req.(*ListMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_CountMatches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountMatchesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).CountMatches(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_CountMatches_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).CountMatches(ctx, req.(*CountMatchesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_Unmatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).Unmatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_Unmatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).Unmatch(ctx, req.(*UnmatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_BlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).BlockUser(ctx, req.(*BlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_UnblockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnblockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).UnblockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_UnblockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).UnblockUser(ctx, req.(*UnblockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ReportUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ReportUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ReportUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ReportUser(ctx, req.(*ReportUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_ListDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).ListDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_ListDecisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).ListDecisions(ctx, req.(*ListDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_GetExploreFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExploreFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExploreServiceServer).GetExploreFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExploreService_GetExploreFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExploreServiceServer).GetExploreFeed(ctx, req.(*GetExploreFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExploreService_SubscribeExploreEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeExploreEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExploreServiceServer).SubscribeExploreEvents(m, &exploreServiceSubscribeExploreEventsServer{ServerStream: stream})
+}
+
+type ExploreService_SubscribeExploreEventsServer interface {
+	Send(*ExploreEvent) error
+	grpc.ServerStream
+}
+
+type exploreServiceSubscribeExploreEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *exploreServiceSubscribeExploreEventsServer) Send(m *ExploreEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ExploreService_ServiceDesc is the grpc.ServiceDesc for ExploreService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -214,11 +614,57 @@ var ExploreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CountLikedYou",
 			Handler:    _ExploreService_CountLikedYou_Handler,
 		},
+		{
+			MethodName: "CountNewLikedYou",
+			Handler:    _ExploreService_CountNewLikedYou_Handler,
+		},
 		{
 			MethodName: "PutDecision",
 			Handler:    _ExploreService_PutDecision_Handler,
 		},
+		{
+			MethodName: "PutDecisions",
+			Handler:    _ExploreService_PutDecisions_Handler,
+		},
+		{
+			MethodName: "ListMatches",
+			Handler:    _ExploreService_ListMatches_Handler,
+		},
+		{
+			MethodName: "CountMatches",
+			Handler:    _ExploreService_CountMatches_Handler,
+		},
+		{
+			MethodName: "Unmatch",
+			Handler:    _ExploreService_Unmatch_Handler,
+		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _ExploreService_BlockUser_Handler,
+		},
+		{
+			MethodName: "UnblockUser",
+			Handler:    _ExploreService_UnblockUser_Handler,
+		},
+		{
+			MethodName: "ReportUser",
+			Handler:    _ExploreService_ReportUser_Handler,
+		},
+		{
+			MethodName: "ListDecisions",
+			Handler:    _ExploreService_ListDecisions_Handler,
+		},
+		{
+			MethodName: "GetExploreFeed",
+			Handler:    _ExploreService_GetExploreFeed_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeExploreEvents",
+			Handler:       _ExploreService_SubscribeExploreEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "explore-service.proto",
 }
@@ -0,0 +1,186 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v5.27.1
+// source: webhook-admin.proto
+
+package explore
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	WebhookAdminService_RegisterWebhookEndpoint_FullMethodName = "/explore.WebhookAdminService/RegisterWebhookEndpoint"
+	WebhookAdminService_ListWebhookEndpoints_FullMethodName    = "/explore.WebhookAdminService/ListWebhookEndpoints"
+	WebhookAdminService_DisableWebhookEndpoint_FullMethodName  = "/explore.WebhookAdminService/DisableWebhookEndpoint"
+)
+
+// WebhookAdminServiceClient is the client API for WebhookAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebhookAdminServiceClient interface {
+	RegisterWebhookEndpoint(ctx context.Context, in *RegisterWebhookEndpointRequest, opts ...grpc.CallOption) (*RegisterWebhookEndpointResponse, error)
+	ListWebhookEndpoints(ctx context.Context, in *ListWebhookEndpointsRequest, opts ...grpc.CallOption) (*ListWebhookEndpointsResponse, error)
+	DisableWebhookEndpoint(ctx context.Context, in *DisableWebhookEndpointRequest, opts ...grpc.CallOption) (*DisableWebhookEndpointResponse, error)
+}
+
+type webhookAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookAdminServiceClient(cc grpc.ClientConnInterface) WebhookAdminServiceClient {
+	return &webhookAdminServiceClient{cc}
+}
+
+func (c *webhookAdminServiceClient) RegisterWebhookEndpoint(ctx context.Context, in *RegisterWebhookEndpointRequest, opts ...grpc.CallOption) (*RegisterWebhookEndpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterWebhookEndpointResponse)
+	err := c.cc.Invoke(ctx, WebhookAdminService_RegisterWebhookEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookAdminServiceClient) ListWebhookEndpoints(ctx context.Context, in *ListWebhookEndpointsRequest, opts ...grpc.CallOption) (*ListWebhookEndpointsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookEndpointsResponse)
+	err := c.cc.Invoke(ctx, WebhookAdminService_ListWebhookEndpoints_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookAdminServiceClient) DisableWebhookEndpoint(ctx context.Context, in *DisableWebhookEndpointRequest, opts ...grpc.CallOption) (*DisableWebhookEndpointResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisableWebhookEndpointResponse)
+	err := c.cc.Invoke(ctx, WebhookAdminService_DisableWebhookEndpoint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookAdminServiceServer is the server API for WebhookAdminService service.
+// All implementations must embed UnimplementedWebhookAdminServiceServer
+// for forward compatibility
+type WebhookAdminServiceServer interface {
+	RegisterWebhookEndpoint(context.Context, *RegisterWebhookEndpointRequest) (*RegisterWebhookEndpointResponse, error)
+	ListWebhookEndpoints(context.Context, *ListWebhookEndpointsRequest) (*ListWebhookEndpointsResponse, error)
+	DisableWebhookEndpoint(context.Context, *DisableWebhookEndpointRequest) (*DisableWebhookEndpointResponse, error)
+	mustEmbedUnimplementedWebhookAdminServiceServer()
+}
+
+// UnimplementedWebhookAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWebhookAdminServiceServer struct {
+}
+
+func (UnimplementedWebhookAdminServiceServer) RegisterWebhookEndpoint(context.Context, *RegisterWebhookEndpointRequest) (*RegisterWebhookEndpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWebhookEndpoint not implemented")
+}
+func (UnimplementedWebhookAdminServiceServer) ListWebhookEndpoints(context.Context, *ListWebhookEndpointsRequest) (*ListWebhookEndpointsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookEndpoints not implemented")
+}
+func (UnimplementedWebhookAdminServiceServer) DisableWebhookEndpoint(context.Context, *DisableWebhookEndpointRequest) (*DisableWebhookEndpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableWebhookEndpoint not implemented")
+}
+func (UnimplementedWebhookAdminServiceServer) mustEmbedUnimplementedWebhookAdminServiceServer() {}
+
+// UnsafeWebhookAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookAdminServiceServer will
+// result in compilation errors.
+type UnsafeWebhookAdminServiceServer interface {
+	mustEmbedUnimplementedWebhookAdminServiceServer()
+}
+
+func RegisterWebhookAdminServiceServer(s grpc.ServiceRegistrar, srv WebhookAdminServiceServer) {
+	s.RegisterService(&WebhookAdminService_ServiceDesc, srv)
+}
+
+func _WebhookAdminService_RegisterWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookAdminServiceServer).RegisterWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookAdminService_RegisterWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookAdminServiceServer).RegisterWebhookEndpoint(ctx, req.(*RegisterWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookAdminService_ListWebhookEndpoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookEndpointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookAdminServiceServer).ListWebhookEndpoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookAdminService_ListWebhookEndpoints_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookAdminServiceServer).ListWebhookEndpoints(ctx, req.(*ListWebhookEndpointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookAdminService_DisableWebhookEndpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisableWebhookEndpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookAdminServiceServer).DisableWebhookEndpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WebhookAdminService_DisableWebhookEndpoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookAdminServiceServer).DisableWebhookEndpoint(ctx, req.(*DisableWebhookEndpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebhookAdminService_ServiceDesc is the grpc.ServiceDesc for WebhookAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "explore.WebhookAdminService",
+	HandlerType: (*WebhookAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterWebhookEndpoint",
+			Handler:    _WebhookAdminService_RegisterWebhookEndpoint_Handler,
+		},
+		{
+			MethodName: "ListWebhookEndpoints",
+			Handler:    _WebhookAdminService_ListWebhookEndpoints_Handler,
+		},
+		{
+			MethodName: "DisableWebhookEndpoint",
+			Handler:    _WebhookAdminService_DisableWebhookEndpoint_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "webhook-admin.proto",
+}
@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"muzz-backend-challenge/pkg/cache"
+	explore "muzz-backend-challenge/pkg/proto"
+)
+
+const (
+	// DefaultCacheTTL is how long cached values are served before they are read again.
+	DefaultCacheTTL = 30 * time.Second
+	// DefaultCachedLikers is how many of the newest likers of a user are cached.
+	DefaultCachedLikers = 100
+)
+
+// CachingOption configures the repository created by NewCachingExploreRepository.
+type CachingOption func(*cachingExploreRepository)
+
+// WithCacheTTL sets how long cached values are served before they are read again.
+func WithCacheTTL(ttl time.Duration) CachingOption {
+	return func(r *cachingExploreRepository) {
+		if ttl > 0 {
+			r.ttl = ttl
+		}
+	}
+}
+
+// WithCachedLikers sets how many of the newest likers of a user are cached. First pages of
+// GetLikedYou with a larger limit are read from the wrapped repository.
+func WithCachedLikers(n int) CachingOption {
+	return func(r *cachingExploreRepository) {
+		if n > 0 {
+			r.cachedLikers = n
+		}
+	}
+}
+
+// cachingExploreRepository serves CountLikes and the first page of GetLikedYou from a cache,
+// and passes every other call to the wrapped repository.
+//
+// Writes that change who likes a user (likes, unmatches and blocks) invalidate that user's
+// cached values once their transaction has ended, so a reader that misses the cache cannot
+// fill it from data that is about to change. A reader that missed just before a commit can
+// still store the old value, which is then served until the TTL runs out.
+type cachingExploreRepository struct {
+	ExploreRepository
+	cache        cache.Cache
+	ttl          time.Duration
+	cachedLikers int
+
+	mu sync.Mutex
+	// changed holds, for each transaction run through WithTx, the users whose cached values
+	// must be invalidated when it ends
+	changed map[Tx]map[string]bool
+}
+
+// cachedLiker is the cached form of a LikerRow.
+type cachedLiker struct {
+	ActorID   string    `json:"actor_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewCachingExploreRepository wraps next with a cache for the reads of who liked a user.
+func NewCachingExploreRepository(next ExploreRepository, c cache.Cache, opts ...CachingOption) ExploreRepository {
+	r := &cachingExploreRepository{
+		ExploreRepository: next,
+		cache:             c,
+		ttl:               DefaultCacheTTL,
+		cachedLikers:      DefaultCachedLikers,
+		changed:           make(map[Tx]map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func likeCountKey(userID string) string { return "liked-you-count:" + userID }
+func likersKey(userID string) string    { return "liked-you:" + userID }
+
+// CountLikes counts the number of users who liked the recipient user.
+func (r *cachingExploreRepository) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
+	key := likeCountKey(recipientUserID)
+	if value, ok := r.get(ctx, key); ok {
+		if count, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			return count, nil
+		}
+	}
+
+	count, err := r.ExploreRepository.CountLikes(ctx, recipientUserID)
+	if err != nil {
+		return 0, err
+	}
+	r.set(ctx, key, []byte(strconv.FormatInt(count, 10)))
+	return count, nil
+}
+
+// GetLikedYou retrieves a list of users who liked the recipient user, newest first.
+// If after is set, only likes older than the cursor are returned.
+//
+// Profiles are not cached, since profile updates do not invalidate the cache, so pages with
+// includeProfile are always read from the wrapped repository.
+func (r *cachingExploreRepository) GetLikedYou(ctx context.Context, recipientUserID string, includeProfile bool, limit int, after *Cursor) ([]LikerRow, error) {
+	if includeProfile || after != nil || limit > r.cachedLikers {
+		return r.ExploreRepository.GetLikedYou(ctx, recipientUserID, includeProfile, limit, after)
+	}
+
+	key := likersKey(recipientUserID)
+	if value, ok := r.get(ctx, key); ok {
+		var cached []cachedLiker
+		if err := json.Unmarshal(value, &cached); err == nil {
+			return likerRows(cached[:min(limit, len(cached))]), nil
+		}
+	}
+
+	likers, err := r.ExploreRepository.GetLikedYou(ctx, recipientUserID, false, r.cachedLikers, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := make([]cachedLiker, len(likers))
+	for i, liker := range likers {
+		cached[i] = cachedLiker{ActorID: liker.Liker.ActorId, CreatedAt: liker.Cursor.CreatedAt}
+	}
+	if value, err := json.Marshal(cached); err == nil {
+		r.set(ctx, key, value)
+	}
+	return likers[:min(limit, len(likers))], nil
+}
+
+func likerRows(cached []cachedLiker) []LikerRow {
+	likers := make([]LikerRow, len(cached))
+	for i, liker := range cached {
+		likers[i] = LikerRow{
+			Liker: &explore.ListLikedYouResponse_Liker{
+				ActorId:       liker.ActorID,
+				UnixTimestamp: uint64(liker.CreatedAt.Unix()),
+			},
+			Cursor: Cursor{CreatedAt: liker.CreatedAt, UserID: liker.ActorID},
+		}
+	}
+	return likers
+}
+
+// WithTx runs fn in a transaction of the wrapped repository and then invalidates the cached
+// values of the users whose likes fn changed. The values are invalidated even if the
+// transaction fails, since a failed commit may still have been applied.
+func (r *cachingExploreRepository) WithTx(ctx context.Context, fn func(tx Tx) error) error {
+	changed := make(map[string]bool)
+	err := r.ExploreRepository.WithTx(ctx, func(tx Tx) error {
+		r.mu.Lock()
+		r.changed[tx] = changed
+		r.mu.Unlock()
+
+		defer func() {
+			r.mu.Lock()
+			delete(r.changed, tx)
+			r.mu.Unlock()
+		}()
+		return fn(tx)
+	})
+
+	userIDs := make([]string, 0, len(changed))
+	for userID := range changed {
+		userIDs = append(userIDs, userID)
+	}
+	r.invalidate(ctx, userIDs...)
+	return err
+}
+
+// likesChanged records that the likes received by the users changed in tx. Their cached
+// values are invalidated when tx ends, or right away if tx was not opened through WithTx.
+func (r *cachingExploreRepository) likesChanged(ctx context.Context, tx Tx, userIDs ...string) {
+	r.mu.Lock()
+	changed, ok := r.changed[tx]
+	if ok {
+		for _, userID := range userIDs {
+			changed[userID] = true
+		}
+	}
+	r.mu.Unlock()
+
+	if !ok {
+		r.invalidate(ctx, userIDs...)
+	}
+}
+
+// InsertLike records a like action from the actor user to the recipient user.
+func (r *cachingExploreRepository) InsertLike(ctx context.Context, tx Tx, actorUserID, recipientUserID string) error {
+	if err := r.ExploreRepository.InsertLike(ctx, tx, actorUserID, recipientUserID); err != nil {
+		return err
+	}
+	r.likesChanged(ctx, tx, recipientUserID)
+	return nil
+}
+
+// DeleteLike removes a like action from the actor user to the recipient user.
+func (r *cachingExploreRepository) DeleteLike(ctx context.Context, tx Tx, actorUserID, recipientUserID string) error {
+	if err := r.ExploreRepository.DeleteLike(ctx, tx, actorUserID, recipientUserID); err != nil {
+		return err
+	}
+	r.likesChanged(ctx, tx, recipientUserID)
+	return nil
+}
+
+// InsertLikes records a like for each pair.
+func (r *cachingExploreRepository) InsertLikes(ctx context.Context, tx Tx, pairs []UserPair) error {
+	if err := r.ExploreRepository.InsertLikes(ctx, tx, pairs); err != nil {
+		return err
+	}
+	r.likesChanged(ctx, tx, recipients(pairs)...)
+	return nil
+}
+
+// DeleteLikes removes the like of each pair, if any.
+func (r *cachingExploreRepository) DeleteLikes(ctx context.Context, tx Tx, pairs []UserPair) error {
+	if err := r.ExploreRepository.DeleteLikes(ctx, tx, pairs); err != nil {
+		return err
+	}
+	r.likesChanged(ctx, tx, recipients(pairs)...)
+	return nil
+}
+
+func recipients(pairs []UserPair) []string {
+	userIDs := make([]string, len(pairs))
+	for i, pair := range pairs {
+		userIDs[i] = pair.RecipientUserID
+	}
+	return userIDs
+}
+
+// Unmatch removes the mutual like between the actor and the recipient.
+func (r *cachingExploreRepository) Unmatch(ctx context.Context, tx Tx, actorUserID, recipientUserID string) error {
+	if err := r.ExploreRepository.Unmatch(ctx, tx, actorUserID, recipientUserID); err != nil {
+		return err
+	}
+	r.likesChanged(ctx, tx, actorUserID, recipientUserID)
+	return nil
+}
+
+// BlockUser records that the blocker has blocked the blocked user, which hides the likes
+// between them.
+func (r *cachingExploreRepository) BlockUser(ctx context.Context, blockerUserID, blockedUserID string) error {
+	err := r.ExploreRepository.BlockUser(ctx, blockerUserID, blockedUserID)
+	r.invalidate(ctx, blockerUserID, blockedUserID)
+	return err
+}
+
+// UnblockUser removes a block previously placed by the blocker, which shows the likes between
+// them again.
+func (r *cachingExploreRepository) UnblockUser(ctx context.Context, blockerUserID, blockedUserID string) error {
+	err := r.ExploreRepository.UnblockUser(ctx, blockerUserID, blockedUserID)
+	r.invalidate(ctx, blockerUserID, blockedUserID)
+	return err
+}
+
+// get reads a cached value. Cache failures are logged and treated as misses, so an unavailable
+// cache only costs the database queries it would have saved.
+func (r *cachingExploreRepository) get(ctx context.Context, key string) ([]byte, bool) {
+	value, ok, err := r.cache.Get(ctx, key)
+	if err != nil {
+		log.Printf("Failed to read %s from cache: %v", key, err)
+		return nil, false
+	}
+	return value, ok
+}
+
+func (r *cachingExploreRepository) set(ctx context.Context, key string, value []byte) {
+	if err := r.cache.Set(ctx, key, value, r.ttl); err != nil {
+		log.Printf("Failed to write %s to cache: %v", key, err)
+	}
+}
+
+// invalidate removes the cached values of the users. A failure is logged, and the stale values
+// are served until they expire.
+func (r *cachingExploreRepository) invalidate(ctx context.Context, userIDs ...string) {
+	invalidateLikes(ctx, r.cache, userIDs...)
+}
+
+// invalidateLikes removes the cached like counts and likers of the users from c.
+func invalidateLikes(ctx context.Context, c cache.Cache, userIDs ...string) {
+	if len(userIDs) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, 2*len(userIDs))
+	for _, userID := range userIDs {
+		keys = append(keys, likeCountKey(userID), likersKey(userID))
+	}
+	if err := c.Delete(context.WithoutCancel(ctx), keys...); err != nil {
+		log.Printf("Failed to invalidate cached likes: %v", err)
+	}
+}
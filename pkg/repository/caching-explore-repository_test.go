@@ -0,0 +1,168 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-backend-challenge/pkg/cache"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+func TestCachingExploreRepositoryConformance(t *testing.T) {
+	runExploreRepositoryConformance(t, func(t *testing.T) conformanceStore {
+		memoryRepository := repository.NewMemoryRepository()
+		return conformanceStore{
+			repo: repository.NewCachingExploreRepository(memoryRepository, cache.NewLRUCache(100)),
+			addUsers: func(t *testing.T, userIDs ...uuid.UUID) {
+				for _, userID := range userIDs {
+					memoryRepository.AddUsers(userID.String())
+				}
+			},
+		}
+	})
+}
+
+func TestCachingExploreRepository_ServesReadsFromCache(t *testing.T) {
+	ctx := context.Background()
+	next := new(repository.MockExploreRepository)
+	likers := []repository.LikerRow{likerRow("liker-2", 2), likerRow("liker-1", 1)}
+	next.On("CountLikes", ctx, "recipient").Return(int64(2), nil).Once()
+	next.On("GetLikedYou", ctx, "recipient", false, 5, (*repository.Cursor)(nil)).Return(likers, nil).Once()
+
+	repo := repository.NewCachingExploreRepository(next, cache.NewLRUCache(10), repository.WithCachedLikers(5))
+
+	for i := 0; i < 2; i++ {
+		count, err := repo.CountLikes(ctx, "recipient")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+
+		page, err := repo.GetLikedYou(ctx, "recipient", false, 1, nil)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, "liker-2", page[0].Liker.ActorId)
+		assert.True(t, page[0].Cursor.CreatedAt.Equal(likers[0].Cursor.CreatedAt))
+	}
+
+	next.AssertExpectations(t)
+}
+
+func TestCachingExploreRepository_ReadsPastTheCachedLikersFromRepository(t *testing.T) {
+	ctx := context.Background()
+	next := new(repository.MockExploreRepository)
+	after := &repository.Cursor{CreatedAt: time.Unix(1, 0), UserID: "liker-1"}
+	next.On("GetLikedYou", ctx, "recipient", false, 10, after).Return([]repository.LikerRow{}, nil).Twice()
+	next.On("GetLikedYou", ctx, "recipient", false, 20, (*repository.Cursor)(nil)).Return([]repository.LikerRow{}, nil).Twice()
+
+	repo := repository.NewCachingExploreRepository(next, cache.NewLRUCache(10), repository.WithCachedLikers(10))
+
+	for i := 0; i < 2; i++ {
+		_, err := repo.GetLikedYou(ctx, "recipient", false, 10, after)
+		require.NoError(t, err)
+		_, err = repo.GetLikedYou(ctx, "recipient", false, 20, nil)
+		require.NoError(t, err)
+	}
+
+	next.AssertExpectations(t)
+}
+
+func TestCachingExploreRepository_ReadsProfilesFromRepository(t *testing.T) {
+	ctx := context.Background()
+	next := new(repository.MockExploreRepository)
+	withProfile := likerRow("liker-1", 1)
+	withProfile.Liker.Profile = &explore.LikerProfile{Username: "liker"}
+	next.On("GetLikedYou", ctx, "recipient", true, 10, (*repository.Cursor)(nil)).Return([]repository.LikerRow{withProfile}, nil).Twice()
+
+	repo := repository.NewCachingExploreRepository(next, cache.NewLRUCache(10), repository.WithCachedLikers(10))
+
+	for i := 0; i < 2; i++ {
+		page, err := repo.GetLikedYou(ctx, "recipient", true, 10, nil)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, "liker", page[0].Liker.Profile.GetUsername())
+	}
+
+	next.AssertExpectations(t)
+}
+
+func TestCachingExploreRepository_InvalidatesOnLikeChanges(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	memoryRepository := repository.NewMemoryRepository()
+	memoryRepository.AddUsers("actor", "recipient")
+	repo := repository.NewCachingExploreRepository(memoryRepository, cache.NewRedisCache(client), repository.WithCacheTTL(time.Hour))
+
+	assertCounts := func(expected int64) {
+		t.Helper()
+		count, err := repo.CountLikes(ctx, "recipient")
+		require.NoError(t, err)
+		assert.Equal(t, expected, count)
+
+		likers, err := repo.GetLikedYou(ctx, "recipient", false, 10, nil)
+		require.NoError(t, err)
+		assert.Len(t, likers, int(expected))
+	}
+
+	assertCounts(0)
+
+	err := repo.WithTx(ctx, func(tx repository.Tx) error {
+		if err := repo.InsertLike(ctx, tx, "actor", "recipient"); err != nil {
+			return err
+		}
+		// The cache is only invalidated once the transaction ends
+		assert.True(t, server.Exists(cache.DefaultRedisPrefix+"liked-you-count:recipient"))
+		return nil
+	})
+	require.NoError(t, err)
+	assertCounts(1)
+
+	require.NoError(t, repo.BlockUser(ctx, "recipient", "actor"))
+	assertCounts(0)
+
+	require.NoError(t, repo.UnblockUser(ctx, "recipient", "actor"))
+	assertCounts(1)
+
+	err = repo.WithTx(ctx, func(tx repository.Tx) error {
+		return repo.DeleteLikes(ctx, tx, []repository.UserPair{{ActorUserID: "actor", RecipientUserID: "recipient"}})
+	})
+	require.NoError(t, err)
+	assertCounts(0)
+}
+
+func TestCachingExploreRepository_FallsBackWhenCacheIsUnavailable(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	defer client.Close()
+	server.Close()
+
+	memoryRepository := repository.NewMemoryRepository()
+	memoryRepository.AddUsers("actor", "recipient")
+	repo := repository.NewCachingExploreRepository(memoryRepository, cache.NewRedisCache(client))
+
+	err := repo.WithTx(ctx, func(tx repository.Tx) error {
+		return repo.InsertLike(ctx, tx, "actor", "recipient")
+	})
+	require.NoError(t, err)
+
+	count, err := repo.CountLikes(ctx, "recipient")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+}
+
+func likerRow(actorID string, unixTimestamp int64) repository.LikerRow {
+	createdAt := time.Unix(unixTimestamp, 0).UTC()
+	return repository.LikerRow{
+		Liker:  &explore.ListLikedYouResponse_Liker{ActorId: actorID, UnixTimestamp: uint64(unixTimestamp)},
+		Cursor: repository.Cursor{CreatedAt: createdAt, UserID: actorID},
+	}
+}
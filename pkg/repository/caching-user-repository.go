@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"muzz-backend-challenge/pkg/cache"
+)
+
+// cachingUserRepository passes every call to the wrapped repository, and invalidates the values
+// cached by a caching explore repository sharing its cache when a user's data is deleted.
+type cachingUserRepository struct {
+	UserRepository
+	cache cache.Cache
+}
+
+// NewCachingUserRepository wraps next so that DeleteUserData invalidates the like counts and
+// likers that NewCachingExploreRepository caches in c.
+func NewCachingUserRepository(next UserRepository, c cache.Cache) UserRepository {
+	return &cachingUserRepository{UserRepository: next, cache: c}
+}
+
+// DeleteUserData deletes a user and all their data, then invalidates the cached values of the
+// user and of the users they liked. If the deletion fails, only the user's own values are
+// invalidated, and those of the users they liked are served until they expire.
+func (r *cachingUserRepository) DeleteUserData(ctx context.Context, userID string) ([]string, error) {
+	likedUserIDs, err := r.UserRepository.DeleteUserData(ctx, userID)
+	invalidateLikes(ctx, r.cache, append([]string{userID}, likedUserIDs...)...)
+	return likedUserIDs, err
+}
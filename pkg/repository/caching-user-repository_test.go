@@ -0,0 +1,54 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-backend-challenge/pkg/cache"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+func TestCachingUserRepository_DeleteUserDataInvalidatesLikes(t *testing.T) {
+	ctx := context.Background()
+	memoryRepository := repository.NewMemoryRepository()
+	memoryRepository.AddUsers("deleted", "recipient")
+	likesCache := cache.NewLRUCache(10)
+	exploreRepo := repository.NewCachingExploreRepository(memoryRepository, likesCache, repository.WithCacheTTL(time.Hour))
+	userRepo := repository.NewCachingUserRepository(memoryRepository, likesCache)
+
+	require.NoError(t, exploreRepo.WithTx(ctx, func(tx repository.Tx) error {
+		return exploreRepo.InsertLike(ctx, tx, "deleted", "recipient")
+	}))
+	assertLikes := func(expected int64) {
+		t.Helper()
+		count, err := exploreRepo.CountLikes(ctx, "recipient")
+		require.NoError(t, err)
+		assert.Equal(t, expected, count)
+
+		likers, err := exploreRepo.GetLikedYou(ctx, "recipient", false, 10, nil)
+		require.NoError(t, err)
+		assert.Len(t, likers, int(expected))
+	}
+	assertLikes(1)
+
+	likedUserIDs, err := userRepo.DeleteUserData(ctx, "deleted")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"recipient"}, likedUserIDs)
+	assertLikes(0)
+}
+
+func TestCachingUserRepository_DeleteUserDataPassesErrorsThrough(t *testing.T) {
+	ctx := context.Background()
+	next := new(repository.MockUserRepository)
+	failure := errors.New("failure")
+	next.On("DeleteUserData", ctx, "user").Return(nil, failure)
+
+	_, err := repository.NewCachingUserRepository(next, cache.NewLRUCache(10)).DeleteUserData(ctx, "user")
+
+	assert.Same(t, failure, err)
+	next.AssertExpectations(t)
+}
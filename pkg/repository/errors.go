@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/lib/pq"
+)
+
+// Errors returned by repository methods, wrapped around the underlying driver error.
+// Callers should match them with errors.Is.
+var (
+	// ErrNotFound is returned when the requested row does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a write clashes with existing data, such as a unique constraint.
+	ErrConflict = errors.New("conflict")
+	// ErrInvalidReference is returned when a write references a row that does not exist, such as an unknown user.
+	ErrInvalidReference = errors.New("invalid reference")
+	// ErrUnavailable is returned when the database cannot be reached or is refusing connections.
+	ErrUnavailable = errors.New("unavailable")
+	// ErrTimeout is returned when a query is cancelled because it ran past its deadline.
+	ErrTimeout = errors.New("timeout")
+	// ErrAborted is returned when the database aborts a transaction because of a serialization
+	// failure or a deadlock. Running the transaction again may succeed.
+	ErrAborted = errors.New("aborted")
+)
+
+// Postgres error classes and codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pqClassConnectionException  = "08"
+	pqClassInsufficientResource = "53"
+	pqForeignKeyViolation       = "23503"
+	pqUniqueViolation           = "23505"
+	pqSerializationFailure      = "40001"
+	pqDeadlockDetected          = "40P01"
+	pqQueryCanceled             = "57014"
+	pqAdminShutdown             = "57P01"
+	pqCrashShutdown             = "57P02"
+	pqCannotConnectNow          = "57P03"
+)
+
+// wrapError annotates err with msg and, when the cause is recognised, with the matching
+// repository error so the service layer can pick an appropriate status code.
+func wrapError(msg string, err error) error {
+	if kind := classifyError(err); kind != nil {
+		return fmt.Errorf("%s: %w: %w", msg, kind, err)
+	}
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// classifyError maps a driver or context error onto one of the repository errors.
+// It returns nil when the error does not fall into any known category.
+func classifyError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == pqForeignKeyViolation:
+			return ErrInvalidReference
+		case pqErr.Code == pqUniqueViolation:
+			return ErrConflict
+		case pqErr.Code == pqQueryCanceled:
+			return ErrTimeout
+		case pqErr.Code == pqSerializationFailure, pqErr.Code == pqDeadlockDetected:
+			return ErrAborted
+		case pqErr.Code == pqAdminShutdown, pqErr.Code == pqCrashShutdown, pqErr.Code == pqCannotConnectNow,
+			pqErr.Code.Class() == pqClassConnectionException, pqErr.Code.Class() == pqClassInsufficientResource:
+			return ErrUnavailable
+		}
+		return nil
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrTimeout
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, sql.ErrConnDone), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrUnavailable
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return ErrTimeout
+		}
+		return ErrUnavailable
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"foreign key violation", &pq.Error{Code: "23503"}, ErrInvalidReference},
+		{"unique violation", &pq.Error{Code: "23505"}, ErrConflict},
+		{"query canceled", &pq.Error{Code: "57014"}, ErrTimeout},
+		{"serialization failure", &pq.Error{Code: "40001"}, ErrAborted},
+		{"deadlock detected", &pq.Error{Code: "40P01"}, ErrAborted},
+		{"admin shutdown", &pq.Error{Code: "57P01"}, ErrUnavailable},
+		{"connection failure", &pq.Error{Code: "08006"}, ErrUnavailable},
+		{"too many connections", &pq.Error{Code: "53300"}, ErrUnavailable},
+		{"syntax error", &pq.Error{Code: "42601"}, nil},
+		{"no rows", sql.ErrNoRows, ErrNotFound},
+		{"deadline exceeded", context.DeadlineExceeded, ErrTimeout},
+		{"bad connection", driver.ErrBadConn, ErrUnavailable},
+		{"connection dropped", io.ErrUnexpectedEOF, ErrUnavailable},
+		{"unknown", errors.New("boom"), nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, classifyError(fmt.Errorf("wrapped: %w", testCase.err)))
+		})
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	cause := &pq.Error{Code: "23503", Message: "violates foreign key constraint"}
+	err := wrapError("failed to insert like", cause)
+
+	assert.ErrorIs(t, err, ErrInvalidReference)
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "failed to insert like: invalid reference: pq: violates foreign key constraint", err.Error())
+}
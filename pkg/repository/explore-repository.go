@@ -5,19 +5,132 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/lib/pq"
 	explore "muzz-backend-challenge/pkg/proto"
+	"slices"
+	"time"
 )
 
 // ExploreRepository defines methods for accessing exploration-related data.
 type ExploreRepository interface {
-	BeginTransaction(ctx context.Context) (*sql.Tx, error)
-	GetLikedYou(ctx context.Context, recipientUserID string, limit, offset int) ([]*explore.ListLikedYouResponse_Liker, error)
-	GetNewLikedYou(ctx context.Context, recipientUserID string, limit, offset int) ([]*explore.ListLikedYouResponse_Liker, error)
+	UnitOfWork
+	GetLikedYou(ctx context.Context, recipientUserID string, includeProfile bool, limit int, after *Cursor) ([]LikerRow, error)
+	GetNewLikedYou(ctx context.Context, recipientUserID string, includeProfile bool, limit int, after *Cursor) ([]LikerRow, error)
 	CountLikes(ctx context.Context, recipientUserID string) (int64, error)
-	InsertDecision(ctx context.Context, transaction *sql.Tx, actorUserID, recipientUserID string, likedRecipient bool) error
-	InsertLike(ctx context.Context, transaction *sql.Tx, actorUserID, recipientUserID string) error
-	DeleteLike(ctx context.Context, transaction *sql.Tx, actorUserID, recipientUserID string) error
-	CheckMutualLike(ctx context.Context, transaction *sql.Tx, actorUserID, recipientUserID string) (bool, error)
+	CountNewLikes(ctx context.Context, recipientUserID string) (int64, error)
+	InsertDecision(ctx context.Context, transaction Tx, actorUserID, recipientUserID string, likedRecipient bool) error
+	InsertLike(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) error
+	DeleteLike(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) error
+	CheckMutualLike(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) (bool, error)
+	GetMatches(ctx context.Context, userID string, limit int, after *Cursor) ([]MatchRow, error)
+	CountMatches(ctx context.Context, userID string) (int64, error)
+	Unmatch(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) error
+	BlockUser(ctx context.Context, blockerUserID, blockedUserID string) error
+	UnblockUser(ctx context.Context, blockerUserID, blockedUserID string) error
+	IsBlocked(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) (bool, error)
+	ReportUser(ctx context.Context, reporterUserID, reportedUserID, reason string) error
+	GetDecisions(ctx context.Context, actorUserID string, filter explore.DecisionFilter, limit int, after *Cursor) ([]DecisionRow, error)
+	GetExploreFeed(ctx context.Context, userID string, order FeedOrder, limit int, after *FeedCursor) ([]CandidateRow, error)
+	InsertOutboxEvent(ctx context.Context, transaction Tx, eventType string, payload []byte) error
+	ReserveIdempotencyKey(ctx context.Context, transaction Tx, actorUserID, key, recipientUserID string, likedRecipient bool, ttl time.Duration) (*IdempotentDecision, error)
+	CompleteIdempotencyKey(ctx context.Context, transaction Tx, actorUserID, key string, mutualLikes bool) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, ttl time.Duration) (int64, error)
+	FindExistingUsers(ctx context.Context, transaction Tx, userIDs []string) (map[string]bool, error)
+	CheckBlockedPairs(ctx context.Context, transaction Tx, pairs []UserPair) ([]bool, error)
+	InsertDecisions(ctx context.Context, transaction Tx, decisions []BatchDecision) error
+	InsertLikes(ctx context.Context, transaction Tx, pairs []UserPair) error
+	DeleteLikes(ctx context.Context, transaction Tx, pairs []UserPair) error
+	CheckMutualLikes(ctx context.Context, transaction Tx, pairs []UserPair) ([]bool, error)
+	InsertOutboxEvents(ctx context.Context, transaction Tx, events []OutboxMessage) error
+}
+
+// Cursor is a keyset position in a list ordered by (created_at, user ID) descending.
+// Passing a cursor to a list method returns the rows strictly after it.
+type Cursor struct {
+	CreatedAt time.Time
+	UserID    string
+}
+
+// LikerRow is a single liker together with the cursor pointing at it.
+type LikerRow struct {
+	Liker  *explore.ListLikedYouResponse_Liker
+	Cursor Cursor
+}
+
+// MatchRow is a single match together with the cursor pointing at it.
+type MatchRow struct {
+	Match  *explore.ListMatchesResponse_Match
+	Cursor Cursor
+}
+
+// DecisionRow is a single outgoing decision together with the cursor pointing at it.
+type DecisionRow struct {
+	Decision *explore.ListDecisionsResponse_Decision
+	Cursor   Cursor
+}
+
+// FeedOrder is the order the explore feed is read in. It decides which candidates make up each
+// page, so unlike the order within a page it cannot be changed after the feed is read.
+type FeedOrder int
+
+const (
+	// FeedOrderLikersFirst lists the users who liked the requester, most recent like first,
+	// then everyone else, newest user first.
+	FeedOrderLikersFirst FeedOrder = iota
+	// FeedOrderNewestFirst lists all candidates newest user first, whether they liked the
+	// requester or not.
+	FeedOrderNewestFirst
+)
+
+// String returns the name of the order.
+func (o FeedOrder) String() string {
+	switch o {
+	case FeedOrderLikersFirst:
+		return "likers_first"
+	case FeedOrderNewestFirst:
+		return "newest_first"
+	}
+	return fmt.Sprintf("FeedOrder(%d)", int(o))
+}
+
+// FeedCursor is a keyset position in the explore feed. In FeedOrderLikersFirst, LikedYou is
+// set for likers and CreatedAt is the time of the like for likers and the time the user joined
+// for everyone else. In FeedOrderNewestFirst, LikedYou is always false and CreatedAt is the
+// time the user joined.
+type FeedCursor struct {
+	LikedYou bool
+	Cursor
+}
+
+// CandidateRow is a single explore feed candidate together with the cursor pointing at it.
+type CandidateRow struct {
+	Candidate *explore.GetExploreFeedResponse_Candidate
+	Cursor    FeedCursor
+}
+
+// IdempotentDecision is a decision previously recorded under an idempotency key.
+type IdempotentDecision struct {
+	RecipientUserID string
+	LikedRecipient  bool
+	MutualLikes     bool
+}
+
+// UserPair identifies an actor acting on a recipient.
+type UserPair struct {
+	ActorUserID     string
+	RecipientUserID string
+}
+
+// BatchDecision is one decision of a batch recorded with InsertDecisions.
+type BatchDecision struct {
+	UserPair
+	LikedRecipient bool
+}
+
+// OutboxMessage is an event to queue in the outbox with InsertOutboxEvents.
+type OutboxMessage struct {
+	EventType string
+	Payload   []byte
 }
 
 // exploreRepository implements the ExploreRepository interface.
@@ -30,139 +143,932 @@ func NewExploreRepository(db *sql.DB) ExploreRepository {
 	return &exploreRepository{db: db}
 }
 
-func (r *exploreRepository) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
-	tx, err := r.db.BeginTx(ctx, nil)
+// WithTx runs fn in a database transaction, retrying it with DefaultRetryPolicy when it fails
+// with a retryable error. See the package-level WithTx for details.
+func (r *exploreRepository) WithTx(ctx context.Context, fn func(tx Tx) error) error {
+	return WithTx(ctx, r.db, DefaultRetryPolicy, nil, func(tx *sql.Tx) error {
+		return fn(tx)
+	})
+}
+
+// GetLikedYou retrieves a list of users who liked the recipient user, newest first.
+// With includeProfile, each liker's profile is read in the same query.
+// If after is set, only likes older than the cursor are returned.
+func (r *exploreRepository) GetLikedYou(ctx context.Context, recipientUserID string, includeProfile bool, limit int, after *Cursor) ([]LikerRow, error) {
+	profileColumns, profileJoin := likerProfileQuery(includeProfile)
+	query := `
+        SELECT likes.actor_user_id, likes.created_at` + profileColumns + `
+        FROM likes` + profileJoin + `
+        WHERE likes.recipient_user_id = $1
+          AND ` + excludeHiddenPairs("likes.actor_user_id", "likes.recipient_user_id") + `
+          AND ($3::timestamp IS NULL OR (likes.created_at, likes.actor_user_id) < ($3::timestamp, $4::uuid))
+        ORDER BY likes.created_at DESC, likes.actor_user_id DESC
+        LIMIT $2`
+
+	createdAt, userID := cursorArgs(after)
+	rows, err := r.db.QueryContext(ctx, query, recipientUserID, limit, createdAt, userID)
 	if err != nil {
-		return nil, err
+		return nil, wrapError("failed to execute query", err)
 	}
-	return tx, nil
+	defer rows.Close()
+
+	return scanLikerRows(rows, includeProfile)
 }
 
-// GetLikedYou retrieves a list of users who liked the recipient user.
-func (r *exploreRepository) GetLikedYou(ctx context.Context, recipientUserID string, limit, offset int) ([]*explore.ListLikedYouResponse_Liker, error) {
+// GetNewLikedYou retrieves a list of new users who liked the recipient user, newest first.
+// New likers are those the recipient has not yet made a decision on, either like or pass.
+// With includeProfile, each liker's profile is read in the same query.
+// If after is set, only likes older than the cursor are returned.
+func (r *exploreRepository) GetNewLikedYou(ctx context.Context, recipientUserID string, includeProfile bool, limit int, after *Cursor) ([]LikerRow, error) {
+	profileColumns, profileJoin := likerProfileQuery(includeProfile)
 	query := `
-        SELECT actor_user_id, EXTRACT(EPOCH FROM created_at) AS unix_timestamp
-        FROM likes
-        WHERE recipient_user_id = $1
-        ORDER BY created_at DESC
-        LIMIT $2 OFFSET $3`
+        SELECT likes.actor_user_id, likes.created_at` + profileColumns + `
+        FROM likes` + profileJoin + `
+        LEFT JOIN decisions
+          ON decisions.actor_user_id = likes.recipient_user_id
+         AND decisions.recipient_user_id = likes.actor_user_id
+        WHERE likes.recipient_user_id = $1
+          AND decisions.actor_user_id IS NULL
+          AND ` + excludeHiddenPairs("likes.actor_user_id", "likes.recipient_user_id") + `
+          AND ($3::timestamp IS NULL OR (likes.created_at, likes.actor_user_id) < ($3::timestamp, $4::uuid))
+        ORDER BY likes.created_at DESC, likes.actor_user_id DESC
+        LIMIT $2`
 
-	rows, err := r.db.QueryContext(ctx, query, recipientUserID, limit, offset)
+	createdAt, userID := cursorArgs(after)
+	rows, err := r.db.QueryContext(ctx, query, recipientUserID, limit, createdAt, userID)
 	if err != nil {
-		return nil, err
+		return nil, wrapError("failed to execute query", err)
 	}
 	defer rows.Close()
 
-	var likers []*explore.ListLikedYouResponse_Liker
-	for rows.Next() {
-		var liker explore.ListLikedYouResponse_Liker
-		var unixTimestamp float64 // Use float64 to handle double precision value from database
-		if err := rows.Scan(&liker.ActorId, &unixTimestamp); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %v", err)
-		}
-		liker.UnixTimestamp = uint64(unixTimestamp) // Convert float64 to uint64
+	return scanLikerRows(rows, includeProfile)
+}
 
-		likers = append(likers, &liker)
+// likerProfileQuery returns the columns and join that add the liker's profile to a query of
+// likes, or nothing when the profile is not wanted. The columns are userColumns of the liker.
+func likerProfileQuery(includeProfile bool) (columns, join string) {
+	if !includeProfile {
+		return "", ""
 	}
+	return `,
+               users.user_id, users.username, users.display_name, users.birthdate, users.gender, users.bio, users.created_at`, `
+        JOIN users ON users.user_id = likes.actor_user_id`
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, err
+// excludeHiddenPairs returns a condition that filters out rows whose user pair is hidden from
+// each other, because either side unmatched or blocked the other. The arguments are the column
+// references for the two users.
+func excludeHiddenPairs(actorColumn, recipientColumn string) string {
+	return fmt.Sprintf(`NOT EXISTS (
+              SELECT 1
+              FROM unmatches
+              WHERE (unmatches.actor_user_id = %[1]s AND unmatches.recipient_user_id = %[2]s)
+                 OR (unmatches.actor_user_id = %[2]s AND unmatches.recipient_user_id = %[1]s)
+          )
+          AND NOT EXISTS (
+              SELECT 1
+              FROM blocks
+              WHERE (blocks.blocker_user_id = %[1]s AND blocks.blocked_user_id = %[2]s)
+                 OR (blocks.blocker_user_id = %[2]s AND blocks.blocked_user_id = %[1]s)
+          )`, actorColumn, recipientColumn)
+}
+
+// cursorArgs converts an optional cursor into query arguments, using NULL when it is absent.
+func cursorArgs(after *Cursor) (interface{}, interface{}) {
+	if after == nil {
+		return nil, nil
 	}
+	return after.CreatedAt, after.UserID
+}
 
-	return likers, nil
+// lockPairs takes a transaction-scoped advisory lock on each unordered pair of users, so
+// transactions changing the likes between the same two users run one after the other. Without
+// it, two users liking each other at the same time would each miss the other's uncommitted like
+// and neither would report the match. Locks are taken in a fixed order to avoid deadlocks between
+// transactions locking several pairs, and are released when the transaction ends.
+func lockPairs(ctx context.Context, tx *sql.Tx, pairs []UserPair) error {
+	query := `
+        SELECT pg_advisory_xact_lock(pair_key)
+        FROM (
+            SELECT DISTINCT hashtextextended(
+                       LEAST(actor_user_id::text, recipient_user_id::text) || ':' ||
+                       GREATEST(actor_user_id::text, recipient_user_id::text), 0) AS pair_key
+            FROM unnest($1::uuid[], $2::uuid[]) AS pairs(actor_user_id, recipient_user_id)
+            ORDER BY pair_key
+        ) keys
+        ORDER BY pair_key`
+
+	actors, recipients := pairArrays(pairs)
+	if _, err := tx.ExecContext(ctx, query, actors, recipients); err != nil {
+		return wrapError("failed to lock user pair", err)
+	}
+	return nil
+}
+
+// pairOf returns the single pair of users for the methods that take several.
+func pairOf(actorUserID, recipientUserID string) []UserPair {
+	return []UserPair{{ActorUserID: actorUserID, RecipientUserID: recipientUserID}}
 }
 
-// GetNewLikedYou retrieves a list of new users who liked the recipient user.
-func (r *exploreRepository) GetNewLikedYou(ctx context.Context, recipientUserID string, limit, offset int) ([]*explore.ListLikedYouResponse_Liker, error) {
+// withLikeCounters runs change, a write to the likes, decisions, unmatches or blocks between
+// the pairs of users, and keeps the like_counters of both users of each pair in step with it.
+//
+// The pairs are locked first, so nothing else changes between them until the transaction ends.
+// Their contribution to the counters is then subtracted before change runs and added back
+// after, which moves the counters by exactly what change did however it affects them.
+func withLikeCounters(ctx context.Context, tx *sql.Tx, pairs []UserPair, change func() error) error {
+	if err := lockPairs(ctx, tx, pairs); err != nil {
+		return err
+	}
+	if err := adjustLikeCounters(ctx, tx, pairs, -1); err != nil {
+		return err
+	}
+	if err := change(); err != nil {
+		return err
+	}
+	return adjustLikeCounters(ctx, tx, pairs, 1)
+}
+
+// adjustLikeCounters adds sign times the current contribution of each unordered pair of users
+// to the like counters of both users. For each side of a pair, a visible like from the other
+// user counts as received, as new received if the side has not decided on the other user yet,
+// and as a match if the side likes the other user back.
+func adjustLikeCounters(ctx context.Context, tx *sql.Tx, pairs []UserPair, sign int) error {
 	query := `
-        SELECT actor_user_id, EXTRACT(EPOCH FROM created_at) AS unix_timestamp
-        FROM likes
-        WHERE recipient_user_id = $1 
-          AND actor_user_id NOT IN (
-              SELECT recipient_user_id 
-              FROM likes 
-              WHERE actor_user_id = $1
-          )
-        ORDER BY created_at DESC
-        LIMIT $2 OFFSET $3`
+        WITH pairs AS (
+            SELECT DISTINCT LEAST(actor_user_id, recipient_user_id) AS first_user_id,
+                   GREATEST(actor_user_id, recipient_user_id) AS second_user_id
+            FROM unnest($1::uuid[], $2::uuid[]) AS pairs(actor_user_id, recipient_user_id)
+        ),
+        sides AS (
+            SELECT first_user_id AS user_id, second_user_id AS other_user_id FROM pairs
+            UNION ALL
+            SELECT second_user_id, first_user_id FROM pairs
+        ),
+        contributions AS (
+            SELECT sides.user_id,
+                   COUNT(received.actor_user_id) AS received,
+                   COUNT(received.actor_user_id) FILTER (WHERE decisions.actor_user_id IS NULL) AS new_received,
+                   COUNT(received.actor_user_id) FILTER (WHERE given.actor_user_id IS NOT NULL) AS matches
+            FROM sides
+            LEFT JOIN likes received
+              ON received.actor_user_id = sides.other_user_id
+             AND received.recipient_user_id = sides.user_id
+             AND ` + excludeHiddenPairs("sides.user_id", "sides.other_user_id") + `
+            LEFT JOIN likes given
+              ON given.actor_user_id = sides.user_id
+             AND given.recipient_user_id = sides.other_user_id
+            LEFT JOIN decisions
+              ON decisions.actor_user_id = sides.user_id
+             AND decisions.recipient_user_id = sides.other_user_id
+            GROUP BY sides.user_id
+        )
+        INSERT INTO like_counters (user_id, received, new_received, matches)
+        SELECT user_id, $3 * received, $3 * new_received, $3 * matches
+        FROM contributions
+        WHERE received > 0
+        ORDER BY user_id
+        ON CONFLICT (user_id) DO UPDATE
+        SET received = like_counters.received + EXCLUDED.received,
+            new_received = like_counters.new_received + EXCLUDED.new_received,
+            matches = like_counters.matches + EXCLUDED.matches`
 
-	rows, err := r.db.QueryContext(ctx, query, recipientUserID, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
+	actors, recipients := pairArrays(pairs)
+	if _, err := tx.ExecContext(ctx, query, actors, recipients, sign); err != nil {
+		return wrapError("failed to update like counters", err)
 	}
-	defer rows.Close()
+	return nil
+}
 
-	var likers []*explore.ListLikedYouResponse_Liker
+// scanLikerRows reads (actor_user_id, created_at) rows into liker rows. With includeProfile,
+// each row continues with the liker's userColumns, see likerProfileQuery.
+func scanLikerRows(rows *sql.Rows, includeProfile bool) ([]LikerRow, error) {
+	var likers []LikerRow
 	for rows.Next() {
-		var liker explore.ListLikedYouResponse_Liker
-		var unixTimestamp float64
-		if err := rows.Scan(&liker.ActorId, &unixTimestamp); err != nil {
-			return nil, fmt.Errorf("failed to scan row: %w", err)
+		var actorID string
+		var createdAt time.Time
+		var profile *explore.LikerProfile
+		if includeProfile {
+			user, err := scanUser(prefixedRow{rows: rows, prefix: []any{&actorID, &createdAt}})
+			if err != nil {
+				return nil, wrapError("failed to scan row", err)
+			}
+			profile = likerProfile(user)
+		} else if err := rows.Scan(&actorID, &createdAt); err != nil {
+			return nil, wrapError("failed to scan row", err)
 		}
-		liker.UnixTimestamp = uint64(unixTimestamp)
 
-		likers = append(likers, &liker)
+		likers = append(likers, LikerRow{
+			Liker: &explore.ListLikedYouResponse_Liker{
+				ActorId:       actorID,
+				UnixTimestamp: uint64(createdAt.Unix()),
+				Profile:       profile,
+			},
+			Cursor: Cursor{CreatedAt: createdAt, UserID: actorID},
+		})
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration error: %w", err)
+		return nil, wrapError("rows iteration error", err)
 	}
 
 	return likers, nil
 }
 
-// CountLikes counts the number of users who liked the recipient user.
+// prefixedRow scans the leading columns of a row into prefix and passes the rest to the
+// scanner it is given to, such as scanUser.
+type prefixedRow struct {
+	rows   *sql.Rows
+	prefix []any
+}
+
+func (r prefixedRow) Scan(dest ...any) error {
+	return r.rows.Scan(slices.Concat(r.prefix, dest)...)
+}
+
+// likerProfile returns the public profile of a user who liked someone.
+func likerProfile(user *explore.User) *explore.LikerProfile {
+	return &explore.LikerProfile{
+		Username:      user.Username,
+		DisplayName:   user.DisplayName,
+		Birthdate:     user.Birthdate,
+		Gender:        user.Gender,
+		Bio:           user.Bio,
+		UnixTimestamp: user.UnixTimestamp,
+	}
+}
+
+// CountLikes counts the number of users who liked the recipient user, from the like counters.
 func (r *exploreRepository) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
-	var count int64
-	query := "SELECT COUNT(*) FROM likes WHERE recipient_user_id = $1"
-	err := r.db.QueryRowContext(ctx, query, recipientUserID).Scan(&count)
+	count, err := r.readLikeCounter(ctx, "received", recipientUserID)
+	if err != nil {
+		return 0, wrapError("failed to count likes", err)
+	}
+	return count, nil
+}
+
+// CountNewLikes counts the users who liked the recipient user and whom the recipient has not yet decided on,
+// from the like counters.
+func (r *exploreRepository) CountNewLikes(ctx context.Context, recipientUserID string) (int64, error) {
+	count, err := r.readLikeCounter(ctx, "new_received", recipientUserID)
 	if err != nil {
-		return 0, err
+		return 0, wrapError("failed to count new likes", err)
 	}
 	return count, nil
 }
 
+// readLikeCounter reads one column of the user's like counters. Users without a row have not
+// received any visible like, so their counters are zero.
+func (r *exploreRepository) readLikeCounter(ctx context.Context, column, userID string) (int64, error) {
+	var count int64
+	query := "SELECT COALESCE(SUM(" + column + "), 0) FROM like_counters WHERE user_id = $1"
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
 // InsertDecision records a user's decision (like/dislike) regarding another user.
-func (r *exploreRepository) InsertDecision(ctx context.Context, tx *sql.Tx, actorUserID, recipientUserID string, likedRecipient bool) error {
+//
+// The decisions table keeps the current decision per pair, with created_at set on the first
+// decision and updated_at on the latest one. Every call is also appended to decision_events
+// so the full history is preserved.
+func (r *exploreRepository) InsertDecision(ctx context.Context, transaction Tx, actorUserID, recipientUserID string, likedRecipient bool) error {
+	tx := sqlTx(transaction)
+
 	query := `
         INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient)
         VALUES ($1, $2, $3)
         ON CONFLICT (actor_user_id, recipient_user_id)
-        DO UPDATE SET liked_recipient = EXCLUDED.liked_recipient, created_at = CURRENT_TIMESTAMP`
-	_, err := tx.ExecContext(ctx, query, actorUserID, recipientUserID, likedRecipient)
+        DO UPDATE SET liked_recipient = EXCLUDED.liked_recipient, updated_at = CURRENT_TIMESTAMP`
+	// A first decision on a liker makes the like no longer new
+	err := withLikeCounters(ctx, tx, pairOf(actorUserID, recipientUserID), func() error {
+		if _, err := tx.ExecContext(ctx, query, actorUserID, recipientUserID, likedRecipient); err != nil {
+			return wrapError("failed to insert decision", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to insert decision: %w", err)
+		return err
+	}
+
+	eventQuery := "INSERT INTO decision_events (actor_user_id, recipient_user_id, liked_recipient) VALUES ($1, $2, $3)"
+	_, err = tx.ExecContext(ctx, eventQuery, actorUserID, recipientUserID, likedRecipient)
+	if err != nil {
+		return wrapError("failed to insert decision event", err)
 	}
 	return nil
 }
 
-// InsertLike records a like action from the actor user to the recipient user.
-func (r *exploreRepository) InsertLike(ctx context.Context, tx *sql.Tx, actorUserID, recipientUserID string) error {
+// InsertLike records a like action from the actor user to the recipient user and updates the
+// like counters of both users. It locks the pair until the transaction ends, so a
+// CheckMutualLike that follows in the same transaction sees any like the recipient committed
+// concurrently.
+func (r *exploreRepository) InsertLike(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) error {
+	tx := sqlTx(transaction)
+
 	query := "INSERT INTO likes (actor_user_id, recipient_user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
-	_, err := tx.ExecContext(ctx, query, actorUserID, recipientUserID)
+	return withLikeCounters(ctx, tx, pairOf(actorUserID, recipientUserID), func() error {
+		if _, err := tx.ExecContext(ctx, query, actorUserID, recipientUserID); err != nil {
+			return wrapError("failed to insert like", err)
+		}
+		return nil
+	})
+}
+
+// DeleteLike removes a like action from the actor user to the recipient user and updates the
+// like counters of both users. Like InsertLike, it locks the pair until the transaction ends.
+func (r *exploreRepository) DeleteLike(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) error {
+	tx := sqlTx(transaction)
+
+	query := "DELETE FROM likes WHERE actor_user_id = $1 AND recipient_user_id = $2"
+	return withLikeCounters(ctx, tx, pairOf(actorUserID, recipientUserID), func() error {
+		if _, err := tx.ExecContext(ctx, query, actorUserID, recipientUserID); err != nil {
+			return wrapError("failed to delete like", err)
+		}
+		return nil
+	})
+}
+
+// CheckMutualLike checks if there is a mutual like between the actor user and the recipient user.
+// Called after InsertLike in the same transaction, it reports a match exactly once when both
+// users like each other concurrently: the second transaction to take the pair lock sees the
+// first one's like.
+func (r *exploreRepository) CheckMutualLike(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) (bool, error) {
+	tx := sqlTx(transaction)
+
+	query := `
+        SELECT EXISTS (
+            SELECT 1
+            FROM likes
+            WHERE actor_user_id = $1
+              AND recipient_user_id = $2
+              AND ` + excludeHiddenPairs("likes.actor_user_id", "likes.recipient_user_id") + `
+        )`
+	var exists bool
+	err := tx.QueryRowContext(ctx, query, recipientUserID, actorUserID).Scan(&exists)
+	if err != nil {
+		return false, wrapError("failed to check mutual like", err)
+	}
+	return exists, nil
+}
+
+// GetMatches retrieves the users who share a mutual like with the given user, most recent match first.
+// The match time is the later of the two likes. If after is set, only older matches are returned.
+func (r *exploreRepository) GetMatches(ctx context.Context, userID string, limit int, after *Cursor) ([]MatchRow, error) {
+	query := `
+        SELECT user_id, matched_at
+        FROM (
+            SELECT given.recipient_user_id AS user_id,
+                   GREATEST(given.created_at, received.created_at) AS matched_at
+            FROM likes given
+            JOIN likes received
+              ON received.actor_user_id = given.recipient_user_id
+             AND received.recipient_user_id = given.actor_user_id
+            WHERE given.actor_user_id = $1
+              AND ` + excludeHiddenPairs("given.actor_user_id", "given.recipient_user_id") + `
+        ) matches
+        WHERE ($3::timestamp IS NULL OR (matched_at, user_id) < ($3::timestamp, $4::uuid))
+        ORDER BY matched_at DESC, user_id DESC
+        LIMIT $2`
+
+	createdAt, cursorUserID := cursorArgs(after)
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, createdAt, cursorUserID)
 	if err != nil {
-		return fmt.Errorf("failed to insert like: %w", err)
+		return nil, wrapError("failed to execute query", err)
+	}
+	defer rows.Close()
+
+	var matches []MatchRow
+	for rows.Next() {
+		var matchedUserID string
+		var matchedAt time.Time
+		if err := rows.Scan(&matchedUserID, &matchedAt); err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+
+		matches = append(matches, MatchRow{
+			Match: &explore.ListMatchesResponse_Match{
+				UserId:        matchedUserID,
+				UnixTimestamp: uint64(matchedAt.Unix()),
+			},
+			Cursor: Cursor{CreatedAt: matchedAt, UserID: matchedUserID},
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	return matches, nil
+}
+
+// CountMatches counts the users who share a mutual like with the given user, from the like counters.
+func (r *exploreRepository) CountMatches(ctx context.Context, userID string) (int64, error) {
+	count, err := r.readLikeCounter(ctx, "matches", userID)
+	if err != nil {
+		return 0, wrapError("failed to count matches", err)
+	}
+	return count, nil
+}
+
+// Unmatch removes the mutual like between the actor and the recipient and records that the
+// actor unmatched. Once unmatched, the pair is excluded from each other's liker and match lists.
+// It returns ErrNotFound if the users are not currently matched.
+func (r *exploreRepository) Unmatch(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) error {
+	tx := sqlTx(transaction)
+
+	deleteQuery := `
+        DELETE FROM likes
+        WHERE (actor_user_id = $1 AND recipient_user_id = $2)
+           OR (actor_user_id = $2 AND recipient_user_id = $1)`
+	insertQuery := "INSERT INTO unmatches (actor_user_id, recipient_user_id) VALUES ($1, $2)"
+
+	return withLikeCounters(ctx, tx, pairOf(actorUserID, recipientUserID), func() error {
+		result, err := tx.ExecContext(ctx, deleteQuery, actorUserID, recipientUserID)
+		if err != nil {
+			return wrapError("failed to delete likes", err)
+		}
+
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return wrapError("failed to delete likes", err)
+		}
+		if deleted != 2 {
+			return fmt.Errorf("failed to unmatch: %w: users are not matched", ErrNotFound)
+		}
+
+		if _, err := tx.ExecContext(ctx, insertQuery, actorUserID, recipientUserID); err != nil {
+			return wrapError("failed to insert unmatch", err)
+		}
+		return nil
+	})
+}
+
+// BlockUser records that the blocker has blocked the blocked user. Blocking is idempotent.
+// The likes between the two users stop counting towards their like counters.
+func (r *exploreRepository) BlockUser(ctx context.Context, blockerUserID, blockedUserID string) error {
+	query := "INSERT INTO blocks (blocker_user_id, blocked_user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+	return r.changeBlock(ctx, "failed to block user", query, blockerUserID, blockedUserID)
+}
+
+// UnblockUser removes a block previously placed by the blocker. Unblocking is idempotent.
+// The likes between the two users count towards their like counters again, unless they are
+// still hidden from each other.
+func (r *exploreRepository) UnblockUser(ctx context.Context, blockerUserID, blockedUserID string) error {
+	query := "DELETE FROM blocks WHERE blocker_user_id = $1 AND blocked_user_id = $2"
+	return r.changeBlock(ctx, "failed to unblock user", query, blockerUserID, blockedUserID)
+}
+
+// changeBlock runs a query adding or removing a block in its own transaction, together with
+// the like counter updates it causes.
+func (r *exploreRepository) changeBlock(ctx context.Context, msg, query, blockerUserID, blockedUserID string) error {
+	return WithTx(ctx, r.db, DefaultRetryPolicy, nil, func(tx *sql.Tx) error {
+		return withLikeCounters(ctx, tx, pairOf(blockerUserID, blockedUserID), func() error {
+			if _, err := tx.ExecContext(ctx, query, blockerUserID, blockedUserID); err != nil {
+				return wrapError(msg, err)
+			}
+			return nil
+		})
+	})
+}
+
+// IsBlocked checks if either user has blocked the other.
+func (r *exploreRepository) IsBlocked(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) (bool, error) {
+	tx := sqlTx(transaction)
+
+	query := `
+        SELECT EXISTS (
+            SELECT 1
+            FROM blocks
+            WHERE (blocker_user_id = $1 AND blocked_user_id = $2)
+               OR (blocker_user_id = $2 AND blocked_user_id = $1)
+        )`
+	var blocked bool
+	err := tx.QueryRowContext(ctx, query, actorUserID, recipientUserID).Scan(&blocked)
+	if err != nil {
+		return false, wrapError("failed to check block", err)
+	}
+	return blocked, nil
+}
+
+// ReportUser records a report filed by the reporter against the reported user.
+func (r *exploreRepository) ReportUser(ctx context.Context, reporterUserID, reportedUserID, reason string) error {
+	query := "INSERT INTO reports (reporter_user_id, reported_user_id, reason) VALUES ($1, $2, $3)"
+	_, err := r.db.ExecContext(ctx, query, reporterUserID, reportedUserID, reason)
+	if err != nil {
+		return wrapError("failed to report user", err)
 	}
 	return nil
 }
 
-// DeleteLike removes a like action from the actor user to the recipient user.
-func (r *exploreRepository) DeleteLike(ctx context.Context, tx *sql.Tx, actorUserID, recipientUserID string) error {
-	query := "DELETE FROM likes WHERE actor_user_id = $1 AND recipient_user_id = $2"
-	_, err := tx.ExecContext(ctx, query, actorUserID, recipientUserID)
+// GetDecisions retrieves the actor user's current decisions on other users, most recently
+// updated first. The filter restricts the result to likes or passes. If after is set, only
+// decisions older than the cursor are returned.
+func (r *exploreRepository) GetDecisions(ctx context.Context, actorUserID string, filter explore.DecisionFilter, limit int, after *Cursor) ([]DecisionRow, error) {
+	query := `
+        SELECT recipient_user_id, liked_recipient, updated_at
+        FROM decisions
+        WHERE actor_user_id = $1
+          AND ($5::boolean IS NULL OR liked_recipient = $5::boolean)
+          AND ($3::timestamp IS NULL OR (updated_at, recipient_user_id) < ($3::timestamp, $4::uuid))
+        ORDER BY updated_at DESC, recipient_user_id DESC
+        LIMIT $2`
+
+	var liked interface{}
+	switch filter {
+	case explore.DecisionFilter_DECISION_FILTER_LIKES:
+		liked = true
+	case explore.DecisionFilter_DECISION_FILTER_PASSES:
+		liked = false
+	}
+
+	updatedAt, cursorUserID := cursorArgs(after)
+	rows, err := r.db.QueryContext(ctx, query, actorUserID, limit, updatedAt, cursorUserID, liked)
+	if err != nil {
+		return nil, wrapError("failed to execute query", err)
+	}
+	defer rows.Close()
+
+	var decisions []DecisionRow
+	for rows.Next() {
+		var recipientUserID string
+		var likedRecipient bool
+		var decidedAt time.Time
+		if err := rows.Scan(&recipientUserID, &likedRecipient, &decidedAt); err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+
+		decisions = append(decisions, DecisionRow{
+			Decision: &explore.ListDecisionsResponse_Decision{
+				RecipientUserId: recipientUserID,
+				LikedRecipient:  likedRecipient,
+				UnixTimestamp:   uint64(decidedAt.Unix()),
+			},
+			Cursor: Cursor{CreatedAt: decidedAt, UserID: recipientUserID},
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	return decisions, nil
+}
+
+// GetExploreFeed retrieves the users the given user has not decided on yet, leaving out the
+// user and anyone hidden from them by a block or unmatch, in the given order. If after is set,
+// only candidates after the cursor are returned.
+func (r *exploreRepository) GetExploreFeed(ctx context.Context, userID string, order FeedOrder, limit int, after *FeedCursor) ([]CandidateRow, error) {
+	// Candidates are paged by (likers_first, ranked_at, user_id) whatever the order, with
+	// likers_first always false unless likers come first
+	query := `
+        SELECT user_id, username, liked_you, likers_first, ranked_at
+        FROM (
+            SELECT users.user_id, users.username,
+                   likes.created_at IS NOT NULL AS liked_you,
+                   $6::boolean AND likes.created_at IS NOT NULL AS likers_first,
+                   CASE WHEN $6::boolean THEN COALESCE(likes.created_at, users.created_at, 'epoch')
+                        ELSE COALESCE(users.created_at, 'epoch')
+                   END AS ranked_at
+            FROM users
+            LEFT JOIN likes
+              ON likes.actor_user_id = users.user_id
+             AND likes.recipient_user_id = $1
+            WHERE users.user_id <> $1
+              AND NOT EXISTS (
+                  SELECT 1
+                  FROM decisions
+                  WHERE decisions.actor_user_id = $1
+                    AND decisions.recipient_user_id = users.user_id
+              )
+              AND ` + excludeHiddenPairs("users.user_id", "$1::uuid") + `
+        ) candidates
+        WHERE ($3::boolean IS NULL OR (likers_first, ranked_at, user_id) < ($3::boolean, $4::timestamp, $5::uuid))
+        ORDER BY likers_first DESC, ranked_at DESC, user_id DESC
+        LIMIT $2`
+
+	var likedYou, rankedAt, cursorUserID interface{}
+	if after != nil {
+		likedYou = after.LikedYou
+		rankedAt, cursorUserID = cursorArgs(&after.Cursor)
+	}
+	likersFirst := order == FeedOrderLikersFirst
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, likedYou, rankedAt, cursorUserID, likersFirst)
+	if err != nil {
+		return nil, wrapError("failed to execute query", err)
+	}
+	defer rows.Close()
+
+	var candidates []CandidateRow
+	for rows.Next() {
+		var candidate explore.GetExploreFeedResponse_Candidate
+		var cursor FeedCursor
+		if err := rows.Scan(&candidate.UserId, &candidate.Username, &candidate.LikedYou, &cursor.LikedYou, &cursor.CreatedAt); err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+
+		cursor.UserID = candidate.UserId
+		candidates = append(candidates, CandidateRow{Candidate: &candidate, Cursor: cursor})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	return candidates, nil
+}
+
+// InsertOutboxEvent queues an event in the outbox as part of the transaction, so it is only
+// published if the transaction commits.
+func (r *exploreRepository) InsertOutboxEvent(ctx context.Context, transaction Tx, eventType string, payload []byte) error {
+	tx := sqlTx(transaction)
+
+	query := "INSERT INTO outbox (event_type, payload) VALUES ($1, $2)"
+	_, err := tx.ExecContext(ctx, query, eventType, payload)
 	if err != nil {
-		return fmt.Errorf("failed to delete like: %w", err)
+		return wrapError("failed to insert outbox event", err)
 	}
 	return nil
 }
 
-// CheckMutualLike checks if there is a mutual like between the actor user and the recipient user.
-func (r *exploreRepository) CheckMutualLike(ctx context.Context, tx *sql.Tx, actorUserID, recipientUserID string) (bool, error) {
-	query := "SELECT EXISTS (SELECT 1 FROM likes WHERE actor_user_id = $1 AND recipient_user_id = $2)"
-	var exists bool
-	err := tx.QueryRowContext(ctx, query, recipientUserID, actorUserID).Scan(&exists)
+// ReserveIdempotencyKey claims an idempotency key for a decision within the transaction.
+//
+// It returns nil if the key is new or has expired, in which case the caller records the
+// decision and completes the key with CompleteIdempotencyKey before committing. If the key was
+// already used within the ttl, the decision recorded under it is returned instead. A concurrent
+// transaction reserving the same key waits until this one commits or rolls back. Reserving a
+// key again in the transaction that reserved it, before completing it, fails with ErrConflict.
+func (r *exploreRepository) ReserveIdempotencyKey(ctx context.Context, transaction Tx, actorUserID, key, recipientUserID string, likedRecipient bool, ttl time.Duration) (*IdempotentDecision, error) {
+	tx := sqlTx(transaction)
+
+	reserveQuery := `
+        INSERT INTO idempotency_keys (actor_user_id, idempotency_key, recipient_user_id, liked_recipient)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (actor_user_id, idempotency_key) DO UPDATE
+        SET recipient_user_id = EXCLUDED.recipient_user_id,
+            liked_recipient = EXCLUDED.liked_recipient,
+            mutual_likes = NULL,
+            created_at = CURRENT_TIMESTAMP
+        WHERE idempotency_keys.created_at <= CURRENT_TIMESTAMP - $5 * INTERVAL '1 millisecond'`
+	result, err := tx.ExecContext(ctx, reserveQuery, actorUserID, key, recipientUserID, likedRecipient, ttl.Milliseconds())
 	if err != nil {
-		return false, fmt.Errorf("failed to check mutual like: %w", err)
+		return nil, wrapError("failed to reserve idempotency key", err)
 	}
-	return exists, nil
+
+	reserved, err := result.RowsAffected()
+	if err != nil {
+		return nil, wrapError("failed to reserve idempotency key", err)
+	}
+	if reserved == 1 {
+		return nil, nil
+	}
+
+	// The key is in use. Other transactions only see it once completed, but this one may have
+	// reserved it earlier without completing it yet, leaving mutual_likes NULL
+	query := `
+        SELECT recipient_user_id, liked_recipient, mutual_likes
+        FROM idempotency_keys
+        WHERE actor_user_id = $1 AND idempotency_key = $2`
+	var decision IdempotentDecision
+	var mutualLikes sql.NullBool
+	err = tx.QueryRowContext(ctx, query, actorUserID, key).Scan(&decision.RecipientUserID, &decision.LikedRecipient, &mutualLikes)
+	if err != nil {
+		return nil, wrapError("failed to get idempotency key", err)
+	}
+	if !mutualLikes.Valid {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w: key %s is reserved but not completed", ErrConflict, key)
+	}
+	decision.MutualLikes = mutualLikes.Bool
+	return &decision, nil
+}
+
+// CompleteIdempotencyKey stores the outcome of the decision recorded under a reserved key.
+func (r *exploreRepository) CompleteIdempotencyKey(ctx context.Context, transaction Tx, actorUserID, key string, mutualLikes bool) error {
+	tx := sqlTx(transaction)
+
+	query := "UPDATE idempotency_keys SET mutual_likes = $3 WHERE actor_user_id = $1 AND idempotency_key = $2"
+	_, err := tx.ExecContext(ctx, query, actorUserID, key, mutualLikes)
+	if err != nil {
+		return wrapError("failed to complete idempotency key", err)
+	}
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys removes the keys older than ttl and returns how many were removed.
+func (r *exploreRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, ttl time.Duration) (int64, error) {
+	query := "DELETE FROM idempotency_keys WHERE created_at <= CURRENT_TIMESTAMP - $1 * INTERVAL '1 millisecond'"
+	result, err := r.db.ExecContext(ctx, query, ttl.Milliseconds())
+	if err != nil {
+		return 0, wrapError("failed to delete expired idempotency keys", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, wrapError("failed to delete expired idempotency keys", err)
+	}
+	return deleted, nil
+}
+
+// pairArrays splits pairs into parallel actor and recipient arrays for unnest.
+func pairArrays(pairs []UserPair) (interface{}, interface{}) {
+	actors := make([]string, len(pairs))
+	recipients := make([]string, len(pairs))
+	for i, pair := range pairs {
+		actors[i] = pair.ActorUserID
+		recipients[i] = pair.RecipientUserID
+	}
+	return pq.Array(actors), pq.Array(recipients)
+}
+
+// scanBools reads single boolean column rows, in order.
+func scanBools(rows *sql.Rows, capacity int) ([]bool, error) {
+	defer rows.Close()
+
+	values := make([]bool, 0, capacity)
+	for rows.Next() {
+		var value bool
+		if err := rows.Scan(&value); err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+		values = append(values, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	return values, nil
+}
+
+// FindExistingUsers returns the subset of the given user IDs that belong to existing users.
+func (r *exploreRepository) FindExistingUsers(ctx context.Context, transaction Tx, userIDs []string) (map[string]bool, error) {
+	tx := sqlTx(transaction)
+
+	query := "SELECT user_id FROM users WHERE user_id = ANY($1::uuid[])"
+	rows, err := tx.QueryContext(ctx, query, pq.Array(userIDs))
+	if err != nil {
+		return nil, wrapError("failed to find users", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[string]bool, len(userIDs))
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+		existing[userID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	return existing, nil
+}
+
+// CheckBlockedPairs checks, for each pair, if either user has blocked the other. The result
+// is in the order of pairs.
+func (r *exploreRepository) CheckBlockedPairs(ctx context.Context, transaction Tx, pairs []UserPair) ([]bool, error) {
+	tx := sqlTx(transaction)
+
+	query := `
+        SELECT EXISTS (
+            SELECT 1
+            FROM blocks
+            WHERE (blocker_user_id = pairs.actor_user_id AND blocked_user_id = pairs.recipient_user_id)
+               OR (blocker_user_id = pairs.recipient_user_id AND blocked_user_id = pairs.actor_user_id)
+        )
+        FROM unnest($1::uuid[], $2::uuid[]) WITH ORDINALITY AS pairs(actor_user_id, recipient_user_id, position)
+        ORDER BY pairs.position`
+
+	actors, recipients := pairArrays(pairs)
+	rows, err := tx.QueryContext(ctx, query, actors, recipients)
+	if err != nil {
+		return nil, wrapError("failed to check blocks", err)
+	}
+	return scanBools(rows, len(pairs))
+}
+
+// InsertDecisions records a batch of decisions, as InsertDecision does for one. The batch
+// must not contain the same pair twice.
+func (r *exploreRepository) InsertDecisions(ctx context.Context, transaction Tx, decisions []BatchDecision) error {
+	tx := sqlTx(transaction)
+
+	pairs := make([]UserPair, len(decisions))
+	liked := make([]bool, len(decisions))
+	for i, decision := range decisions {
+		pairs[i] = decision.UserPair
+		liked[i] = decision.LikedRecipient
+	}
+	actors, recipients := pairArrays(pairs)
+
+	query := `
+        INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient)
+        SELECT * FROM unnest($1::uuid[], $2::uuid[], $3::boolean[])
+        ON CONFLICT (actor_user_id, recipient_user_id)
+        DO UPDATE SET liked_recipient = EXCLUDED.liked_recipient, updated_at = CURRENT_TIMESTAMP`
+	// This locks every pair of the batch up front, so the like changes that follow only take
+	// locks that are already held and cannot deadlock with another batch
+	err := withLikeCounters(ctx, tx, pairs, func() error {
+		if _, err := tx.ExecContext(ctx, query, actors, recipients, pq.Array(liked)); err != nil {
+			return wrapError("failed to insert decisions", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	eventQuery := `
+        INSERT INTO decision_events (actor_user_id, recipient_user_id, liked_recipient)
+        SELECT * FROM unnest($1::uuid[], $2::uuid[], $3::boolean[])`
+	_, err = tx.ExecContext(ctx, eventQuery, actors, recipients, pq.Array(liked))
+	if err != nil {
+		return wrapError("failed to insert decision events", err)
+	}
+	return nil
+}
+
+// InsertLikes records a like for each pair and updates the like counters. Existing likes are kept.
+func (r *exploreRepository) InsertLikes(ctx context.Context, transaction Tx, pairs []UserPair) error {
+	tx := sqlTx(transaction)
+
+	query := `
+        INSERT INTO likes (actor_user_id, recipient_user_id)
+        SELECT * FROM unnest($1::uuid[], $2::uuid[])
+        ON CONFLICT DO NOTHING`
+
+	actors, recipients := pairArrays(pairs)
+	return withLikeCounters(ctx, tx, pairs, func() error {
+		if _, err := tx.ExecContext(ctx, query, actors, recipients); err != nil {
+			return wrapError("failed to insert likes", err)
+		}
+		return nil
+	})
+}
+
+// DeleteLikes removes the like of each pair, if any, and updates the like counters.
+func (r *exploreRepository) DeleteLikes(ctx context.Context, transaction Tx, pairs []UserPair) error {
+	tx := sqlTx(transaction)
+
+	query := `
+        DELETE FROM likes
+        USING unnest($1::uuid[], $2::uuid[]) AS pairs(actor_user_id, recipient_user_id)
+        WHERE likes.actor_user_id = pairs.actor_user_id
+          AND likes.recipient_user_id = pairs.recipient_user_id`
+
+	actors, recipients := pairArrays(pairs)
+	return withLikeCounters(ctx, tx, pairs, func() error {
+		if _, err := tx.ExecContext(ctx, query, actors, recipients); err != nil {
+			return wrapError("failed to delete likes", err)
+		}
+		return nil
+	})
+}
+
+// CheckMutualLikes checks, for each pair, if the recipient also likes the actor, as
+// CheckMutualLike does for one pair. The result is in the order of pairs.
+func (r *exploreRepository) CheckMutualLikes(ctx context.Context, transaction Tx, pairs []UserPair) ([]bool, error) {
+	tx := sqlTx(transaction)
+
+	query := `
+        SELECT EXISTS (
+            SELECT 1
+            FROM likes
+            WHERE actor_user_id = pairs.recipient_user_id
+              AND recipient_user_id = pairs.actor_user_id
+              AND ` + excludeHiddenPairs("likes.actor_user_id", "likes.recipient_user_id") + `
+        )
+        FROM unnest($1::uuid[], $2::uuid[]) WITH ORDINALITY AS pairs(actor_user_id, recipient_user_id, position)
+        ORDER BY pairs.position`
+
+	actors, recipients := pairArrays(pairs)
+	rows, err := tx.QueryContext(ctx, query, actors, recipients)
+	if err != nil {
+		return nil, wrapError("failed to check mutual likes", err)
+	}
+	return scanBools(rows, len(pairs))
+}
+
+// InsertOutboxEvents queues several events in the outbox as part of the transaction, in order.
+func (r *exploreRepository) InsertOutboxEvents(ctx context.Context, transaction Tx, events []OutboxMessage) error {
+	tx := sqlTx(transaction)
+
+	eventTypes := make([]string, len(events))
+	payloads := make([]string, len(events))
+	for i, event := range events {
+		eventTypes[i] = event.EventType
+		payloads[i] = string(event.Payload)
+	}
+
+	query := `
+        INSERT INTO outbox (event_type, payload)
+        SELECT event_type, payload
+        FROM unnest($1::varchar[], $2::jsonb[]) WITH ORDINALITY AS events(event_type, payload, position)
+        ORDER BY events.position`
+	_, err := tx.ExecContext(ctx, query, pq.Array(eventTypes), pq.Array(payloads))
+	if err != nil {
+		return wrapError("failed to insert outbox events", err)
+	}
+	return nil
 }
@@ -0,0 +1,578 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+// conformanceStore is an ExploreRepository under test, together with a way to create users in
+// its storage.
+type conformanceStore struct {
+	repo     repository.ExploreRepository
+	addUsers func(t *testing.T, userIDs ...uuid.UUID)
+}
+
+// runExploreRepositoryConformance checks the behaviour every ExploreRepository implementation
+// must share. newStore is called for each subtest; users are created with fresh IDs so the
+// subtests do not see each other's data.
+func runExploreRepositoryConformance(t *testing.T, newStore func(t *testing.T) conformanceStore) {
+	ctx := context.Background()
+
+	// users creates n users and returns their IDs
+	users := func(t *testing.T, store conformanceStore, n int) []string {
+		ids := make([]uuid.UUID, n)
+		userIDs := make([]string, n)
+		for i := range ids {
+			ids[i] = uuid.New()
+			userIDs[i] = ids[i].String()
+		}
+		store.addUsers(t, ids...)
+		return userIDs
+	}
+
+	inTx := func(t *testing.T, store conformanceStore, fn func(tx repository.Tx) error) {
+		require.NoError(t, store.repo.WithTx(ctx, fn))
+	}
+
+	// decide records a decision the way PutDecision does
+	decide := func(t *testing.T, store conformanceStore, actorUserID, recipientUserID string, liked bool) {
+		inTx(t, store, func(tx repository.Tx) error {
+			if err := store.repo.InsertDecision(ctx, tx, actorUserID, recipientUserID, liked); err != nil {
+				return err
+			}
+			if liked {
+				return store.repo.InsertLike(ctx, tx, actorUserID, recipientUserID)
+			}
+			return store.repo.DeleteLike(ctx, tx, actorUserID, recipientUserID)
+		})
+	}
+
+	// tick makes sure the next write gets a later timestamp than the previous one
+	tick := func() { time.Sleep(2 * time.Millisecond) }
+
+	likerIDs := func(rows []repository.LikerRow) []string {
+		ids := make([]string, len(rows))
+		for i, row := range rows {
+			ids[i] = row.Liker.ActorId
+		}
+		return ids
+	}
+
+	matchIDs := func(rows []repository.MatchRow) []string {
+		ids := make([]string, len(rows))
+		for i, row := range rows {
+			ids[i] = row.Match.UserId
+		}
+		return ids
+	}
+
+	t.Run("liked you is newest first and paginates", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 4)
+		recipient, first, second, third := ids[0], ids[1], ids[2], ids[3]
+
+		for _, liker := range []string{first, second, third} {
+			decide(t, store, liker, recipient, true)
+			tick()
+		}
+
+		page, err := store.repo.GetLikedYou(ctx, recipient, false, 2, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{third, second}, likerIDs(page))
+
+		page, err = store.repo.GetLikedYou(ctx, recipient, false, 2, &page[1].Cursor)
+		require.NoError(t, err)
+		assert.Equal(t, []string{first}, likerIDs(page))
+
+		count, err := store.repo.CountLikes(ctx, recipient)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("new liked you excludes users decided on", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 3)
+		recipient, passed, pending := ids[0], ids[1], ids[2]
+
+		decide(t, store, passed, recipient, true)
+		decide(t, store, pending, recipient, true)
+		decide(t, store, recipient, passed, false)
+
+		likers, err := store.repo.GetNewLikedYou(ctx, recipient, false, 10, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{pending}, likerIDs(likers))
+
+		count, err := store.repo.CountNewLikes(ctx, recipient)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+
+		count, err = store.repo.CountLikes(ctx, recipient)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("decisions are upserted", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 2)
+		actor, recipient := ids[0], ids[1]
+
+		decide(t, store, actor, recipient, true)
+		tick()
+		decide(t, store, actor, recipient, false)
+
+		decisions, err := store.repo.GetDecisions(ctx, actor, explore.DecisionFilter_DECISION_FILTER_ALL, 10, nil)
+		require.NoError(t, err)
+		require.Len(t, decisions, 1)
+		assert.Equal(t, recipient, decisions[0].Decision.RecipientUserId)
+		assert.False(t, decisions[0].Decision.LikedRecipient)
+
+		likes, err := store.repo.GetDecisions(ctx, actor, explore.DecisionFilter_DECISION_FILTER_LIKES, 10, nil)
+		require.NoError(t, err)
+		assert.Empty(t, likes)
+
+		count, err := store.repo.CountLikes(ctx, recipient)
+		require.NoError(t, err)
+		assert.Zero(t, count, "passing removes the earlier like")
+	})
+
+	t.Run("decisions are most recently updated first and paginate", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 4)
+		actor, first, second, third := ids[0], ids[1], ids[2], ids[3]
+
+		decide(t, store, actor, first, true)
+		tick()
+		decide(t, store, actor, second, false)
+		tick()
+		decide(t, store, actor, third, true)
+		tick()
+		// Deciding again moves the decision to the front
+		decide(t, store, actor, first, true)
+
+		page, err := store.repo.GetDecisions(ctx, actor, explore.DecisionFilter_DECISION_FILTER_ALL, 2, nil)
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, first, page[0].Decision.RecipientUserId)
+		assert.Equal(t, third, page[1].Decision.RecipientUserId)
+
+		page, err = store.repo.GetDecisions(ctx, actor, explore.DecisionFilter_DECISION_FILTER_ALL, 2, &page[1].Cursor)
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Equal(t, second, page[0].Decision.RecipientUserId)
+
+		passes, err := store.repo.GetDecisions(ctx, actor, explore.DecisionFilter_DECISION_FILTER_PASSES, 10, nil)
+		require.NoError(t, err)
+		require.Len(t, passes, 1)
+		assert.Equal(t, second, passes[0].Decision.RecipientUserId)
+	})
+
+	t.Run("liking again keeps the original like", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 2)
+		actor, recipient := ids[0], ids[1]
+
+		decide(t, store, actor, recipient, true)
+		before, err := store.repo.GetLikedYou(ctx, recipient, false, 10, nil)
+		require.NoError(t, err)
+
+		tick()
+		decide(t, store, actor, recipient, true)
+		after, err := store.repo.GetLikedYou(ctx, recipient, false, 10, nil)
+		require.NoError(t, err)
+
+		require.Len(t, after, 1)
+		assert.True(t, before[0].Cursor.CreatedAt.Equal(after[0].Cursor.CreatedAt))
+	})
+
+	t.Run("unknown users are rejected", func(t *testing.T) {
+		store := newStore(t)
+		actor := users(t, store, 1)[0]
+		unknown := uuid.New().String()
+
+		err := store.repo.WithTx(ctx, func(tx repository.Tx) error {
+			return store.repo.InsertLike(ctx, tx, actor, unknown)
+		})
+		assert.ErrorIs(t, err, repository.ErrInvalidReference)
+
+		err = store.repo.WithTx(ctx, func(tx repository.Tx) error {
+			return store.repo.InsertDecision(ctx, tx, unknown, actor, false)
+		})
+		assert.ErrorIs(t, err, repository.ErrInvalidReference)
+	})
+
+	t.Run("mutual likes make a match", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 2)
+		first, second := ids[0], ids[1]
+
+		decide(t, store, first, second, true)
+		tick()
+
+		var mutual bool
+		inTx(t, store, func(tx repository.Tx) error {
+			if err := store.repo.InsertLike(ctx, tx, second, first); err != nil {
+				return err
+			}
+			var err error
+			mutual, err = store.repo.CheckMutualLike(ctx, tx, second, first)
+			return err
+		})
+		assert.True(t, mutual)
+
+		secondLike, err := store.repo.GetLikedYou(ctx, first, false, 10, nil)
+		require.NoError(t, err)
+
+		matches, err := store.repo.GetMatches(ctx, first, 10, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{second}, matchIDs(matches))
+		assert.True(t, matches[0].Cursor.CreatedAt.Equal(secondLike[0].Cursor.CreatedAt), "matched when the later like was made")
+
+		count, err := store.repo.CountMatches(ctx, second)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("unmatch hides the pair", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 2)
+		first, second := ids[0], ids[1]
+
+		decide(t, store, first, second, true)
+		decide(t, store, second, first, true)
+		inTx(t, store, func(tx repository.Tx) error {
+			return store.repo.Unmatch(ctx, tx, first, second)
+		})
+
+		count, err := store.repo.CountMatches(ctx, first)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		// Liking again after an unmatch does not bring the pair back
+		decide(t, store, second, first, true)
+		count, err = store.repo.CountLikes(ctx, first)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		err = store.repo.WithTx(ctx, func(tx repository.Tx) error {
+			return store.repo.Unmatch(ctx, tx, first, second)
+		})
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("blocks hide the pair in both directions until lifted", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 2)
+		first, second := ids[0], ids[1]
+
+		decide(t, store, first, second, true)
+		decide(t, store, second, first, true)
+		require.NoError(t, store.repo.BlockUser(ctx, first, second))
+		require.NoError(t, store.repo.BlockUser(ctx, first, second), "blocking is idempotent")
+
+		matches, err := store.repo.GetMatches(ctx, second, 10, nil)
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+
+		likers, err := store.repo.GetLikedYou(ctx, first, false, 10, nil)
+		require.NoError(t, err)
+		assert.Empty(t, likers)
+
+		var blocked bool
+		inTx(t, store, func(tx repository.Tx) error {
+			var err error
+			blocked, err = store.repo.IsBlocked(ctx, tx, second, first)
+			return err
+		})
+		assert.True(t, blocked)
+
+		require.NoError(t, store.repo.UnblockUser(ctx, first, second))
+		count, err := store.repo.CountMatches(ctx, second)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("counts follow likes, decisions, unmatches and blocks", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 4)
+		user, matched, pending, blocked := ids[0], ids[1], ids[2], ids[3]
+
+		// assertCounts checks the counts against the lists they count
+		assertCounts := func(likes, newLikes, matches int64) {
+			t.Helper()
+			liked, err := store.repo.GetLikedYou(ctx, user, false, 10, nil)
+			require.NoError(t, err)
+			newLiked, err := store.repo.GetNewLikedYou(ctx, user, false, 10, nil)
+			require.NoError(t, err)
+			matchRows, err := store.repo.GetMatches(ctx, user, 10, nil)
+			require.NoError(t, err)
+
+			count, err := store.repo.CountLikes(ctx, user)
+			require.NoError(t, err)
+			assert.Equal(t, likes, count)
+			assert.Len(t, liked, int(likes))
+
+			count, err = store.repo.CountNewLikes(ctx, user)
+			require.NoError(t, err)
+			assert.Equal(t, newLikes, count)
+			assert.Len(t, newLiked, int(newLikes))
+
+			count, err = store.repo.CountMatches(ctx, user)
+			require.NoError(t, err)
+			assert.Equal(t, matches, count)
+			assert.Len(t, matchRows, int(matches))
+		}
+
+		assertCounts(0, 0, 0)
+
+		decide(t, store, matched, user, true)
+		decide(t, store, pending, user, true)
+		decide(t, store, blocked, user, true)
+		assertCounts(3, 3, 0)
+
+		decide(t, store, user, matched, true)
+		assertCounts(3, 2, 1)
+
+		require.NoError(t, store.repo.BlockUser(ctx, user, blocked))
+		assertCounts(2, 1, 1)
+
+		decide(t, store, pending, user, false)
+		assertCounts(1, 0, 1)
+
+		inTx(t, store, func(tx repository.Tx) error {
+			return store.repo.Unmatch(ctx, tx, matched, user)
+		})
+		assertCounts(0, 0, 0)
+
+		require.NoError(t, store.repo.UnblockUser(ctx, user, blocked))
+		assertCounts(1, 1, 0)
+
+		inTx(t, store, func(tx repository.Tx) error {
+			return store.repo.InsertLikes(ctx, tx, []repository.UserPair{
+				{ActorUserID: pending, RecipientUserID: user},
+				{ActorUserID: user, RecipientUserID: blocked},
+			})
+		})
+		assertCounts(2, 2, 1)
+
+		inTx(t, store, func(tx repository.Tx) error {
+			return store.repo.DeleteLikes(ctx, tx, []repository.UserPair{
+				{ActorUserID: blocked, RecipientUserID: user},
+			})
+		})
+		assertCounts(1, 1, 0)
+	})
+
+	t.Run("explore feed lists undecided users with likers first", func(t *testing.T) {
+		store := newStore(t)
+		// Users are created one at a time so they join in this order
+		var ids []string
+		for i := 0; i < 8; i++ {
+			ids = append(ids, users(t, store, 1)[0])
+			tick()
+		}
+		user, likedLast, likedFirst, older, newer, passed, blocked, blocker :=
+			ids[0], ids[1], ids[2], ids[3], ids[4], ids[5], ids[6], ids[7]
+
+		decide(t, store, likedFirst, user, true)
+		tick()
+		decide(t, store, likedLast, user, true)
+		decide(t, store, passed, user, true)
+		decide(t, store, user, passed, false)
+		require.NoError(t, store.repo.BlockUser(ctx, user, blocked))
+		require.NoError(t, store.repo.BlockUser(ctx, blocker, user))
+
+		// Other users may share the storage, so only the ones created here are compared
+		own := make(map[string]bool)
+		for _, id := range ids {
+			own[id] = true
+		}
+		readFeed := func(order repository.FeedOrder, limit int) (candidateIDs []string, likedYou []bool) {
+			var after *repository.FeedCursor
+			for {
+				page, err := store.repo.GetExploreFeed(ctx, user, order, limit, after)
+				require.NoError(t, err)
+				for _, row := range page {
+					if own[row.Candidate.UserId] {
+						candidateIDs = append(candidateIDs, row.Candidate.UserId)
+						likedYou = append(likedYou, row.Candidate.LikedYou)
+					}
+				}
+				if len(page) < limit {
+					return candidateIDs, likedYou
+				}
+				after = &page[len(page)-1].Cursor
+			}
+		}
+
+		// Likers are ordered by the time of their like, everyone else by the time they joined
+		candidateIDs, likedYou := readFeed(repository.FeedOrderLikersFirst, 1000)
+		assert.Equal(t, []string{likedLast, likedFirst, newer, older}, candidateIDs)
+		assert.Equal(t, []bool{true, true, false, false}, likedYou)
+
+		pagedIDs, _ := readFeed(repository.FeedOrderLikersFirst, 2)
+		assert.Equal(t, candidateIDs, pagedIDs)
+
+		// Newest first ignores the likes for the order, not for liked_you
+		candidateIDs, likedYou = readFeed(repository.FeedOrderNewestFirst, 1000)
+		assert.Equal(t, []string{newer, older, likedFirst, likedLast}, candidateIDs)
+		assert.Equal(t, []bool{false, false, true, true}, likedYou)
+
+		pagedIDs, _ = readFeed(repository.FeedOrderNewestFirst, 2)
+		assert.Equal(t, candidateIDs, pagedIDs)
+	})
+
+	t.Run("rolled back transactions leave no trace", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 2)
+		actor, recipient := ids[0], ids[1]
+
+		failure := errors.New("failure")
+		err := store.repo.WithTx(ctx, func(tx repository.Tx) error {
+			if err := store.repo.InsertDecision(ctx, tx, actor, recipient, true); err != nil {
+				return err
+			}
+			if err := store.repo.InsertLike(ctx, tx, actor, recipient); err != nil {
+				return err
+			}
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		count, err := store.repo.CountLikes(ctx, recipient)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		decisions, err := store.repo.GetDecisions(ctx, actor, explore.DecisionFilter_DECISION_FILTER_ALL, 10, nil)
+		require.NoError(t, err)
+		assert.Empty(t, decisions)
+	})
+
+	t.Run("idempotency keys replay the completed decision until they expire", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 2)
+		actor, recipient := ids[0], ids[1]
+
+		reserve := func(ttl time.Duration) *repository.IdempotentDecision {
+			var original *repository.IdempotentDecision
+			inTx(t, store, func(tx repository.Tx) error {
+				var err error
+				original, err = store.repo.ReserveIdempotencyKey(ctx, tx, actor, "swipe-1", recipient, true, ttl)
+				if err != nil || original != nil {
+					return err
+				}
+				return store.repo.CompleteIdempotencyKey(ctx, tx, actor, "swipe-1", true)
+			})
+			return original
+		}
+
+		assert.Nil(t, reserve(time.Hour))
+		assert.Equal(t, &repository.IdempotentDecision{RecipientUserID: recipient, LikedRecipient: true, MutualLikes: true}, reserve(time.Hour))
+
+		tick()
+		assert.Nil(t, reserve(time.Millisecond), "an expired key is reserved again")
+	})
+
+	t.Run("reserving a key twice before completing it is a conflict", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 2)
+		actor, recipient := ids[0], ids[1]
+
+		err := store.repo.WithTx(ctx, func(tx repository.Tx) error {
+			original, err := store.repo.ReserveIdempotencyKey(ctx, tx, actor, "swipe-1", recipient, true, time.Hour)
+			require.NoError(t, err)
+			require.Nil(t, original)
+
+			_, err = store.repo.ReserveIdempotencyKey(ctx, tx, actor, "swipe-1", recipient, true, time.Hour)
+			return err
+		})
+		assert.ErrorIs(t, err, repository.ErrConflict)
+	})
+
+	t.Run("batch methods match their single pair counterparts", func(t *testing.T) {
+		store := newStore(t)
+		ids := users(t, store, 4)
+		actor, liked, passed, blocker := ids[0], ids[1], ids[2], ids[3]
+		unknown := uuid.New().String()
+
+		decide(t, store, liked, actor, true)
+		decide(t, store, actor, passed, true)
+		require.NoError(t, store.repo.BlockUser(ctx, blocker, actor))
+
+		pairs := []repository.UserPair{
+			{ActorUserID: actor, RecipientUserID: liked},
+			{ActorUserID: actor, RecipientUserID: passed},
+			{ActorUserID: actor, RecipientUserID: blocker},
+		}
+
+		inTx(t, store, func(tx repository.Tx) error {
+			existing, err := store.repo.FindExistingUsers(ctx, tx, []string{actor, unknown, liked})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]bool{actor: true, liked: true}, existing)
+
+			blocked, err := store.repo.CheckBlockedPairs(ctx, tx, pairs)
+			require.NoError(t, err)
+			assert.Equal(t, []bool{false, false, true}, blocked)
+
+			err = store.repo.InsertDecisions(ctx, tx, []repository.BatchDecision{
+				{UserPair: pairs[0], LikedRecipient: true},
+				{UserPair: pairs[1], LikedRecipient: false},
+			})
+			require.NoError(t, err)
+			require.NoError(t, store.repo.InsertLikes(ctx, tx, pairs[:1]))
+			require.NoError(t, store.repo.DeleteLikes(ctx, tx, pairs[1:2]))
+
+			mutual, err := store.repo.CheckMutualLikes(ctx, tx, pairs[:2])
+			require.NoError(t, err)
+			assert.Equal(t, []bool{true, false}, mutual)
+			return nil
+		})
+
+		matches, err := store.repo.GetMatches(ctx, actor, 10, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{liked}, matchIDs(matches))
+
+		likers, err := store.repo.GetLikedYou(ctx, passed, false, 10, nil)
+		require.NoError(t, err)
+		assert.Empty(t, likers)
+	})
+
+	t.Run("concurrent mutual likes report one match", func(t *testing.T) {
+		store := newStore(t)
+
+		for attempt := 0; attempt < 10; attempt++ {
+			ids := users(t, store, 2)
+
+			var wg sync.WaitGroup
+			results := make([]bool, 2)
+			errs := make([]error, 2)
+			for i, pair := range [][2]string{{ids[0], ids[1]}, {ids[1], ids[0]}} {
+				wg.Add(1)
+				go func(i int, actorUserID, recipientUserID string) {
+					defer wg.Done()
+					errs[i] = store.repo.WithTx(ctx, func(tx repository.Tx) error {
+						if err := store.repo.InsertLike(ctx, tx, actorUserID, recipientUserID); err != nil {
+							return err
+						}
+						var err error
+						results[i], err = store.repo.CheckMutualLike(ctx, tx, actorUserID, recipientUserID)
+						return err
+					})
+				}(i, pair[0], pair[1])
+			}
+			wg.Wait()
+
+			require.NoError(t, errs[0])
+			require.NoError(t, errs[1])
+			assert.True(t, results[0] != results[1], "exactly one of the likes reports the match")
+		}
+	})
+}
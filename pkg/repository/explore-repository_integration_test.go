@@ -5,13 +5,15 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	explore "muzz-backend-challenge/pkg/proto"
 	"muzz-backend-challenge/pkg/repository"
 )
 
@@ -40,6 +42,10 @@ func setupDB(t *testing.T) (*sql.DB, func()) {
 			username VARCHAR(255) UNIQUE NOT NULL,
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		);`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS display_name VARCHAR(100) NOT NULL DEFAULT '';`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS birthdate DATE;`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS gender VARCHAR(16) CHECK (gender IN ('female', 'male', 'non_binary'));`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS bio TEXT NOT NULL DEFAULT '';`,
 		`CREATE TABLE IF NOT EXISTS likes (
 			id SERIAL PRIMARY KEY,
 			actor_user_id UUID NOT NULL,
@@ -58,6 +64,92 @@ func setupDB(t *testing.T) (*sql.DB, func()) {
 			FOREIGN KEY (actor_user_id) REFERENCES users(user_id),
 			FOREIGN KEY (recipient_user_id) REFERENCES users(user_id)
 		);`,
+		`ALTER TABLE decisions ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;`,
+		`CREATE TABLE IF NOT EXISTS decision_events (
+			id BIGSERIAL PRIMARY KEY,
+			actor_user_id UUID NOT NULL,
+			recipient_user_id UUID NOT NULL,
+			liked_recipient BOOLEAN NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (actor_user_id) REFERENCES users(user_id),
+			FOREIGN KEY (recipient_user_id) REFERENCES users(user_id)
+		);`,
+		`CREATE TABLE IF NOT EXISTS blocks (
+			blocker_user_id UUID NOT NULL,
+			blocked_user_id UUID NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (blocker_user_id, blocked_user_id),
+			FOREIGN KEY (blocker_user_id) REFERENCES users(user_id),
+			FOREIGN KEY (blocked_user_id) REFERENCES users(user_id)
+		);`,
+		`CREATE TABLE IF NOT EXISTS reports (
+			id SERIAL PRIMARY KEY,
+			reporter_user_id UUID NOT NULL,
+			reported_user_id UUID NOT NULL,
+			reason TEXT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (reporter_user_id) REFERENCES users(user_id),
+			FOREIGN KEY (reported_user_id) REFERENCES users(user_id)
+		);`,
+		`ALTER TABLE reports ALTER COLUMN reporter_user_id DROP NOT NULL;`,
+		`ALTER TABLE reports ALTER COLUMN reported_user_id DROP NOT NULL;`,
+		`CREATE TABLE IF NOT EXISTS outbox (
+			id BIGSERIAL PRIMARY KEY,
+			event_type VARCHAR(255) NOT NULL,
+			payload JSONB NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			available_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			published_at TIMESTAMP,
+			last_error TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);`,
+		`CREATE TABLE IF NOT EXISTS webhook_endpoints (
+			id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+			url TEXT NOT NULL,
+			secret TEXT NOT NULL,
+			enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		);`,
+		`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+			id BIGSERIAL PRIMARY KEY,
+			endpoint_id UUID NOT NULL,
+			outbox_event_id BIGINT NOT NULL,
+			event_type VARCHAR(255) NOT NULL,
+			payload JSONB NOT NULL,
+			status VARCHAR(16) NOT NULL DEFAULT 'pending',
+			attempts INTEGER NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			last_error TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			delivered_at TIMESTAMP,
+			UNIQUE(endpoint_id, outbox_event_id),
+			FOREIGN KEY (endpoint_id) REFERENCES webhook_endpoints(id)
+		);`,
+		`CREATE TABLE IF NOT EXISTS idempotency_keys (
+			actor_user_id UUID NOT NULL,
+			idempotency_key VARCHAR(255) NOT NULL,
+			recipient_user_id UUID NOT NULL,
+			liked_recipient BOOLEAN NOT NULL,
+			mutual_likes BOOLEAN,
+			created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (actor_user_id, idempotency_key),
+			FOREIGN KEY (actor_user_id) REFERENCES users(user_id)
+		);`,
+		`CREATE TABLE IF NOT EXISTS unmatches (
+			id SERIAL PRIMARY KEY,
+			actor_user_id UUID NOT NULL,
+			recipient_user_id UUID NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (actor_user_id) REFERENCES users(user_id),
+			FOREIGN KEY (recipient_user_id) REFERENCES users(user_id)
+		);`,
+		`CREATE TABLE IF NOT EXISTS like_counters (
+			user_id UUID PRIMARY KEY,
+			received BIGINT NOT NULL DEFAULT 0,
+			new_received BIGINT NOT NULL DEFAULT 0,
+			matches BIGINT NOT NULL DEFAULT 0,
+			FOREIGN KEY (user_id) REFERENCES users(user_id)
+		);`,
 	}
 
 	for _, query := range createTables {
@@ -86,6 +178,20 @@ func seedTestData(t *testing.T, db *sql.DB, recipientUserID, user1ID, user2ID uu
 		_, err := db.Exec(query)
 		require.NoError(t, err, "failed to insert like")
 	}
+
+	// Every like is backed by a decision, as PutDecision records both
+	decisionInsertQuery := `
+		INSERT INTO decisions (actor_user_id, recipient_user_id, liked_recipient)
+		SELECT actor_user_id, recipient_user_id, TRUE
+		FROM likes
+		WHERE actor_user_id IN ($1, $2, $3) AND recipient_user_id IN ($1, $2, $3)
+		ON CONFLICT DO NOTHING;`
+	_, err := db.Exec(decisionInsertQuery, recipientUserID, user1ID, user2ID)
+	require.NoError(t, err, "failed to insert decisions")
+
+	// The likes were inserted directly, so the like counters have to catch up
+	_, err = repository.NewLikeCounterRepository(db).ReconcileLikeCounters(context.Background(), true)
+	require.NoError(t, err, "failed to reconcile like counters")
 	fmt.Println("Test data seeded successfully")
 }
 
@@ -104,6 +210,12 @@ func cleanupTestData(t *testing.T, db *sql.DB, userIDs ...uuid.UUID) {
 		queries := []string{
 			fmt.Sprintf("DELETE FROM likes WHERE actor_user_id = '%s' OR recipient_user_id = '%s';", userID, userID),
 			fmt.Sprintf("DELETE FROM decisions WHERE actor_user_id = '%s' OR recipient_user_id = '%s';", userID, userID),
+			fmt.Sprintf("DELETE FROM decision_events WHERE actor_user_id = '%s' OR recipient_user_id = '%s';", userID, userID),
+			fmt.Sprintf("DELETE FROM unmatches WHERE actor_user_id = '%s' OR recipient_user_id = '%s';", userID, userID),
+			fmt.Sprintf("DELETE FROM blocks WHERE blocker_user_id = '%s' OR blocked_user_id = '%s';", userID, userID),
+			fmt.Sprintf("DELETE FROM reports WHERE reporter_user_id = '%s' OR reported_user_id = '%s';", userID, userID),
+			fmt.Sprintf("DELETE FROM idempotency_keys WHERE actor_user_id = '%s';", userID),
+			fmt.Sprintf("DELETE FROM like_counters WHERE user_id = '%s';", userID),
 			fmt.Sprintf("DELETE FROM users WHERE user_id = '%s';", userID),
 		}
 
@@ -134,7 +246,7 @@ func TestIntegrationGetLikedYou(t *testing.T) {
 	seedTestData(t, db, recipientUserID, user1ID, user2ID)
 
 	repo := repository.NewExploreRepository(db)
-	likers, err := repo.GetLikedYou(ctx, recipientUserID.String(), 10, 0)
+	likers, err := repo.GetLikedYou(ctx, recipientUserID.String(), false, 10, nil)
 	require.NoError(t, err)
 	assert.Len(t, likers, 2)
 
@@ -160,7 +272,7 @@ func TestIntegrationGetNewLikedYou(t *testing.T) {
 	seedTestData(t, db, recipientUserID, user1ID, user2ID)
 
 	repo := repository.NewExploreRepository(db)
-	likers, err := repo.GetNewLikedYou(ctx, recipientUserID.String(), 10, 0)
+	likers, err := repo.GetNewLikedYou(ctx, recipientUserID.String(), false, 10, nil)
 	require.NoError(t, err)
 	assert.Len(t, likers, 1) // user2 has not liked test-recipient back
 
@@ -168,6 +280,95 @@ func TestIntegrationGetNewLikedYou(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestIntegrationGetNewLikedYouExcludesPasses(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	recipientUserID := uuid.New()
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	defer cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	seedTestData(t, db, recipientUserID, user1ID, user2ID)
+
+	repo := repository.NewExploreRepository(db)
+
+	// The recipient passes on user2, who liked them
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertDecision(ctx, tx, recipientUserID.String(), user2ID.String(), false))
+	require.NoError(t, tx.Commit())
+
+	likers, err := repo.GetNewLikedYou(ctx, recipientUserID.String(), false, 10, nil)
+	require.NoError(t, err)
+	assert.Empty(t, likers)
+
+	count, err := repo.CountNewLikes(ctx, recipientUserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	// user1 was never decided on by user2, so user1 is new for user2 once they like them
+	_, err = db.Exec("INSERT INTO likes (actor_user_id, recipient_user_id) VALUES ($1, $2)", user1ID, user2ID)
+	require.NoError(t, err)
+
+	likers, err = repo.GetNewLikedYou(ctx, user2ID.String(), false, 10, nil)
+	require.NoError(t, err)
+	require.Len(t, likers, 1)
+	assert.Equal(t, user1ID.String(), likers[0].Liker.ActorId)
+}
+
+func TestIntegrationCountNewLikes(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	recipientUserID := uuid.New()
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	defer cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	seedTestData(t, db, recipientUserID, user1ID, user2ID)
+
+	repo := repository.NewExploreRepository(db)
+	count, err := repo.CountNewLikes(ctx, recipientUserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count) // the recipient has already decided on user1
+}
+
+func TestIntegrationGetLikedYouKeysetPagination(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	recipientUserID := uuid.New()
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	defer cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	seedTestData(t, db, recipientUserID, user1ID, user2ID)
+
+	repo := repository.NewExploreRepository(db)
+	firstPage, err := repo.GetLikedYou(ctx, recipientUserID.String(), false, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 1)
+
+	secondPage, err := repo.GetLikedYou(ctx, recipientUserID.String(), false, 1, &firstPage[0].Cursor)
+	require.NoError(t, err)
+	require.Len(t, secondPage, 1)
+	assert.NotEqual(t, firstPage[0].Liker.ActorId, secondPage[0].Liker.ActorId)
+
+	lastPage, err := repo.GetLikedYou(ctx, recipientUserID.String(), false, 1, &secondPage[0].Cursor)
+	require.NoError(t, err)
+	assert.Empty(t, lastPage)
+}
+
 func TestIntegrationCountLikes(t *testing.T) {
 	db, closeFunc := setupDB(t)
 	defer closeFunc()
@@ -261,6 +462,27 @@ func TestIntegrationInsertLike(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestIntegrationInsertLikeUnknownUser(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	actorUserID := uuid.New()
+
+	cleanupTestData(t, db, actorUserID)
+	defer cleanupTestData(t, db, actorUserID)
+
+	insertUsers(t, db, actorUserID)
+
+	repo := repository.NewExploreRepository(db)
+	err = repo.InsertLike(ctx, tx, actorUserID.String(), uuid.New().String())
+	assert.ErrorIs(t, err, repository.ErrInvalidReference)
+}
+
 func TestIntegrationDeleteLike(t *testing.T) {
 	db, closeFunc := setupDB(t)
 	defer closeFunc()
@@ -316,3 +538,772 @@ func TestIntegrationCheckMutualLike(t *testing.T) {
 	err = tx.Commit()
 	require.NoError(t, err)
 }
+
+func TestIntegrationGetMatches(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	recipientUserID := uuid.New()
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	defer cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	seedTestData(t, db, recipientUserID, user1ID, user2ID)
+
+	repo := repository.NewExploreRepository(db)
+	matches, err := repo.GetMatches(ctx, recipientUserID.String(), 10, nil)
+	require.NoError(t, err)
+	require.Len(t, matches, 1) // only user1 liked recipient back
+	assert.Equal(t, user1ID.String(), matches[0].Match.UserId)
+
+	matches, err = repo.GetMatches(ctx, recipientUserID.String(), 10, &matches[0].Cursor)
+	require.NoError(t, err)
+	assert.Empty(t, matches)
+}
+
+func TestIntegrationCountMatches(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	recipientUserID := uuid.New()
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	defer cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	seedTestData(t, db, recipientUserID, user1ID, user2ID)
+
+	repo := repository.NewExploreRepository(db)
+	count, err := repo.CountMatches(ctx, recipientUserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	count, err = repo.CountMatches(ctx, user2ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestIntegrationUnmatch(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	recipientUserID := uuid.New()
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	defer cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	seedTestData(t, db, recipientUserID, user1ID, user2ID)
+
+	repo := repository.NewExploreRepository(db)
+	err = repo.Unmatch(ctx, tx, recipientUserID.String(), user1ID.String())
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	// A new like after the unmatch must not bring user1 back into the recipient's lists
+	_, err = db.Exec("INSERT INTO likes (actor_user_id, recipient_user_id) VALUES ($1, $2)", user1ID, recipientUserID)
+	require.NoError(t, err)
+
+	likers, err := repo.GetLikedYou(ctx, recipientUserID.String(), false, 10, nil)
+	require.NoError(t, err)
+	require.Len(t, likers, 1)
+	assert.Equal(t, user2ID.String(), likers[0].Liker.ActorId)
+
+	var unmatchedBy string
+	err = db.QueryRow("SELECT actor_user_id FROM unmatches WHERE recipient_user_id = $1", user1ID).Scan(&unmatchedBy)
+	require.NoError(t, err)
+	assert.Equal(t, recipientUserID.String(), unmatchedBy)
+}
+
+func TestIntegrationUnmatchNotMatched(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	recipientUserID := uuid.New()
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	defer cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	seedTestData(t, db, recipientUserID, user1ID, user2ID)
+
+	repo := repository.NewExploreRepository(db)
+	err = repo.Unmatch(ctx, tx, recipientUserID.String(), user2ID.String())
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestIntegrationBlockUserHidesPairInBothDirections(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	recipientUserID := uuid.New()
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	defer cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	seedTestData(t, db, recipientUserID, user1ID, user2ID)
+
+	repo := repository.NewExploreRepository(db)
+	// user1 blocks the recipient, which must hide user1 from the recipient's lists as well
+	require.NoError(t, repo.BlockUser(ctx, user1ID.String(), recipientUserID.String()))
+
+	likers, err := repo.GetLikedYou(ctx, recipientUserID.String(), false, 10, nil)
+	require.NoError(t, err)
+	require.Len(t, likers, 1)
+	assert.Equal(t, user2ID.String(), likers[0].Liker.ActorId)
+
+	count, err := repo.CountLikes(ctx, recipientUserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	blocked, err := repo.IsBlocked(ctx, tx, recipientUserID.String(), user1ID.String())
+	require.NoError(t, err)
+	assert.True(t, blocked)
+
+	mutual, err := repo.CheckMutualLike(ctx, tx, recipientUserID.String(), user1ID.String())
+	require.NoError(t, err)
+	assert.False(t, mutual)
+	require.NoError(t, tx.Rollback())
+
+	require.NoError(t, repo.UnblockUser(ctx, user1ID.String(), recipientUserID.String()))
+
+	count, err = repo.CountLikes(ctx, recipientUserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestIntegrationReportUser(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	reporterUserID := uuid.New()
+	reportedUserID := uuid.New()
+
+	cleanupTestData(t, db, reporterUserID, reportedUserID)
+	defer cleanupTestData(t, db, reporterUserID, reportedUserID)
+	insertUsers(t, db, reporterUserID, reportedUserID)
+
+	repo := repository.NewExploreRepository(db)
+	require.NoError(t, repo.ReportUser(ctx, reporterUserID.String(), reportedUserID.String(), "spam"))
+
+	var reason string
+	err := db.QueryRow("SELECT reason FROM reports WHERE reporter_user_id = $1 AND reported_user_id = $2", reporterUserID, reportedUserID).Scan(&reason)
+	require.NoError(t, err)
+	assert.Equal(t, "spam", reason)
+}
+
+func TestIntegrationInsertDecisionKeepsHistory(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	actorUserID := uuid.New()
+	recipientUserID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, actorUserID)
+	defer cleanupTestData(t, db, recipientUserID, actorUserID)
+	insertUsers(t, db, actorUserID, recipientUserID)
+
+	repo := repository.NewExploreRepository(db)
+	for _, liked := range []bool{true, false} {
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		require.NoError(t, repo.InsertDecision(ctx, tx, actorUserID.String(), recipientUserID.String(), liked))
+		require.NoError(t, tx.Commit())
+	}
+
+	var events int
+	err := db.QueryRow("SELECT COUNT(*) FROM decision_events WHERE actor_user_id = $1", actorUserID).Scan(&events)
+	require.NoError(t, err)
+	assert.Equal(t, 2, events)
+
+	var createdAt, updatedAt time.Time
+	err = db.QueryRow("SELECT created_at, updated_at FROM decisions WHERE actor_user_id = $1", actorUserID).Scan(&createdAt, &updatedAt)
+	require.NoError(t, err)
+	assert.True(t, updatedAt.After(createdAt), "updated_at should move while created_at is kept")
+}
+
+func TestIntegrationGetDecisions(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	actorUserID := uuid.New()
+	likedUserID := uuid.New()
+	passedUserID := uuid.New()
+
+	cleanupTestData(t, db, actorUserID, likedUserID, passedUserID)
+	defer cleanupTestData(t, db, actorUserID, likedUserID, passedUserID)
+	insertUsers(t, db, actorUserID, likedUserID, passedUserID)
+
+	repo := repository.NewExploreRepository(db)
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertDecision(ctx, tx, actorUserID.String(), likedUserID.String(), true))
+	require.NoError(t, repo.InsertDecision(ctx, tx, actorUserID.String(), passedUserID.String(), false))
+	require.NoError(t, tx.Commit())
+
+	all, err := repo.GetDecisions(ctx, actorUserID.String(), explore.DecisionFilter_DECISION_FILTER_ALL, 10, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	likes, err := repo.GetDecisions(ctx, actorUserID.String(), explore.DecisionFilter_DECISION_FILTER_LIKES, 10, nil)
+	require.NoError(t, err)
+	require.Len(t, likes, 1)
+	assert.Equal(t, likedUserID.String(), likes[0].Decision.RecipientUserId)
+
+	passes, err := repo.GetDecisions(ctx, actorUserID.String(), explore.DecisionFilter_DECISION_FILTER_PASSES, 10, nil)
+	require.NoError(t, err)
+	require.Len(t, passes, 1)
+	assert.Equal(t, passedUserID.String(), passes[0].Decision.RecipientUserId)
+
+	firstPage, err := repo.GetDecisions(ctx, actorUserID.String(), explore.DecisionFilter_DECISION_FILTER_ALL, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 1)
+	secondPage, err := repo.GetDecisions(ctx, actorUserID.String(), explore.DecisionFilter_DECISION_FILTER_ALL, 1, &firstPage[0].Cursor)
+	require.NoError(t, err)
+	require.Len(t, secondPage, 1)
+	assert.NotEqual(t, firstPage[0].Decision.RecipientUserId, secondPage[0].Decision.RecipientUserId)
+}
+
+func TestIntegrationOutbox(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	_, err := db.Exec("DELETE FROM outbox")
+	require.NoError(t, err)
+	defer db.Exec("DELETE FROM outbox")
+
+	repo := repository.NewExploreRepository(db)
+	outbox := repository.NewOutboxRepository(db)
+
+	// Events from a rolled back transaction are never queued
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertOutboxEvent(ctx, tx, "decision.recorded", []byte(`{"n":0}`)))
+	require.NoError(t, tx.Rollback())
+
+	tx, err = db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.InsertOutboxEvent(ctx, tx, "decision.recorded", []byte(`{"n":1}`)))
+	require.NoError(t, repo.InsertOutboxEvent(ctx, tx, "match.created", []byte(`{"n":2}`)))
+	require.NoError(t, tx.Commit())
+
+	events, err := outbox.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "decision.recorded", events[0].EventType)
+	assert.JSONEq(t, `{"n":1}`, string(events[0].Payload))
+	assert.Equal(t, 1, events[0].Attempts)
+	assert.Equal(t, "match.created", events[1].EventType)
+
+	// Claimed events are leased and not handed out again
+	claimed, err := outbox.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	require.NoError(t, outbox.MarkOutboxEventPublished(ctx, events[0].ID))
+	require.NoError(t, outbox.MarkOutboxEventFailed(ctx, events[1].ID, 0, "endpoint down"))
+
+	// Only the failed event becomes available again, with its attempts counted
+	retried, err := outbox.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, events[1].ID, retried[0].ID)
+	assert.Equal(t, 2, retried[0].Attempts)
+
+	// Only published events past the retention are purged
+	deleted, err := outbox.DeletePublishedOutboxEvents(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+	_, err = db.Exec("UPDATE outbox SET published_at = CURRENT_TIMESTAMP - INTERVAL '2 hours' WHERE id = $1", events[0].ID)
+	require.NoError(t, err)
+	deleted, err = outbox.DeletePublishedOutboxEvents(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	var remaining []int64
+	rows, err := db.Query("SELECT id FROM outbox")
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		require.NoError(t, rows.Scan(&id))
+		remaining = append(remaining, id)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []int64{events[1].ID}, remaining)
+}
+
+func TestIntegrationWebhookDeliveries(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	cleanup := func() {
+		_, err := db.Exec("DELETE FROM webhook_deliveries")
+		require.NoError(t, err)
+		_, err = db.Exec("DELETE FROM webhook_endpoints")
+		require.NoError(t, err)
+	}
+	cleanup()
+	defer cleanup()
+
+	repo := repository.NewWebhookRepository(db)
+
+	active, err := repo.CreateWebhookEndpoint(ctx, "https://example.com/active", "active-secret")
+	require.NoError(t, err)
+	assert.True(t, active.Enabled)
+	disabled, err := repo.CreateWebhookEndpoint(ctx, "https://example.com/disabled", "disabled-secret")
+	require.NoError(t, err)
+	require.NoError(t, repo.DisableWebhookEndpoint(ctx, disabled.Id))
+
+	endpoints, err := repo.ListWebhookEndpoints(ctx)
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+	assert.False(t, endpoints[1].Enabled)
+
+	// Enqueueing an event twice only queues one delivery, and only to enabled endpoints
+	payload := []byte(`{"user_ids":["a","b"]}`)
+	require.NoError(t, repo.EnqueueWebhookDeliveries(ctx, 1, "match.created", payload))
+	require.NoError(t, repo.EnqueueWebhookDeliveries(ctx, 1, "match.created", payload))
+
+	deliveries, err := repo.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, active.Id, deliveries[0].EndpointID)
+	assert.Equal(t, "https://example.com/active", deliveries[0].URL)
+	assert.Equal(t, "active-secret", deliveries[0].Secret)
+	assert.Equal(t, int64(1), deliveries[0].OutboxEventID)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+
+	// A failed delivery is retried, a dead one never is
+	require.NoError(t, repo.MarkWebhookFailed(ctx, deliveries[0].ID, 0, "status 500"))
+	retried, err := repo.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, 2, retried[0].Attempts)
+
+	require.NoError(t, repo.MarkWebhookDead(ctx, retried[0].ID, "status 500"))
+	_, err = db.Exec("UPDATE webhook_deliveries SET next_attempt_at = CURRENT_TIMESTAMP - INTERVAL '1 minute'")
+	require.NoError(t, err)
+	dead, err := repo.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, dead)
+
+	var status string
+	err = db.QueryRow("SELECT status FROM webhook_deliveries WHERE id = $1", retried[0].ID).Scan(&status)
+	require.NoError(t, err)
+	assert.Equal(t, "dead", status)
+}
+
+func TestIntegrationDisableUnknownWebhookEndpoint(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	repo := repository.NewWebhookRepository(db)
+	err := repo.DisableWebhookEndpoint(context.Background(), uuid.New().String())
+
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestIntegrationIdempotencyKeys(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	actorUserID := uuid.New()
+	recipientUserID := uuid.New()
+
+	cleanupTestData(t, db, actorUserID, recipientUserID)
+	defer cleanupTestData(t, db, actorUserID, recipientUserID)
+	insertUsers(t, db, actorUserID, recipientUserID)
+
+	repo := repository.NewExploreRepository(db)
+	reserve := func(ttl time.Duration) *repository.IdempotentDecision {
+		tx, err := db.BeginTx(ctx, nil)
+		require.NoError(t, err)
+		defer tx.Rollback()
+
+		original, err := repo.ReserveIdempotencyKey(ctx, tx, actorUserID.String(), "swipe-1", recipientUserID.String(), true, ttl)
+		require.NoError(t, err)
+		if original == nil {
+			require.NoError(t, repo.CompleteIdempotencyKey(ctx, tx, actorUserID.String(), "swipe-1", true))
+			require.NoError(t, tx.Commit())
+		}
+		return original
+	}
+
+	assert.Nil(t, reserve(time.Hour), "a new key is reserved")
+
+	original := reserve(time.Hour)
+	require.NotNil(t, original, "a used key returns the original decision")
+	assert.Equal(t, repository.IdempotentDecision{RecipientUserID: recipientUserID.String(), LikedRecipient: true, MutualLikes: true}, *original)
+
+	assert.Nil(t, reserve(0), "an expired key is reserved again")
+
+	deleted, err := repo.DeleteExpiredIdempotencyKeys(ctx, 0)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+}
+
+func TestIntegrationBatchDecisions(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	actorUserID := uuid.New().String()
+	likedUserID := uuid.New().String()
+	passedUserID := uuid.New().String()
+	blockedUserID := uuid.New().String()
+	userIDs := []uuid.UUID{uuid.MustParse(actorUserID), uuid.MustParse(likedUserID), uuid.MustParse(passedUserID), uuid.MustParse(blockedUserID)}
+
+	cleanupTestData(t, db, userIDs...)
+	defer cleanupTestData(t, db, userIDs...)
+	insertUsers(t, db, userIDs...)
+
+	repo := repository.NewExploreRepository(db)
+	require.NoError(t, repo.BlockUser(ctx, blockedUserID, actorUserID))
+
+	// The liked user already likes the actor, and the actor liked the passed user before
+	_, err := db.Exec("INSERT INTO likes (actor_user_id, recipient_user_id) VALUES ($1, $2), ($2, $3)", likedUserID, actorUserID, passedUserID)
+	require.NoError(t, err)
+
+	liked := repository.UserPair{ActorUserID: actorUserID, RecipientUserID: likedUserID}
+	passed := repository.UserPair{ActorUserID: actorUserID, RecipientUserID: passedUserID}
+	blocked := repository.UserPair{ActorUserID: actorUserID, RecipientUserID: blockedUserID}
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	existing, err := repo.FindExistingUsers(ctx, tx, []string{actorUserID, uuid.New().String()})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{actorUserID: true}, existing)
+
+	isBlocked, err := repo.CheckBlockedPairs(ctx, tx, []repository.UserPair{liked, blocked, passed})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{false, true, false}, isBlocked)
+
+	require.NoError(t, repo.InsertDecisions(ctx, tx, []repository.BatchDecision{
+		{UserPair: liked, LikedRecipient: true},
+		{UserPair: passed, LikedRecipient: false},
+	}))
+	require.NoError(t, repo.InsertLikes(ctx, tx, []repository.UserPair{liked}))
+	require.NoError(t, repo.DeleteLikes(ctx, tx, []repository.UserPair{passed}))
+
+	mutual, err := repo.CheckMutualLikes(ctx, tx, []repository.UserPair{liked, passed})
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, mutual)
+
+	require.NoError(t, repo.InsertOutboxEvents(ctx, tx, []repository.OutboxMessage{
+		{EventType: "decision.recorded", Payload: []byte(`{"n":1}`)},
+		{EventType: "match.created", Payload: []byte(`{"n":2}`)},
+	}))
+	require.NoError(t, tx.Commit())
+
+	var decisions, likes int
+	err = db.QueryRow("SELECT COUNT(*) FROM decisions WHERE actor_user_id = $1", actorUserID).Scan(&decisions)
+	require.NoError(t, err)
+	assert.Equal(t, 2, decisions)
+	err = db.QueryRow("SELECT COUNT(*) FROM likes WHERE actor_user_id = $1", actorUserID).Scan(&likes)
+	require.NoError(t, err)
+	assert.Equal(t, 1, likes, "only the like on the liked user remains")
+}
+
+func TestIntegrationConcurrentMutualLikesReportOneMatch(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+	repo := repository.NewExploreRepository(db)
+
+	// likeAndCheck records a like the way PutDecision does and reports whether it made a match
+	likeAndCheck := func(actorUserID, recipientUserID string, start <-chan struct{}) (bool, error) {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return false, err
+		}
+		defer tx.Rollback()
+
+		<-start
+		if err := repo.InsertDecision(ctx, tx, actorUserID, recipientUserID, true); err != nil {
+			return false, err
+		}
+		if err := repo.InsertLike(ctx, tx, actorUserID, recipientUserID); err != nil {
+			return false, err
+		}
+		mutual, err := repo.CheckMutualLike(ctx, tx, actorUserID, recipientUserID)
+		if err != nil {
+			return false, err
+		}
+		return mutual, tx.Commit()
+	}
+
+	for attempt := 0; attempt < 20; attempt++ {
+		firstUserID := uuid.New()
+		secondUserID := uuid.New()
+		insertUsers(t, db, firstUserID, secondUserID)
+
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		results := make([]bool, 2)
+		errs := make([]error, 2)
+		for i, pair := range [][2]uuid.UUID{{firstUserID, secondUserID}, {secondUserID, firstUserID}} {
+			wg.Add(1)
+			go func(i int, actorUserID, recipientUserID uuid.UUID) {
+				defer wg.Done()
+				results[i], errs[i] = likeAndCheck(actorUserID.String(), recipientUserID.String(), start)
+			}(i, pair[0], pair[1])
+		}
+		close(start)
+		wg.Wait()
+
+		cleanupTestData(t, db, firstUserID, secondUserID)
+
+		require.NoError(t, errs[0])
+		require.NoError(t, errs[1])
+		assert.True(t, results[0] != results[1], "exactly one of the two likes must report the match (attempt %d)", attempt)
+	}
+}
+
+func TestIntegrationReconcileLikeCounters(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	recipientUserID := uuid.New()
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	defer cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	seedTestData(t, db, recipientUserID, user1ID, user2ID)
+
+	counters := repository.NewLikeCounterRepository(db)
+	drifts, err := counters.ReconcileLikeCounters(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+
+	_, err = db.Exec("UPDATE like_counters SET received = 7 WHERE user_id = $1", recipientUserID)
+	require.NoError(t, err)
+
+	expected := []repository.LikeCounterDrift{{
+		UserID: recipientUserID.String(),
+		Stored: repository.LikeCounters{Received: 7, NewReceived: 1, Matches: 1},
+		Actual: repository.LikeCounters{Received: 2, NewReceived: 1, Matches: 1},
+	}}
+	drifts, err = counters.ReconcileLikeCounters(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, expected, drifts)
+
+	drifts, err = counters.ReconcileLikeCounters(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, expected, drifts)
+
+	count, err := repository.NewExploreRepository(db).CountLikes(ctx, recipientUserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	drifts, err = counters.ReconcileLikeCounters(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+}
+
+func TestIntegrationExploreRepositoryConformance(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	repo := repository.NewExploreRepository(db)
+	runExploreRepositoryConformance(t, func(t *testing.T) conformanceStore {
+		return conformanceStore{
+			repo: repo,
+			addUsers: func(t *testing.T, userIDs ...uuid.UUID) {
+				insertUsers(t, db, userIDs...)
+				t.Cleanup(func() { cleanupTestData(t, db, userIDs...) })
+			},
+		}
+	})
+}
+
+func TestIntegrationUserRepositoryConformance(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	runUserRepositoryConformance(t, func(t *testing.T) userConformanceStore {
+		return userConformanceStore{
+			users:   repository.NewUserRepository(db),
+			explore: repository.NewExploreRepository(db),
+		}
+	})
+}
+
+func TestIntegrationDeleteUserData(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	recipientUserID := uuid.New()
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	defer cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	seedTestData(t, db, recipientUserID, user1ID, user2ID)
+
+	repo := repository.NewExploreRepository(db)
+	require.NoError(t, repo.BlockUser(ctx, user2ID.String(), user1ID.String()))
+	reason := "deleted-" + uuid.NewString()
+	require.NoError(t, repo.ReportUser(ctx, user1ID.String(), user2ID.String(), reason))
+	require.NoError(t, repo.ReportUser(ctx, user2ID.String(), user1ID.String(), reason))
+	defer db.Exec("DELETE FROM reports WHERE reason = $1", reason)
+	require.NoError(t, repo.WithTx(ctx, func(tx repository.Tx) error {
+		_, err := repo.ReserveIdempotencyKey(ctx, tx, user2ID.String(), uuid.NewString(), user1ID.String(), true, time.Hour)
+		return err
+	}))
+
+	// Events naming the deleted user are dropped from the outbox and the webhook queue
+	payloads := [][]byte{
+		[]byte(`{"actor_user_id":"` + user1ID.String() + `","recipient_user_id":"` + user2ID.String() + `"}`),
+		[]byte(`{"actor_user_id":"` + user2ID.String() + `","recipient_user_id":"` + user1ID.String() + `"}`),
+		[]byte(`{"user_ids":["` + recipientUserID.String() + `","` + user1ID.String() + `"]}`),
+		[]byte(`{"actor_user_id":"` + user2ID.String() + `","recipient_user_id":"` + recipientUserID.String() + `"}`),
+	}
+	webhooks := repository.NewWebhookRepository(db)
+	endpoint, err := webhooks.CreateWebhookEndpoint(ctx, "https://example.com/deleted-user-data", "secret")
+	require.NoError(t, err)
+	defer db.Exec("DELETE FROM webhook_endpoints WHERE id = $1", endpoint.Id)
+	defer db.Exec("DELETE FROM webhook_deliveries WHERE endpoint_id = $1", endpoint.Id)
+	var eventIDs []int64
+	for _, payload := range payloads {
+		require.NoError(t, repo.WithTx(ctx, func(tx repository.Tx) error {
+			return repo.InsertOutboxEvent(ctx, tx, "decision.recorded", payload)
+		}))
+		var eventID int64
+		require.NoError(t, db.QueryRow("SELECT MAX(id) FROM outbox").Scan(&eventID))
+		eventIDs = append(eventIDs, eventID)
+		require.NoError(t, webhooks.EnqueueWebhookDeliveries(ctx, eventID, "decision.recorded", payload))
+	}
+	defer db.Exec("DELETE FROM outbox WHERE id = ANY($1)", pq.Array(eventIDs))
+
+	likedUserIDs, err := repository.NewUserRepository(db).DeleteUserData(ctx, user1ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, []string{recipientUserID.String()}, likedUserIDs)
+
+	for _, query := range []string{
+		"SELECT COUNT(*) FROM outbox WHERE id = ANY($1)",
+		"SELECT COUNT(*) FROM webhook_deliveries WHERE outbox_event_id = ANY($1)",
+	} {
+		var count int
+		require.NoError(t, db.QueryRow(query, pq.Array(eventIDs)).Scan(&count))
+		assert.Equal(t, 1, count, query)
+	}
+
+	for _, query := range []string{
+		"SELECT COUNT(*) FROM users WHERE user_id = $1",
+		"SELECT COUNT(*) FROM likes WHERE actor_user_id = $1 OR recipient_user_id = $1",
+		"SELECT COUNT(*) FROM decisions WHERE actor_user_id = $1 OR recipient_user_id = $1",
+		"SELECT COUNT(*) FROM blocks WHERE blocker_user_id = $1 OR blocked_user_id = $1",
+		"SELECT COUNT(*) FROM reports WHERE reporter_user_id = $1 OR reported_user_id = $1",
+		"SELECT COUNT(*) FROM idempotency_keys WHERE actor_user_id = $1 OR recipient_user_id = $1",
+		"SELECT COUNT(*) FROM like_counters WHERE user_id = $1",
+	} {
+		var count int
+		require.NoError(t, db.QueryRow(query, user1ID).Scan(&count))
+		assert.Zero(t, count, query)
+	}
+
+	// The reports are kept for moderation, without the deleted user's ID
+	var reports []string
+	rows, err := db.Query(`
+		SELECT COALESCE(reporter_user_id::text, ''), COALESCE(reported_user_id::text, '')
+		FROM reports
+		WHERE reason = $1
+		ORDER BY reporter_user_id NULLS FIRST`, reason)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var reporter, reported string
+		require.NoError(t, rows.Scan(&reporter, &reported))
+		reports = append(reports, reporter+">"+reported)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{">" + user2ID.String(), user2ID.String() + ">"}, reports)
+
+	// The counters of the users the deleted user liked or was liked by are kept in step
+	count, err := repo.CountLikes(ctx, recipientUserID.String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	matches, err := repo.CountMatches(ctx, recipientUserID.String())
+	require.NoError(t, err)
+	assert.Zero(t, matches)
+
+	drifts, err := repository.NewLikeCounterRepository(db).ReconcileLikeCounters(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+}
+
+func TestIntegrationExportUserData(t *testing.T) {
+	db, closeFunc := setupDB(t)
+	defer closeFunc()
+
+	ctx := context.Background()
+
+	recipientUserID := uuid.New()
+	user1ID := uuid.New()
+	user2ID := uuid.New()
+
+	cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	defer cleanupTestData(t, db, recipientUserID, user1ID, user2ID)
+	seedTestData(t, db, recipientUserID, user1ID, user2ID)
+
+	data, err := repository.NewUserRepository(db).ExportUserData(ctx, recipientUserID.String())
+	require.NoError(t, err)
+
+	assert.Equal(t, recipientUserID.String(), data.User.UserId)
+	require.Len(t, data.LikesGiven, 1)
+	assert.Equal(t, user1ID.String(), data.LikesGiven[0].UserID)
+	assert.Len(t, data.LikesReceived, 2)
+	require.Len(t, data.Decisions, 1)
+	assert.Equal(t, repository.UserDataDecision{
+		UserID:    user1ID.String(),
+		Liked:     true,
+		CreatedAt: data.Decisions[0].CreatedAt,
+		UpdatedAt: data.Decisions[0].UpdatedAt,
+	}, data.Decisions[0])
+	require.Len(t, data.Matches, 1)
+	assert.Equal(t, user1ID.String(), data.Matches[0].UserID)
+}
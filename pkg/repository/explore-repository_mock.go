@@ -2,28 +2,39 @@ package repository
 
 import (
 	"context"
-	"database/sql"
 	"github.com/stretchr/testify/mock"
 	explore "muzz-backend-challenge/pkg/proto"
+	"time"
 )
 
 type MockExploreRepository struct {
 	mock.Mock
 }
 
-func (m *MockExploreRepository) BeginTransaction(ctx context.Context) (*sql.Tx, error) {
+// WithTx runs fn once in the transaction passed to Return, committing it if fn succeeds and
+// rolling it back otherwise. When the transaction is nil, fn is not run and the error passed
+// to Return is returned instead.
+func (m *MockExploreRepository) WithTx(ctx context.Context, fn func(tx Tx) error) error {
 	args := m.Called(ctx)
-	return args.Get(0).(*sql.Tx), args.Error(1)
+	tx, _ := args.Get(0).(Tx)
+	if tx == nil {
+		return args.Error(1)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
 }
 
-func (m *MockExploreRepository) GetLikedYou(ctx context.Context, recipientUserID string, limit, offset int) ([]*explore.ListLikedYouResponse_Liker, error) {
-	args := m.Called(ctx, recipientUserID, limit, offset)
-	return args.Get(0).([]*explore.ListLikedYouResponse_Liker), args.Error(1)
+func (m *MockExploreRepository) GetLikedYou(ctx context.Context, recipientUserID string, includeProfile bool, limit int, after *Cursor) ([]LikerRow, error) {
+	args := m.Called(ctx, recipientUserID, includeProfile, limit, after)
+	return args.Get(0).([]LikerRow), args.Error(1)
 }
 
-func (m *MockExploreRepository) GetNewLikedYou(ctx context.Context, recipientUserID string, limit, offset int) ([]*explore.ListLikedYouResponse_Liker, error) {
-	args := m.Called(ctx, recipientUserID, limit, offset)
-	return args.Get(0).([]*explore.ListLikedYouResponse_Liker), args.Error(1)
+func (m *MockExploreRepository) GetNewLikedYou(ctx context.Context, recipientUserID string, includeProfile bool, limit int, after *Cursor) ([]LikerRow, error) {
+	args := m.Called(ctx, recipientUserID, includeProfile, limit, after)
+	return args.Get(0).([]LikerRow), args.Error(1)
 }
 
 func (m *MockExploreRepository) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
@@ -31,22 +42,128 @@ func (m *MockExploreRepository) CountLikes(ctx context.Context, recipientUserID
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockExploreRepository) InsertDecision(ctx context.Context, tx *sql.Tx, actorUserID, recipientUserID string, likedRecipient bool) error {
+func (m *MockExploreRepository) CountNewLikes(ctx context.Context, recipientUserID string) (int64, error) {
+	args := m.Called(ctx, recipientUserID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockExploreRepository) InsertDecision(ctx context.Context, tx Tx, actorUserID, recipientUserID string, likedRecipient bool) error {
 	args := m.Called(ctx, tx, actorUserID, recipientUserID, likedRecipient)
 	return args.Error(0)
 }
 
-func (m *MockExploreRepository) InsertLike(ctx context.Context, tx *sql.Tx, actorUserID, recipientUserID string) error {
+func (m *MockExploreRepository) InsertLike(ctx context.Context, tx Tx, actorUserID, recipientUserID string) error {
+	args := m.Called(ctx, tx, actorUserID, recipientUserID)
+	return args.Error(0)
+}
+
+func (m *MockExploreRepository) DeleteLike(ctx context.Context, tx Tx, actorUserID, recipientUserID string) error {
 	args := m.Called(ctx, tx, actorUserID, recipientUserID)
 	return args.Error(0)
 }
 
-func (m *MockExploreRepository) DeleteLike(ctx context.Context, tx *sql.Tx, actorUserID, recipientUserID string) error {
+func (m *MockExploreRepository) CheckMutualLike(ctx context.Context, tx Tx, actorUserID, recipientUserID string) (bool, error) {
+	args := m.Called(ctx, tx, actorUserID, recipientUserID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockExploreRepository) GetMatches(ctx context.Context, userID string, limit int, after *Cursor) ([]MatchRow, error) {
+	args := m.Called(ctx, userID, limit, after)
+	return args.Get(0).([]MatchRow), args.Error(1)
+}
+
+func (m *MockExploreRepository) CountMatches(ctx context.Context, userID string) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockExploreRepository) Unmatch(ctx context.Context, tx Tx, actorUserID, recipientUserID string) error {
 	args := m.Called(ctx, tx, actorUserID, recipientUserID)
 	return args.Error(0)
 }
 
-func (m *MockExploreRepository) CheckMutualLike(ctx context.Context, tx *sql.Tx, actorUserID, recipientUserID string) (bool, error) {
+func (m *MockExploreRepository) BlockUser(ctx context.Context, blockerUserID, blockedUserID string) error {
+	args := m.Called(ctx, blockerUserID, blockedUserID)
+	return args.Error(0)
+}
+
+func (m *MockExploreRepository) UnblockUser(ctx context.Context, blockerUserID, blockedUserID string) error {
+	args := m.Called(ctx, blockerUserID, blockedUserID)
+	return args.Error(0)
+}
+
+func (m *MockExploreRepository) IsBlocked(ctx context.Context, tx Tx, actorUserID, recipientUserID string) (bool, error) {
 	args := m.Called(ctx, tx, actorUserID, recipientUserID)
 	return args.Bool(0), args.Error(1)
 }
+
+func (m *MockExploreRepository) ReportUser(ctx context.Context, reporterUserID, reportedUserID, reason string) error {
+	args := m.Called(ctx, reporterUserID, reportedUserID, reason)
+	return args.Error(0)
+}
+
+func (m *MockExploreRepository) GetDecisions(ctx context.Context, actorUserID string, filter explore.DecisionFilter, limit int, after *Cursor) ([]DecisionRow, error) {
+	args := m.Called(ctx, actorUserID, filter, limit, after)
+	return args.Get(0).([]DecisionRow), args.Error(1)
+}
+
+func (m *MockExploreRepository) GetExploreFeed(ctx context.Context, userID string, order FeedOrder, limit int, after *FeedCursor) ([]CandidateRow, error) {
+	args := m.Called(ctx, userID, order, limit, after)
+	return args.Get(0).([]CandidateRow), args.Error(1)
+}
+
+func (m *MockExploreRepository) InsertOutboxEvent(ctx context.Context, tx Tx, eventType string, payload []byte) error {
+	args := m.Called(ctx, tx, eventType, payload)
+	return args.Error(0)
+}
+
+func (m *MockExploreRepository) ReserveIdempotencyKey(ctx context.Context, tx Tx, actorUserID, key, recipientUserID string, likedRecipient bool, ttl time.Duration) (*IdempotentDecision, error) {
+	args := m.Called(ctx, tx, actorUserID, key, recipientUserID, likedRecipient, ttl)
+	decision, _ := args.Get(0).(*IdempotentDecision)
+	return decision, args.Error(1)
+}
+
+func (m *MockExploreRepository) CompleteIdempotencyKey(ctx context.Context, tx Tx, actorUserID, key string, mutualLikes bool) error {
+	args := m.Called(ctx, tx, actorUserID, key, mutualLikes)
+	return args.Error(0)
+}
+
+func (m *MockExploreRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, ttl time.Duration) (int64, error) {
+	args := m.Called(ctx, ttl)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockExploreRepository) FindExistingUsers(ctx context.Context, tx Tx, userIDs []string) (map[string]bool, error) {
+	args := m.Called(ctx, tx, userIDs)
+	return args.Get(0).(map[string]bool), args.Error(1)
+}
+
+func (m *MockExploreRepository) CheckBlockedPairs(ctx context.Context, tx Tx, pairs []UserPair) ([]bool, error) {
+	args := m.Called(ctx, tx, pairs)
+	return args.Get(0).([]bool), args.Error(1)
+}
+
+func (m *MockExploreRepository) InsertDecisions(ctx context.Context, tx Tx, decisions []BatchDecision) error {
+	args := m.Called(ctx, tx, decisions)
+	return args.Error(0)
+}
+
+func (m *MockExploreRepository) InsertLikes(ctx context.Context, tx Tx, pairs []UserPair) error {
+	args := m.Called(ctx, tx, pairs)
+	return args.Error(0)
+}
+
+func (m *MockExploreRepository) DeleteLikes(ctx context.Context, tx Tx, pairs []UserPair) error {
+	args := m.Called(ctx, tx, pairs)
+	return args.Error(0)
+}
+
+func (m *MockExploreRepository) CheckMutualLikes(ctx context.Context, tx Tx, pairs []UserPair) ([]bool, error) {
+	args := m.Called(ctx, tx, pairs)
+	return args.Get(0).([]bool), args.Error(1)
+}
+
+func (m *MockExploreRepository) InsertOutboxEvents(ctx context.Context, tx Tx, events []OutboxMessage) error {
+	args := m.Called(ctx, tx, events)
+	return args.Error(0)
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// LikeCounters are the denormalized like counts of a user, see withLikeCounters.
+type LikeCounters struct {
+	Received    int64
+	NewReceived int64
+	Matches     int64
+}
+
+// LikeCounterDrift is a user whose stored like counters differ from the counts of their likes.
+type LikeCounterDrift struct {
+	UserID string
+	Stored LikeCounters
+	Actual LikeCounters
+}
+
+// LikeCounterRepository defines methods for checking the like counters against the likes.
+type LikeCounterRepository interface {
+	ReconcileLikeCounters(ctx context.Context, fix bool) ([]LikeCounterDrift, error)
+}
+
+// likeCounterRepository implements the LikeCounterRepository interface.
+type likeCounterRepository struct {
+	db *sql.DB
+}
+
+// NewLikeCounterRepository creates a new instance of likeCounterRepository.
+func NewLikeCounterRepository(db *sql.DB) LikeCounterRepository {
+	return &likeCounterRepository{db: db}
+}
+
+// ReconcileLikeCounters recomputes every user's like counters from the likes, decisions,
+// unmatches and blocks, and returns the users whose stored counters differ, ordered by user
+// ID. With fix, the stored counters of those users are replaced by the recomputed ones.
+//
+// The counters table is locked against writes while it runs. Every write that affects the
+// counters updates them before it changes anything else, so once the lock is held no
+// transaction is halfway through a change and the comparison is exact.
+func (r *likeCounterRepository) ReconcileLikeCounters(ctx context.Context, fix bool) ([]LikeCounterDrift, error) {
+	var drifts []LikeCounterDrift
+	err := WithTx(ctx, r.db, DefaultRetryPolicy, nil, func(tx *sql.Tx) error {
+		drifts = nil
+
+		if _, err := tx.ExecContext(ctx, "LOCK TABLE like_counters IN EXCLUSIVE MODE"); err != nil {
+			return wrapError("failed to lock like counters", err)
+		}
+
+		var err error
+		drifts, err = findLikeCounterDrifts(ctx, tx)
+		if err != nil || !fix || len(drifts) == 0 {
+			return err
+		}
+		return storeLikeCounters(ctx, tx, drifts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return drifts, nil
+}
+
+// findLikeCounterDrifts compares the stored counters with counts computed from the likes.
+// Users missing from either side have zero counters.
+func findLikeCounterDrifts(ctx context.Context, tx *sql.Tx) ([]LikeCounterDrift, error) {
+	query := `
+        WITH actual AS (
+            SELECT likes.recipient_user_id AS user_id,
+                   COUNT(*) AS received,
+                   COUNT(*) FILTER (WHERE decisions.actor_user_id IS NULL) AS new_received,
+                   COUNT(given.actor_user_id) AS matches
+            FROM likes
+            LEFT JOIN likes given
+              ON given.actor_user_id = likes.recipient_user_id
+             AND given.recipient_user_id = likes.actor_user_id
+            LEFT JOIN decisions
+              ON decisions.actor_user_id = likes.recipient_user_id
+             AND decisions.recipient_user_id = likes.actor_user_id
+            WHERE ` + excludeHiddenPairs("likes.actor_user_id", "likes.recipient_user_id") + `
+            GROUP BY likes.recipient_user_id
+        )
+        SELECT COALESCE(actual.user_id, stored.user_id),
+               COALESCE(stored.received, 0), COALESCE(stored.new_received, 0), COALESCE(stored.matches, 0),
+               COALESCE(actual.received, 0), COALESCE(actual.new_received, 0), COALESCE(actual.matches, 0)
+        FROM actual
+        FULL JOIN like_counters stored ON stored.user_id = actual.user_id
+        WHERE (COALESCE(stored.received, 0), COALESCE(stored.new_received, 0), COALESCE(stored.matches, 0))
+           <> (COALESCE(actual.received, 0), COALESCE(actual.new_received, 0), COALESCE(actual.matches, 0))
+        ORDER BY 1`
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, wrapError("failed to compare like counters", err)
+	}
+	defer rows.Close()
+
+	var drifts []LikeCounterDrift
+	for rows.Next() {
+		var drift LikeCounterDrift
+		err := rows.Scan(
+			&drift.UserID,
+			&drift.Stored.Received, &drift.Stored.NewReceived, &drift.Stored.Matches,
+			&drift.Actual.Received, &drift.Actual.NewReceived, &drift.Actual.Matches,
+		)
+		if err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+		drifts = append(drifts, drift)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	return drifts, nil
+}
+
+// storeLikeCounters replaces the stored counters of the drifted users with the actual ones.
+func storeLikeCounters(ctx context.Context, tx *sql.Tx, drifts []LikeCounterDrift) error {
+	userIDs := make([]string, len(drifts))
+	received := make([]int64, len(drifts))
+	newReceived := make([]int64, len(drifts))
+	matches := make([]int64, len(drifts))
+	for i, drift := range drifts {
+		userIDs[i] = drift.UserID
+		received[i] = drift.Actual.Received
+		newReceived[i] = drift.Actual.NewReceived
+		matches[i] = drift.Actual.Matches
+	}
+
+	query := `
+        INSERT INTO like_counters (user_id, received, new_received, matches)
+        SELECT * FROM unnest($1::uuid[], $2::bigint[], $3::bigint[], $4::bigint[])
+        ON CONFLICT (user_id) DO UPDATE
+        SET received = EXCLUDED.received,
+            new_received = EXCLUDED.new_received,
+            matches = EXCLUDED.matches`
+	_, err := tx.ExecContext(ctx, query, pq.Array(userIDs), pq.Array(received), pq.Array(newReceived), pq.Array(matches))
+	if err != nil {
+		return wrapError("failed to store like counters", err)
+	}
+	return nil
+}
@@ -0,0 +1,854 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	explore "muzz-backend-challenge/pkg/proto"
+)
+
+// MemoryRepository is an in-memory implementation of ExploreRepository, OutboxRepository and
+// UserRepository, for running the server without Postgres and for tests.
+//
+// It follows the semantics of the Postgres repositories: the same upserts, ordering, keyset
+// pagination, visibility rules and reference checks on users. Transactions run one at a time
+// on a private copy of the data, which replaces the shared data when they commit, so concurrent
+// transactions are serializable and a rolled back transaction leaves no trace. Reads outside a
+// transaction run concurrently with each other. Published outbox events are discarded rather
+// than kept, so the data does not grow with every decision.
+type MemoryRepository struct {
+	mu    sync.RWMutex
+	state *memoryState
+	now   func() time.Time
+}
+
+var (
+	_ ExploreRepository = (*MemoryRepository)(nil)
+	_ OutboxRepository  = (*MemoryRepository)(nil)
+	_ UserRepository    = (*MemoryRepository)(nil)
+)
+
+// errMemoryTxDone is returned when a memory transaction is committed or rolled back twice.
+var errMemoryTxDone = errors.New("transaction has already been committed or rolled back")
+
+// memoryState holds all the data of a MemoryRepository. The map keys mirror the primary and
+// unique keys of the Postgres tables.
+type memoryState struct {
+	users           map[string]memoryUser
+	likes           map[UserPair]time.Time
+	decisions       map[UserPair]memoryDecision
+	decisionEvents  []BatchDecision
+	unmatches       map[UserPair]bool
+	blocks          map[UserPair]bool
+	reports         []memoryReport
+	idempotencyKeys map[memoryIdempotencyKeyID]memoryIdempotencyKey
+	outbox          []memoryOutboxEvent
+	nextOutboxID    int64
+}
+
+type memoryUser struct {
+	UserProfile
+	createdAt time.Time
+}
+
+type memoryDecision struct {
+	liked     bool
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+type memoryReport struct {
+	reporterUserID string
+	reportedUserID string
+	reason         string
+	createdAt      time.Time
+}
+
+type memoryIdempotencyKeyID struct {
+	actorUserID string
+	key         string
+}
+
+type memoryIdempotencyKey struct {
+	decision  IdempotentDecision
+	completed bool
+	createdAt time.Time
+}
+
+type memoryOutboxEvent struct {
+	OutboxEvent
+	availableAt time.Time
+	lastError   string
+}
+
+// NewMemoryRepository creates an empty in-memory repository. Users must be added with
+// CreateUser, AddUser or AddUsers before decisions can reference them.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		state: &memoryState{
+			users:           make(map[string]memoryUser),
+			likes:           make(map[UserPair]time.Time),
+			decisions:       make(map[UserPair]memoryDecision),
+			unmatches:       make(map[UserPair]bool),
+			blocks:          make(map[UserPair]bool),
+			idempotencyKeys: make(map[memoryIdempotencyKeyID]memoryIdempotencyKey),
+			nextOutboxID:    1,
+		},
+		// Postgres timestamps have microsecond precision
+		now: func() time.Time { return time.Now().UTC().Truncate(time.Microsecond) },
+	}
+}
+
+// AddUsers registers users so that likes, decisions, blocks and reports can reference them.
+// Each user's username is their ID. Adding an existing user does nothing.
+func (r *MemoryRepository) AddUsers(userIDs ...string) {
+	for _, userID := range userIDs {
+		r.AddUser(userID, userID)
+	}
+}
+
+// AddUser registers a user with the given username. Adding an existing user does nothing.
+func (r *MemoryRepository) AddUser(userID, username string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.state.users[userID]; !exists {
+		r.state.users[userID] = memoryUser{UserProfile: UserProfile{Username: username}, createdAt: r.now()}
+	}
+}
+
+// clone returns a copy of the state that can be changed without affecting s.
+func (s *memoryState) clone() *memoryState {
+	return &memoryState{
+		users:           maps.Clone(s.users),
+		likes:           maps.Clone(s.likes),
+		decisions:       maps.Clone(s.decisions),
+		decisionEvents:  slices.Clone(s.decisionEvents),
+		unmatches:       maps.Clone(s.unmatches),
+		blocks:          maps.Clone(s.blocks),
+		reports:         slices.Clone(s.reports),
+		idempotencyKeys: maps.Clone(s.idempotencyKeys),
+		outbox:          slices.Clone(s.outbox),
+		nextOutboxID:    s.nextOutboxID,
+	}
+}
+
+// memoryTx is a transaction of a MemoryRepository. It holds the repository's write lock until
+// it commits or rolls back.
+type memoryTx struct {
+	repository *MemoryRepository
+	state      *memoryState
+	// now is the start time of the transaction, used for every timestamp it writes like
+	// CURRENT_TIMESTAMP in Postgres
+	now  time.Time
+	done bool
+}
+
+// Commit makes the transaction's changes visible and releases the repository.
+func (tx *memoryTx) Commit() error {
+	if tx.done {
+		return fmt.Errorf("failed to commit transaction: %w", errMemoryTxDone)
+	}
+	tx.done = true
+	tx.repository.state = tx.state
+	tx.repository.mu.Unlock()
+	return nil
+}
+
+// Rollback discards the transaction's changes and releases the repository.
+func (tx *memoryTx) Rollback() error {
+	if tx.done {
+		return fmt.Errorf("failed to roll back transaction: %w", errMemoryTxDone)
+	}
+	tx.done = true
+	tx.repository.mu.Unlock()
+	return nil
+}
+
+// memoryTxFrom returns the memory transaction behind tx. Like sqlTx, it panics when given a
+// transaction opened by another storage.
+func memoryTxFrom(tx Tx) *memoryTx {
+	transaction, ok := tx.(*memoryTx)
+	if !ok {
+		panic(fmt.Sprintf("repository: %T is not an in-memory transaction", tx))
+	}
+	return transaction
+}
+
+// WithTx runs fn in a transaction, committing it if fn returns nil and rolling it back
+// otherwise. Transactions never conflict, so fn runs exactly once. fn must only use the
+// transaction it is given: calling methods that do not take a Tx would wait for the
+// transaction to end.
+func (r *MemoryRepository) WithTx(ctx context.Context, fn func(tx Tx) error) error {
+	if err := ctx.Err(); err != nil {
+		return wrapError("failed to begin transaction", err)
+	}
+
+	r.mu.Lock()
+	tx := &memoryTx{repository: r, state: r.state.clone(), now: r.now()}
+	defer func() {
+		// Release the repository even if fn panics
+		if !tx.done {
+			tx.Rollback()
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// read runs fn on the committed state while holding the read lock.
+func (r *MemoryRepository) read(fn func(state *memoryState)) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fn(r.state)
+}
+
+// write runs fn on the committed state while holding the write lock, passing the current time.
+func (r *MemoryRepository) write(fn func(state *memoryState, now time.Time) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return fn(r.state, r.now())
+}
+
+// requireUsers returns ErrInvalidReference, like a foreign key violation, if any of the users
+// does not exist.
+func (s *memoryState) requireUsers(msg string, userIDs ...string) error {
+	for _, userID := range userIDs {
+		if _, exists := s.users[userID]; !exists {
+			return fmt.Errorf("%s: %w: user %s does not exist", msg, ErrInvalidReference, userID)
+		}
+	}
+	return nil
+}
+
+// hidden reports whether either user unmatched or blocked the other, see excludeHiddenPairs.
+func (s *memoryState) hidden(firstUserID, secondUserID string) bool {
+	forward := UserPair{ActorUserID: firstUserID, RecipientUserID: secondUserID}
+	backward := UserPair{ActorUserID: secondUserID, RecipientUserID: firstUserID}
+	return s.unmatches[forward] || s.unmatches[backward] || s.blocks[forward] || s.blocks[backward]
+}
+
+// blocked reports whether either user blocked the other.
+func (s *memoryState) blocked(firstUserID, secondUserID string) bool {
+	return s.blocks[UserPair{ActorUserID: firstUserID, RecipientUserID: secondUserID}] ||
+		s.blocks[UserPair{ActorUserID: secondUserID, RecipientUserID: firstUserID}]
+}
+
+// mutualLike reports whether the recipient likes the actor back, see CheckMutualLike.
+func (s *memoryState) mutualLike(actorUserID, recipientUserID string) bool {
+	_, liked := s.likes[UserPair{ActorUserID: recipientUserID, RecipientUserID: actorUserID}]
+	return liked && !s.hidden(recipientUserID, actorUserID)
+}
+
+// likers returns the visible likes received by the recipient. With onlyNew, likes from users
+// the recipient has already decided on are left out. With includeProfile, each liker's profile
+// is added.
+func (s *memoryState) likers(recipientUserID string, onlyNew, includeProfile bool) []LikerRow {
+	var likers []LikerRow
+	for pair, createdAt := range s.likes {
+		if pair.RecipientUserID != recipientUserID || s.hidden(pair.ActorUserID, pair.RecipientUserID) {
+			continue
+		}
+		if _, decided := s.decisions[UserPair{ActorUserID: recipientUserID, RecipientUserID: pair.ActorUserID}]; onlyNew && decided {
+			continue
+		}
+
+		liker := &explore.ListLikedYouResponse_Liker{
+			ActorId:       pair.ActorUserID,
+			UnixTimestamp: uint64(createdAt.Unix()),
+		}
+		if includeProfile {
+			liker.Profile = likerProfile(s.users[pair.ActorUserID].toProto(pair.ActorUserID))
+		}
+
+		likers = append(likers, LikerRow{
+			Liker:  liker,
+			Cursor: Cursor{CreatedAt: createdAt, UserID: pair.ActorUserID},
+		})
+	}
+	return likers
+}
+
+// matches returns the visible mutual likes of the user.
+func (s *memoryState) matches(userID string) []MatchRow {
+	var matches []MatchRow
+	for pair, givenAt := range s.likes {
+		if pair.ActorUserID != userID || s.hidden(pair.ActorUserID, pair.RecipientUserID) {
+			continue
+		}
+		receivedAt, mutual := s.likes[UserPair{ActorUserID: pair.RecipientUserID, RecipientUserID: userID}]
+		if !mutual {
+			continue
+		}
+
+		matchedAt := givenAt
+		if receivedAt.After(givenAt) {
+			matchedAt = receivedAt
+		}
+		matches = append(matches, MatchRow{
+			Match: &explore.ListMatchesResponse_Match{
+				UserId:        pair.RecipientUserID,
+				UnixTimestamp: uint64(matchedAt.Unix()),
+			},
+			Cursor: Cursor{CreatedAt: matchedAt, UserID: pair.RecipientUserID},
+		})
+	}
+	return matches
+}
+
+// compareCursors orders cursors by timestamp, then user ID, as the Postgres keyset queries do.
+func compareCursors(a, b Cursor) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.UserID, b.UserID)
+}
+
+// page sorts rows newest first and returns up to limit of them, starting after the cursor.
+func page[T any](rows []T, cursor func(T) Cursor, limit int, after *Cursor) []T {
+	slices.SortFunc(rows, func(a, b T) int {
+		return compareCursors(cursor(b), cursor(a))
+	})
+
+	start := 0
+	if after != nil {
+		start = len(rows)
+		for i, row := range rows {
+			if compareCursors(cursor(row), *after) < 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	rows = rows[start:]
+	if len(rows) > limit {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+// feedCandidates returns the users the given user has not decided on and is not hidden from,
+// see GetExploreFeed, in the given order.
+func (s *memoryState) feedCandidates(userID string, order FeedOrder) []CandidateRow {
+	var candidates []CandidateRow
+	for candidateID, user := range s.users {
+		if candidateID == userID || s.hidden(candidateID, userID) {
+			continue
+		}
+		if _, decided := s.decisions[UserPair{ActorUserID: userID, RecipientUserID: candidateID}]; decided {
+			continue
+		}
+
+		likedAt, likedYou := s.likes[UserPair{ActorUserID: candidateID, RecipientUserID: userID}]
+		likersFirst := likedYou && order == FeedOrderLikersFirst
+		rankedAt := user.createdAt
+		if likersFirst {
+			rankedAt = likedAt
+		}
+		candidates = append(candidates, CandidateRow{
+			Candidate: &explore.GetExploreFeedResponse_Candidate{
+				UserId:   candidateID,
+				Username: user.Username,
+				LikedYou: likedYou,
+			},
+			Cursor: FeedCursor{LikedYou: likersFirst, Cursor: Cursor{CreatedAt: rankedAt, UserID: candidateID}},
+		})
+	}
+
+	slices.SortFunc(candidates, func(a, b CandidateRow) int {
+		return compareFeedCursors(b.Cursor, a.Cursor)
+	})
+	return candidates
+}
+
+// compareFeedCursors orders feed cursors with likers after everyone else, then like compareCursors.
+func compareFeedCursors(a, b FeedCursor) int {
+	if a.LikedYou != b.LikedYou {
+		if a.LikedYou {
+			return 1
+		}
+		return -1
+	}
+	return compareCursors(a.Cursor, b.Cursor)
+}
+
+func likerCursor(row LikerRow) Cursor       { return row.Cursor }
+func matchCursor(row MatchRow) Cursor       { return row.Cursor }
+func decisionCursor(row DecisionRow) Cursor { return row.Cursor }
+
+// GetLikedYou retrieves a list of users who liked the recipient user, newest first.
+// With includeProfile, each liker's profile is added.
+// If after is set, only likes older than the cursor are returned.
+func (r *MemoryRepository) GetLikedYou(ctx context.Context, recipientUserID string, includeProfile bool, limit int, after *Cursor) ([]LikerRow, error) {
+	var likers []LikerRow
+	r.read(func(state *memoryState) {
+		likers = page(state.likers(recipientUserID, false, includeProfile), likerCursor, limit, after)
+	})
+	return likers, nil
+}
+
+// GetNewLikedYou retrieves a list of new users who liked the recipient user, newest first.
+// New likers are those the recipient has not yet made a decision on, either like or pass.
+// With includeProfile, each liker's profile is added.
+// If after is set, only likes older than the cursor are returned.
+func (r *MemoryRepository) GetNewLikedYou(ctx context.Context, recipientUserID string, includeProfile bool, limit int, after *Cursor) ([]LikerRow, error) {
+	var likers []LikerRow
+	r.read(func(state *memoryState) {
+		likers = page(state.likers(recipientUserID, true, includeProfile), likerCursor, limit, after)
+	})
+	return likers, nil
+}
+
+// CountLikes counts the number of users who liked the recipient user.
+func (r *MemoryRepository) CountLikes(ctx context.Context, recipientUserID string) (int64, error) {
+	var count int64
+	r.read(func(state *memoryState) {
+		count = int64(len(state.likers(recipientUserID, false, false)))
+	})
+	return count, nil
+}
+
+// CountNewLikes counts the users who liked the recipient user and whom the recipient has not yet decided on.
+func (r *MemoryRepository) CountNewLikes(ctx context.Context, recipientUserID string) (int64, error) {
+	var count int64
+	r.read(func(state *memoryState) {
+		count = int64(len(state.likers(recipientUserID, true, false)))
+	})
+	return count, nil
+}
+
+// InsertDecision records a user's decision (like/dislike) regarding another user, keeping the
+// time of the first decision on the pair and appending the decision to the history.
+func (r *MemoryRepository) InsertDecision(ctx context.Context, transaction Tx, actorUserID, recipientUserID string, likedRecipient bool) error {
+	tx := memoryTxFrom(transaction)
+
+	if err := tx.state.requireUsers("failed to insert decision", actorUserID, recipientUserID); err != nil {
+		return err
+	}
+	tx.putDecision(BatchDecision{
+		UserPair:       UserPair{ActorUserID: actorUserID, RecipientUserID: recipientUserID},
+		LikedRecipient: likedRecipient,
+	})
+	return nil
+}
+
+// putDecision upserts a decision and records it in the history.
+func (tx *memoryTx) putDecision(decision BatchDecision) {
+	current, exists := tx.state.decisions[decision.UserPair]
+	if !exists {
+		current.createdAt = tx.now
+	}
+	current.liked = decision.LikedRecipient
+	current.updatedAt = tx.now
+
+	tx.state.decisions[decision.UserPair] = current
+	tx.state.decisionEvents = append(tx.state.decisionEvents, decision)
+}
+
+// InsertLike records a like action from the actor user to the recipient user. An existing
+// like keeps its original time.
+func (r *MemoryRepository) InsertLike(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) error {
+	tx := memoryTxFrom(transaction)
+
+	if err := tx.state.requireUsers("failed to insert like", actorUserID, recipientUserID); err != nil {
+		return err
+	}
+	tx.putLike(UserPair{ActorUserID: actorUserID, RecipientUserID: recipientUserID})
+	return nil
+}
+
+// putLike records a like unless it already exists.
+func (tx *memoryTx) putLike(pair UserPair) {
+	if _, exists := tx.state.likes[pair]; !exists {
+		tx.state.likes[pair] = tx.now
+	}
+}
+
+// DeleteLike removes a like action from the actor user to the recipient user.
+func (r *MemoryRepository) DeleteLike(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) error {
+	tx := memoryTxFrom(transaction)
+
+	delete(tx.state.likes, UserPair{ActorUserID: actorUserID, RecipientUserID: recipientUserID})
+	return nil
+}
+
+// CheckMutualLike checks if there is a mutual like between the actor user and the recipient user.
+func (r *MemoryRepository) CheckMutualLike(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) (bool, error) {
+	tx := memoryTxFrom(transaction)
+
+	return tx.state.mutualLike(actorUserID, recipientUserID), nil
+}
+
+// GetMatches retrieves the users who share a mutual like with the given user, most recent match first.
+// The match time is the later of the two likes. If after is set, only older matches are returned.
+func (r *MemoryRepository) GetMatches(ctx context.Context, userID string, limit int, after *Cursor) ([]MatchRow, error) {
+	var matches []MatchRow
+	r.read(func(state *memoryState) {
+		matches = page(state.matches(userID), matchCursor, limit, after)
+	})
+	return matches, nil
+}
+
+// CountMatches counts the users who share a mutual like with the given user.
+func (r *MemoryRepository) CountMatches(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	r.read(func(state *memoryState) {
+		count = int64(len(state.matches(userID)))
+	})
+	return count, nil
+}
+
+// Unmatch removes the mutual like between the actor and the recipient and records that the
+// actor unmatched. It returns ErrNotFound if the users are not currently matched.
+func (r *MemoryRepository) Unmatch(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) error {
+	tx := memoryTxFrom(transaction)
+
+	given := UserPair{ActorUserID: actorUserID, RecipientUserID: recipientUserID}
+	received := UserPair{ActorUserID: recipientUserID, RecipientUserID: actorUserID}
+	_, liked := tx.state.likes[given]
+	_, likedBack := tx.state.likes[received]
+	if !liked || !likedBack {
+		return fmt.Errorf("failed to unmatch: %w: users are not matched", ErrNotFound)
+	}
+
+	delete(tx.state.likes, given)
+	delete(tx.state.likes, received)
+	tx.state.unmatches[given] = true
+	return nil
+}
+
+// BlockUser records that the blocker has blocked the blocked user. Blocking is idempotent.
+func (r *MemoryRepository) BlockUser(ctx context.Context, blockerUserID, blockedUserID string) error {
+	return r.write(func(state *memoryState, _ time.Time) error {
+		if err := state.requireUsers("failed to block user", blockerUserID, blockedUserID); err != nil {
+			return err
+		}
+		state.blocks[UserPair{ActorUserID: blockerUserID, RecipientUserID: blockedUserID}] = true
+		return nil
+	})
+}
+
+// UnblockUser removes a block previously placed by the blocker. Unblocking is idempotent.
+func (r *MemoryRepository) UnblockUser(ctx context.Context, blockerUserID, blockedUserID string) error {
+	return r.write(func(state *memoryState, _ time.Time) error {
+		delete(state.blocks, UserPair{ActorUserID: blockerUserID, RecipientUserID: blockedUserID})
+		return nil
+	})
+}
+
+// IsBlocked checks if either user has blocked the other.
+func (r *MemoryRepository) IsBlocked(ctx context.Context, transaction Tx, actorUserID, recipientUserID string) (bool, error) {
+	tx := memoryTxFrom(transaction)
+
+	return tx.state.blocked(actorUserID, recipientUserID), nil
+}
+
+// ReportUser records a report filed by the reporter against the reported user.
+func (r *MemoryRepository) ReportUser(ctx context.Context, reporterUserID, reportedUserID, reason string) error {
+	return r.write(func(state *memoryState, now time.Time) error {
+		if err := state.requireUsers("failed to report user", reporterUserID, reportedUserID); err != nil {
+			return err
+		}
+		state.reports = append(state.reports, memoryReport{
+			reporterUserID: reporterUserID,
+			reportedUserID: reportedUserID,
+			reason:         reason,
+			createdAt:      now,
+		})
+		return nil
+	})
+}
+
+// GetDecisions retrieves the actor user's current decisions on other users, most recently
+// updated first. The filter restricts the result to likes or passes. If after is set, only
+// decisions older than the cursor are returned.
+func (r *MemoryRepository) GetDecisions(ctx context.Context, actorUserID string, filter explore.DecisionFilter, limit int, after *Cursor) ([]DecisionRow, error) {
+	var decisions []DecisionRow
+	r.read(func(state *memoryState) {
+		for pair, decision := range state.decisions {
+			if pair.ActorUserID != actorUserID {
+				continue
+			}
+			if (filter == explore.DecisionFilter_DECISION_FILTER_LIKES && !decision.liked) ||
+				(filter == explore.DecisionFilter_DECISION_FILTER_PASSES && decision.liked) {
+				continue
+			}
+
+			decisions = append(decisions, DecisionRow{
+				Decision: &explore.ListDecisionsResponse_Decision{
+					RecipientUserId: pair.RecipientUserID,
+					LikedRecipient:  decision.liked,
+					UnixTimestamp:   uint64(decision.updatedAt.Unix()),
+				},
+				Cursor: Cursor{CreatedAt: decision.updatedAt, UserID: pair.RecipientUserID},
+			})
+		}
+		decisions = page(decisions, decisionCursor, limit, after)
+	})
+	return decisions, nil
+}
+
+// GetExploreFeed retrieves the users the given user has not decided on yet, leaving out the
+// user and anyone hidden from them by a block or unmatch, in the given order. If after is set,
+// only candidates after the cursor are returned.
+func (r *MemoryRepository) GetExploreFeed(ctx context.Context, userID string, order FeedOrder, limit int, after *FeedCursor) ([]CandidateRow, error) {
+	var candidates []CandidateRow
+	r.read(func(state *memoryState) {
+		candidates = state.feedCandidates(userID, order)
+		if after != nil {
+			start := len(candidates)
+			for i, candidate := range candidates {
+				if compareFeedCursors(candidate.Cursor, *after) < 0 {
+					start = i
+					break
+				}
+			}
+			candidates = candidates[start:]
+		}
+		if len(candidates) > limit {
+			candidates = candidates[:limit]
+		}
+	})
+	return candidates, nil
+}
+
+// InsertOutboxEvent queues an event in the outbox as part of the transaction, so it is only
+// published if the transaction commits.
+func (r *MemoryRepository) InsertOutboxEvent(ctx context.Context, transaction Tx, eventType string, payload []byte) error {
+	tx := memoryTxFrom(transaction)
+
+	return tx.queueOutboxEvents("failed to insert outbox event", []OutboxMessage{{EventType: eventType, Payload: payload}})
+}
+
+// queueOutboxEvents appends events to the outbox. Like the JSONB column in Postgres, it rejects
+// payloads that are not valid JSON.
+func (tx *memoryTx) queueOutboxEvents(msg string, events []OutboxMessage) error {
+	for _, event := range events {
+		if !json.Valid(event.Payload) {
+			return fmt.Errorf("%s: payload of %s event is not valid JSON", msg, event.EventType)
+		}
+	}
+
+	for _, event := range events {
+		tx.state.outbox = append(tx.state.outbox, memoryOutboxEvent{
+			OutboxEvent: OutboxEvent{
+				ID:        tx.state.nextOutboxID,
+				EventType: event.EventType,
+				Payload:   slices.Clone(event.Payload),
+				CreatedAt: tx.now,
+			},
+			availableAt: tx.now,
+		})
+		tx.state.nextOutboxID++
+	}
+	return nil
+}
+
+// ReserveIdempotencyKey claims an idempotency key for a decision within the transaction.
+//
+// It returns nil if the key is new or has expired, in which case the caller records the
+// decision and completes the key with CompleteIdempotencyKey before committing. If the key was
+// already used within the ttl, the decision recorded under it is returned instead. Reserving a
+// key again in the transaction that reserved it, before completing it, fails with ErrConflict.
+func (r *MemoryRepository) ReserveIdempotencyKey(ctx context.Context, transaction Tx, actorUserID, key, recipientUserID string, likedRecipient bool, ttl time.Duration) (*IdempotentDecision, error) {
+	tx := memoryTxFrom(transaction)
+
+	if err := tx.state.requireUsers("failed to reserve idempotency key", actorUserID); err != nil {
+		return nil, err
+	}
+
+	id := memoryIdempotencyKeyID{actorUserID: actorUserID, key: key}
+	if existing, ok := tx.state.idempotencyKeys[id]; ok && existing.createdAt.After(tx.now.Add(-ttl)) {
+		if !existing.completed {
+			return nil, fmt.Errorf("failed to reserve idempotency key: %w: key %s is reserved but not completed", ErrConflict, key)
+		}
+		decision := existing.decision
+		return &decision, nil
+	}
+
+	tx.state.idempotencyKeys[id] = memoryIdempotencyKey{
+		decision:  IdempotentDecision{RecipientUserID: recipientUserID, LikedRecipient: likedRecipient},
+		createdAt: tx.now,
+	}
+	return nil, nil
+}
+
+// CompleteIdempotencyKey stores the outcome of the decision recorded under a reserved key.
+func (r *MemoryRepository) CompleteIdempotencyKey(ctx context.Context, transaction Tx, actorUserID, key string, mutualLikes bool) error {
+	tx := memoryTxFrom(transaction)
+
+	id := memoryIdempotencyKeyID{actorUserID: actorUserID, key: key}
+	if reserved, ok := tx.state.idempotencyKeys[id]; ok {
+		reserved.decision.MutualLikes = mutualLikes
+		reserved.completed = true
+		tx.state.idempotencyKeys[id] = reserved
+	}
+	return nil
+}
+
+// DeleteExpiredIdempotencyKeys removes the keys older than ttl and returns how many were removed.
+func (r *MemoryRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, ttl time.Duration) (int64, error) {
+	var deleted int64
+	err := r.write(func(state *memoryState, now time.Time) error {
+		for id, reserved := range state.idempotencyKeys {
+			if !reserved.createdAt.After(now.Add(-ttl)) {
+				delete(state.idempotencyKeys, id)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// FindExistingUsers returns the subset of the given user IDs that belong to existing users.
+func (r *MemoryRepository) FindExistingUsers(ctx context.Context, transaction Tx, userIDs []string) (map[string]bool, error) {
+	tx := memoryTxFrom(transaction)
+
+	existing := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if _, exists := tx.state.users[userID]; exists {
+			existing[userID] = true
+		}
+	}
+	return existing, nil
+}
+
+// CheckBlockedPairs checks, for each pair, if either user has blocked the other. The result
+// is in the order of pairs.
+func (r *MemoryRepository) CheckBlockedPairs(ctx context.Context, transaction Tx, pairs []UserPair) ([]bool, error) {
+	tx := memoryTxFrom(transaction)
+
+	blocked := make([]bool, len(pairs))
+	for i, pair := range pairs {
+		blocked[i] = tx.state.blocked(pair.ActorUserID, pair.RecipientUserID)
+	}
+	return blocked, nil
+}
+
+// InsertDecisions records a batch of decisions, as InsertDecision does for one. The batch
+// must not contain the same pair twice.
+func (r *MemoryRepository) InsertDecisions(ctx context.Context, transaction Tx, decisions []BatchDecision) error {
+	tx := memoryTxFrom(transaction)
+
+	for _, decision := range decisions {
+		err := tx.state.requireUsers("failed to insert decisions", decision.ActorUserID, decision.RecipientUserID)
+		if err != nil {
+			return err
+		}
+	}
+	for _, decision := range decisions {
+		tx.putDecision(decision)
+	}
+	return nil
+}
+
+// InsertLikes records a like for each pair. Existing likes are kept.
+func (r *MemoryRepository) InsertLikes(ctx context.Context, transaction Tx, pairs []UserPair) error {
+	tx := memoryTxFrom(transaction)
+
+	for _, pair := range pairs {
+		if err := tx.state.requireUsers("failed to insert likes", pair.ActorUserID, pair.RecipientUserID); err != nil {
+			return err
+		}
+	}
+	for _, pair := range pairs {
+		tx.putLike(pair)
+	}
+	return nil
+}
+
+// DeleteLikes removes the like of each pair, if any.
+func (r *MemoryRepository) DeleteLikes(ctx context.Context, transaction Tx, pairs []UserPair) error {
+	tx := memoryTxFrom(transaction)
+
+	for _, pair := range pairs {
+		delete(tx.state.likes, pair)
+	}
+	return nil
+}
+
+// CheckMutualLikes checks, for each pair, if the recipient also likes the actor, as
+// CheckMutualLike does for one pair. The result is in the order of pairs.
+func (r *MemoryRepository) CheckMutualLikes(ctx context.Context, transaction Tx, pairs []UserPair) ([]bool, error) {
+	tx := memoryTxFrom(transaction)
+
+	mutual := make([]bool, len(pairs))
+	for i, pair := range pairs {
+		mutual[i] = tx.state.mutualLike(pair.ActorUserID, pair.RecipientUserID)
+	}
+	return mutual, nil
+}
+
+// InsertOutboxEvents queues several events in the outbox as part of the transaction, in order.
+func (r *MemoryRepository) InsertOutboxEvents(ctx context.Context, transaction Tx, events []OutboxMessage) error {
+	tx := memoryTxFrom(transaction)
+
+	return tx.queueOutboxEvents("failed to insert outbox events", events)
+}
+
+// ClaimOutboxEvents claims up to limit pending events, oldest first, and hides them from other
+// claimers for the lease duration, as the Postgres outbox does.
+func (r *MemoryRepository) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error) {
+	var events []OutboxEvent
+	err := r.write(func(state *memoryState, now time.Time) error {
+		for i := range state.outbox {
+			if len(events) == limit {
+				break
+			}
+			event := &state.outbox[i]
+			if event.availableAt.After(now) {
+				continue
+			}
+
+			event.Attempts++
+			event.availableAt = now.Add(lease)
+			claimed := event.OutboxEvent
+			claimed.Payload = slices.Clone(event.Payload)
+			events = append(events, claimed)
+		}
+		return nil
+	})
+	return events, err
+}
+
+// MarkOutboxEventPublished removes a published event from the outbox so it is never claimed again.
+func (r *MemoryRepository) MarkOutboxEventPublished(ctx context.Context, id int64) error {
+	return r.write(func(state *memoryState, _ time.Time) error {
+		state.outbox = slices.DeleteFunc(state.outbox, func(event memoryOutboxEvent) bool {
+			return event.ID == id
+		})
+		return nil
+	})
+}
+
+// DeletePublishedOutboxEvents removes the events published more than retention ago. Published
+// events are removed as soon as they are marked, so there is never anything left to delete.
+func (r *MemoryRepository) DeletePublishedOutboxEvents(ctx context.Context, retention time.Duration) (int64, error) {
+	return 0, nil
+}
+
+// MarkOutboxEventFailed records a failed publish attempt and makes the event available again
+// after retryIn.
+func (r *MemoryRepository) MarkOutboxEventFailed(ctx context.Context, id int64, retryIn time.Duration, reason string) error {
+	return r.write(func(state *memoryState, now time.Time) error {
+		for i := range state.outbox {
+			if state.outbox[i].ID == id {
+				state.outbox[i].availableAt = now.Add(retryIn)
+				state.outbox[i].lastError = reason
+			}
+		}
+		return nil
+	})
+}
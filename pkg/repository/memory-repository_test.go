@@ -0,0 +1,163 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+func TestMemoryRepositoryConformance(t *testing.T) {
+	runExploreRepositoryConformance(t, func(t *testing.T) conformanceStore {
+		repo := repository.NewMemoryRepository()
+		return conformanceStore{
+			repo: repo,
+			addUsers: func(t *testing.T, userIDs ...uuid.UUID) {
+				for _, userID := range userIDs {
+					repo.AddUsers(userID.String())
+				}
+			},
+		}
+	})
+}
+
+func TestMemoryUserRepositoryConformance(t *testing.T) {
+	runUserRepositoryConformance(t, func(t *testing.T) userConformanceStore {
+		repo := repository.NewMemoryRepository()
+		return userConformanceStore{users: repo, explore: repo}
+	})
+}
+
+func TestMemoryRepositoryOutbox(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+
+	// Events from a rolled back transaction are never queued
+	err := repo.WithTx(ctx, func(tx repository.Tx) error {
+		require.NoError(t, repo.InsertOutboxEvent(ctx, tx, "decision.recorded", []byte(`{"n":0}`)))
+		return assert.AnError
+	})
+	require.ErrorIs(t, err, assert.AnError)
+
+	err = repo.WithTx(ctx, func(tx repository.Tx) error {
+		return repo.InsertOutboxEvents(ctx, tx, []repository.OutboxMessage{
+			{EventType: "decision.recorded", Payload: []byte(`{"n":1}`)},
+			{EventType: "match.created", Payload: []byte(`{"n":2}`)},
+		})
+	})
+	require.NoError(t, err)
+
+	err = repo.WithTx(ctx, func(tx repository.Tx) error {
+		return repo.InsertOutboxEvent(ctx, tx, "decision.recorded", []byte(`not json`))
+	})
+	assert.Error(t, err)
+
+	events, err := repo.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "decision.recorded", events[0].EventType)
+	assert.JSONEq(t, `{"n":1}`, string(events[0].Payload))
+	assert.Equal(t, 1, events[0].Attempts)
+	assert.Equal(t, "match.created", events[1].EventType)
+
+	// Claimed events are leased and not handed out again
+	claimed, err := repo.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	require.NoError(t, repo.MarkOutboxEventPublished(ctx, events[0].ID))
+	require.NoError(t, repo.MarkOutboxEventFailed(ctx, events[1].ID, 0, "endpoint down"))
+
+	// Only the failed event becomes available again, with its attempts counted
+	retried, err := repo.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, retried, 1)
+	assert.Equal(t, events[1].ID, retried[0].ID)
+	assert.Equal(t, 2, retried[0].Attempts)
+}
+
+func TestMemoryRepositoryDeleteUserDataDropsOutboxEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+	repo.AddUsers("deleted", "other")
+
+	err := repo.WithTx(ctx, func(tx repository.Tx) error {
+		return repo.InsertOutboxEvents(ctx, tx, []repository.OutboxMessage{
+			{EventType: "decision.recorded", Payload: []byte(`{"actor_user_id":"deleted","recipient_user_id":"other"}`)},
+			{EventType: "decision.recorded", Payload: []byte(`{"actor_user_id":"other","recipient_user_id":"deleted"}`)},
+			{EventType: "match.created", Payload: []byte(`{"user_ids":["other","deleted"]}`)},
+			{EventType: "decision.recorded", Payload: []byte(`{"actor_user_id":"other","recipient_user_id":"someone"}`)},
+		})
+	})
+	require.NoError(t, err)
+
+	_, err = repo.DeleteUserData(ctx, "deleted")
+	require.NoError(t, err)
+
+	events, err := repo.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.JSONEq(t, `{"actor_user_id":"other","recipient_user_id":"someone"}`, string(events[0].Payload))
+}
+
+func TestMemoryRepositoryDeleteExpiredIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+	repo.AddUsers("actor", "recipient")
+
+	err := repo.WithTx(ctx, func(tx repository.Tx) error {
+		_, err := repo.ReserveIdempotencyKey(ctx, tx, "actor", "swipe-1", "recipient", true, time.Hour)
+		return err
+	})
+	require.NoError(t, err)
+
+	deleted, err := repo.DeleteExpiredIdempotencyKeys(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	time.Sleep(2 * time.Millisecond)
+	deleted, err = repo.DeleteExpiredIdempotencyKeys(ctx, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
+func TestMemoryRepositoryReleasesPanickingTransactions(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryRepository()
+	repo.AddUsers("actor", "recipient")
+
+	assert.Panics(t, func() {
+		repo.WithTx(ctx, func(tx repository.Tx) error {
+			require.NoError(t, repo.InsertLike(ctx, tx, "actor", "recipient"))
+			panic("boom")
+		})
+	})
+
+	// The repository is usable again and the like was rolled back
+	count, err := repo.CountLikes(ctx, "recipient")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestMemoryRepositoryRejectsForeignTransactions(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+
+	assert.Panics(t, func() {
+		repo.InsertLike(context.Background(), &repository.MockTx{}, "actor", "recipient")
+	})
+}
+
+func TestMemoryRepositoryRejectsCancelledContexts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := repository.NewMemoryRepository().WithTx(ctx, func(tx repository.Tx) error {
+		t.Fatal("the transaction must not run")
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
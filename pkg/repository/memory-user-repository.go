@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	explore "muzz-backend-challenge/pkg/proto"
+)
+
+// CreateUser creates a user with a new ID. A username that is already taken fails with ErrConflict.
+func (r *MemoryRepository) CreateUser(ctx context.Context, profile UserProfile) (*explore.User, error) {
+	var user *explore.User
+	err := r.write(func(state *memoryState, now time.Time) error {
+		if err := state.requireUsernameFree("failed to create user", "", profile.Username); err != nil {
+			return err
+		}
+
+		userID := uuid.NewString()
+		state.users[userID] = memoryUser{UserProfile: profile, createdAt: now}
+		user = state.users[userID].toProto(userID)
+		return nil
+	})
+	return user, err
+}
+
+// GetUser retrieves a user by ID, or fails with ErrNotFound.
+func (r *MemoryRepository) GetUser(ctx context.Context, userID string) (*explore.User, error) {
+	var user *explore.User
+	var err error
+	r.read(func(state *memoryState) {
+		stored, exists := state.users[userID]
+		if !exists {
+			err = fmt.Errorf("failed to get user: %w: user does not exist", ErrNotFound)
+			return
+		}
+		user = stored.toProto(userID)
+	})
+	return user, err
+}
+
+// UpdateUser changes the fields of the update that are set and returns the updated user. It
+// fails with ErrNotFound if the user does not exist and with ErrConflict if the new username is
+// already taken.
+func (r *MemoryRepository) UpdateUser(ctx context.Context, userID string, update UserUpdate) (*explore.User, error) {
+	var user *explore.User
+	err := r.write(func(state *memoryState, now time.Time) error {
+		stored, exists := state.users[userID]
+		if !exists {
+			return fmt.Errorf("failed to update user: %w: user does not exist", ErrNotFound)
+		}
+
+		if update.Username != nil {
+			if err := state.requireUsernameFree("failed to update user", userID, *update.Username); err != nil {
+				return err
+			}
+			stored.Username = *update.Username
+		}
+		if update.DisplayName != nil {
+			stored.DisplayName = *update.DisplayName
+		}
+		if update.Birthdate != nil {
+			stored.Birthdate = *update.Birthdate
+		}
+		if update.Gender != nil {
+			stored.Gender = *update.Gender
+		}
+		if update.Bio != nil {
+			stored.Bio = *update.Bio
+		}
+
+		state.users[userID] = stored
+		user = stored.toProto(userID)
+		return nil
+	})
+	return user, err
+}
+
+// DeleteUser deletes a user. Like the foreign keys in Postgres, it fails with ErrNotFound if the
+// user does not exist and with ErrInvalidReference while the user still has likes, decisions,
+// blocks, reports or idempotency keys.
+func (r *MemoryRepository) DeleteUser(ctx context.Context, userID string) error {
+	return r.write(func(state *memoryState, now time.Time) error {
+		if _, exists := state.users[userID]; !exists {
+			return fmt.Errorf("failed to delete user: %w: user does not exist", ErrNotFound)
+		}
+		if state.referenced(userID) {
+			return fmt.Errorf("failed to delete user: %w: user %s is still referenced", ErrInvalidReference, userID)
+		}
+
+		delete(state.users, userID)
+		return nil
+	})
+}
+
+// DeleteUserData deletes a user together with their likes, decisions, unmatches, blocks,
+// idempotency keys and the outbox events naming them, and removes their ID from the reports
+// they filed or received. It returns the users they liked, whose likers changed, and fails with
+// ErrNotFound if the user does not exist.
+func (r *MemoryRepository) DeleteUserData(ctx context.Context, userID string) ([]string, error) {
+	var likedUserIDs []string
+	err := r.write(func(state *memoryState, now time.Time) error {
+		if _, exists := state.users[userID]; !exists {
+			return fmt.Errorf("failed to delete user data: %w: user does not exist", ErrNotFound)
+		}
+
+		involves := func(pair UserPair) bool {
+			return pair.ActorUserID == userID || pair.RecipientUserID == userID
+		}
+		for pair := range state.likes {
+			if pair.ActorUserID == userID {
+				likedUserIDs = append(likedUserIDs, pair.RecipientUserID)
+			}
+		}
+		maps.DeleteFunc(state.likes, func(pair UserPair, _ time.Time) bool { return involves(pair) })
+		maps.DeleteFunc(state.decisions, func(pair UserPair, _ memoryDecision) bool { return involves(pair) })
+		maps.DeleteFunc(state.unmatches, func(pair UserPair, _ bool) bool { return involves(pair) })
+		maps.DeleteFunc(state.blocks, func(pair UserPair, _ bool) bool { return involves(pair) })
+		state.decisionEvents = slices.DeleteFunc(state.decisionEvents, func(decision BatchDecision) bool {
+			return involves(decision.UserPair)
+		})
+		maps.DeleteFunc(state.idempotencyKeys, func(id memoryIdempotencyKeyID, key memoryIdempotencyKey) bool {
+			return id.actorUserID == userID || key.decision.RecipientUserID == userID
+		})
+		state.outbox = slices.DeleteFunc(state.outbox, func(event memoryOutboxEvent) bool {
+			return namesUser(event.Payload, userID)
+		})
+
+		for i, report := range state.reports {
+			if report.reporterUserID == userID {
+				state.reports[i].reporterUserID = ""
+			}
+			if report.reportedUserID == userID {
+				state.reports[i].reportedUserID = ""
+			}
+		}
+
+		delete(state.users, userID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return likedUserIDs, nil
+}
+
+// namesUser reports whether an event payload names the user in any of the fields the events
+// use for user IDs.
+func namesUser(payload []byte, userID string) bool {
+	var names struct {
+		ActorUserID     string   `json:"actor_user_id"`
+		RecipientUserID string   `json:"recipient_user_id"`
+		UserIDs         []string `json:"user_ids"`
+	}
+	if err := json.Unmarshal(payload, &names); err != nil {
+		return false
+	}
+	return names.ActorUserID == userID || names.RecipientUserID == userID || slices.Contains(names.UserIDs, userID)
+}
+
+// ExportUserData retrieves the user's profile, the likes they gave and received, their
+// decisions and their matches. It fails with ErrNotFound if the user does not exist.
+func (r *MemoryRepository) ExportUserData(ctx context.Context, userID string) (*UserData, error) {
+	var data *UserData
+	var err error
+	r.read(func(state *memoryState) {
+		stored, exists := state.users[userID]
+		if !exists {
+			err = fmt.Errorf("failed to get user: %w: user does not exist", ErrNotFound)
+			return
+		}
+		data = &UserData{User: stored.toProto(userID)}
+
+		for pair, createdAt := range state.likes {
+			switch userID {
+			case pair.ActorUserID:
+				data.LikesGiven = append(data.LikesGiven, UserDataLike{UserID: pair.RecipientUserID, CreatedAt: createdAt})
+			case pair.RecipientUserID:
+				data.LikesReceived = append(data.LikesReceived, UserDataLike{UserID: pair.ActorUserID, CreatedAt: createdAt})
+			}
+		}
+		for pair, decision := range state.decisions {
+			if pair.ActorUserID == userID {
+				data.Decisions = append(data.Decisions, UserDataDecision{
+					UserID:    pair.RecipientUserID,
+					Liked:     decision.liked,
+					CreatedAt: decision.createdAt,
+					UpdatedAt: decision.updatedAt,
+				})
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	oldestFirst := func(a, b UserDataLike) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.UserID, b.UserID)
+	}
+	slices.SortFunc(data.LikesGiven, oldestFirst)
+	slices.SortFunc(data.LikesReceived, oldestFirst)
+	slices.SortFunc(data.Decisions, func(a, b UserDataDecision) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+	data.Matches = matchesOf(data.LikesGiven, data.LikesReceived)
+	return data, nil
+}
+
+// requireUsernameFree returns ErrConflict, like the unique constraint on usernames, if another
+// user than userID has the username.
+func (s *memoryState) requireUsernameFree(msg, userID, username string) error {
+	for otherUserID, user := range s.users {
+		if otherUserID != userID && user.Username == username {
+			return fmt.Errorf("%s: %w: username %s is taken", msg, ErrConflict, username)
+		}
+	}
+	return nil
+}
+
+// referenced reports whether any row refers to the user.
+func (s *memoryState) referenced(userID string) bool {
+	involves := func(pair UserPair) bool {
+		return pair.ActorUserID == userID || pair.RecipientUserID == userID
+	}
+
+	for pair := range s.likes {
+		if involves(pair) {
+			return true
+		}
+	}
+	for pair := range s.decisions {
+		if involves(pair) {
+			return true
+		}
+	}
+	for _, decision := range s.decisionEvents {
+		if involves(decision.UserPair) {
+			return true
+		}
+	}
+	for pair := range s.unmatches {
+		if involves(pair) {
+			return true
+		}
+	}
+	for pair := range s.blocks {
+		if involves(pair) {
+			return true
+		}
+	}
+	for _, report := range s.reports {
+		if report.reporterUserID == userID || report.reportedUserID == userID {
+			return true
+		}
+	}
+	for id := range s.idempotencyKeys {
+		if id.actorUserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// toProto converts the stored user to its proto form.
+func (u memoryUser) toProto(userID string) *explore.User {
+	return &explore.User{
+		UserId:        userID,
+		Username:      u.Username,
+		DisplayName:   u.DisplayName,
+		Birthdate:     u.Birthdate,
+		Gender:        u.Gender,
+		Bio:           u.Bio,
+		UnixTimestamp: uint64(u.createdAt.Unix()),
+	}
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+)
+
+// OutboxEvent is an event queued in the outbox for publishing.
+type OutboxEvent struct {
+	ID        int64
+	EventType string
+	Payload   []byte
+	Attempts  int
+	CreatedAt time.Time
+}
+
+// OutboxRepository defines methods for draining the outbox.
+type OutboxRepository interface {
+	ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error)
+	MarkOutboxEventPublished(ctx context.Context, id int64) error
+	MarkOutboxEventFailed(ctx context.Context, id int64, retryIn time.Duration, reason string) error
+	DeletePublishedOutboxEvents(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// outboxRepository implements the OutboxRepository interface.
+type outboxRepository struct {
+	db *sql.DB
+}
+
+// NewOutboxRepository creates a new instance of outboxRepository.
+func NewOutboxRepository(db *sql.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// ClaimOutboxEvents claims up to limit pending events, oldest first, and hides them from other
+// claimers for the lease duration. Events that are neither published nor failed before the
+// lease expires become available again, which gives at-least-once delivery if a relay crashes.
+// Rows locked by a concurrent claimer are skipped rather than waited on.
+func (r *outboxRepository) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error) {
+	query := `
+        UPDATE outbox
+        SET attempts = attempts + 1,
+            available_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 millisecond'
+        WHERE id IN (
+            SELECT id
+            FROM outbox
+            WHERE published_at IS NULL AND available_at <= CURRENT_TIMESTAMP
+            ORDER BY id
+            LIMIT $1
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING id, event_type, payload, attempts, created_at`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, wrapError("failed to claim outbox events", err)
+	}
+	defer rows.Close()
+
+	var events []OutboxEvent
+	for rows.Next() {
+		var event OutboxEvent
+		if err := rows.Scan(&event.ID, &event.EventType, &event.Payload, &event.Attempts, &event.CreatedAt); err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	// RETURNING does not preserve the order of the subquery
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	return events, nil
+}
+
+// MarkOutboxEventPublished records that an event was published so it is never claimed again.
+func (r *outboxRepository) MarkOutboxEventPublished(ctx context.Context, id int64) error {
+	query := "UPDATE outbox SET published_at = CURRENT_TIMESTAMP, last_error = NULL WHERE id = $1"
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return wrapError("failed to mark outbox event published", err)
+	}
+	return nil
+}
+
+// MarkOutboxEventFailed records a failed publish and makes the event available again after retryIn.
+func (r *outboxRepository) MarkOutboxEventFailed(ctx context.Context, id int64, retryIn time.Duration, reason string) error {
+	query := `
+        UPDATE outbox
+        SET available_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 millisecond', last_error = $3
+        WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id, retryIn.Milliseconds(), reason)
+	if err != nil {
+		return wrapError("failed to mark outbox event failed", err)
+	}
+	return nil
+}
+
+// DeletePublishedOutboxEvents removes the events published more than retention ago and returns
+// how many were removed. Pending and failed events are kept however old they are.
+func (r *outboxRepository) DeletePublishedOutboxEvents(ctx context.Context, retention time.Duration) (int64, error) {
+	query := "DELETE FROM outbox WHERE published_at <= CURRENT_TIMESTAMP - $1 * INTERVAL '1 millisecond'"
+	result, err := r.db.ExecContext(ctx, query, retention.Milliseconds())
+	if err != nil {
+		return 0, wrapError("failed to delete published outbox events", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, wrapError("failed to delete published outbox events", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy controls how WithTx retries transactions that fail with a retryable error.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of times the transaction is run, including the first.
+	MaxAttempts int
+	// BaseDelay is the upper bound of the delay before the first retry. It doubles for every
+	// further retry, and the actual delay is picked at random below the bound.
+	BaseDelay time.Duration
+	// MaxDelay caps the bound on the delay between retries.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy used by the repositories created with this package.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   10 * time.Millisecond,
+	MaxDelay:    500 * time.Millisecond,
+}
+
+// WithTx runs fn in a transaction on db and commits it if fn succeeds.
+//
+// When fn, BEGIN or COMMIT fails with a retryable error (see IsRetryable), the transaction is
+// rolled back and fn runs again in a new one, after a jittered exponential backoff, until it
+// succeeds or the policy runs out of attempts. fn must therefore be safe to run more than
+// once and should not have side effects outside the transaction. Retries stop early when ctx
+// is done or when its deadline would pass before the next attempt starts; the error of the
+// last attempt is returned in that case.
+//
+// A COMMIT that fails because the connection was lost is not retried, since the transaction
+// may have been committed before the connection dropped.
+func WithTx(ctx context.Context, db *sql.DB, policy RetryPolicy, opts *sql.TxOptions, fn func(tx *sql.Tx) error) error {
+	for attempt := 1; ; attempt++ {
+		retryable, err := runTx(ctx, db, opts, fn)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= policy.MaxAttempts {
+			return err
+		}
+		if !sleepBeforeRetry(ctx, retryDelay(attempt, policy)) {
+			return err
+		}
+	}
+}
+
+// runTx runs fn in a single transaction and reports whether a failure can be retried.
+func runTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(tx *sql.Tx) error) (bool, error) {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return IsRetryable(err), wrapError("failed to begin transaction", err)
+	}
+	defer func() {
+		// Release the connection even if fn panics
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return IsRetryable(err), err
+	}
+
+	if err := tx.Commit(); err != nil {
+		err = wrapError("failed to commit transaction", err)
+		return errors.Is(err, ErrAborted), err
+	}
+	return false, nil
+}
+
+// IsRetryable reports whether err was caused by a serialization failure, a deadlock or a
+// lost connection, after which the whole transaction can be run again.
+func IsRetryable(err error) bool {
+	if errors.Is(err, ErrAborted) || errors.Is(err, ErrUnavailable) {
+		return true
+	}
+	kind := classifyError(err)
+	return kind == ErrAborted || kind == ErrUnavailable
+}
+
+// retryDelay picks the delay before the given retry using exponential backoff with full jitter.
+func retryDelay(attempt int, policy RetryPolicy) time.Duration {
+	bound := policy.BaseDelay
+	for i := 1; i < attempt && bound < policy.MaxDelay; i++ {
+		bound *= 2
+	}
+	bound = min(bound, policy.MaxDelay)
+	if bound <= 0 {
+		return 0
+	}
+	return rand.N(bound)
+}
+
+// sleepBeforeRetry waits for delay and reports whether the caller should retry. It returns
+// false without waiting when ctx is done or its deadline would pass during the wait.
+func sleepBeforeRetry(ctx context.Context, delay time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+		return false
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRetryPolicy retries quickly so the tests do not sleep.
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+func TestWithTx_RetriesRetryableErrors(t *testing.T) {
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec("INSERT INTO likes").WillReturnError(&pq.Error{Code: "40P01"})
+	dbMock.ExpectRollback()
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec("INSERT INTO likes").WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit().WillReturnError(&pq.Error{Code: "40001"})
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec("INSERT INTO likes").WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	attempts := 0
+	err = WithTx(context.Background(), db, testRetryPolicy, nil, func(tx *sql.Tx) error {
+		attempts++
+		_, err := tx.Exec("INSERT INTO likes")
+		return err
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestWithTx_DoesNotRetryOtherErrors(t *testing.T) {
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	rejected := errors.New("rejected")
+	dbMock.ExpectBegin()
+	dbMock.ExpectRollback()
+
+	attempts := 0
+	err = WithTx(context.Background(), db, testRetryPolicy, nil, func(tx *sql.Tx) error {
+		attempts++
+		return rejected
+	})
+
+	assert.Same(t, rejected, err)
+	assert.Equal(t, 1, attempts)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestWithTx_RollsBackWhenFnPanics(t *testing.T) {
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectRollback()
+
+	assert.PanicsWithValue(t, "boom", func() {
+		WithTx(context.Background(), db, testRetryPolicy, nil, func(tx *sql.Tx) error {
+			panic("boom")
+		})
+	})
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestWithTx_GivesUpAfterMaxAttempts(t *testing.T) {
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	for i := 0; i < testRetryPolicy.MaxAttempts; i++ {
+		dbMock.ExpectBegin()
+		dbMock.ExpectRollback()
+	}
+
+	attempts := 0
+	err = WithTx(context.Background(), db, testRetryPolicy, nil, func(tx *sql.Tx) error {
+		attempts++
+		return wrapError("failed to insert like", &pq.Error{Code: "40001"})
+	})
+
+	assert.ErrorIs(t, err, ErrAborted)
+	assert.Equal(t, testRetryPolicy.MaxAttempts, attempts)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestWithTx_DoesNotRetryCommitOnLostConnection(t *testing.T) {
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectCommit().WillReturnError(driver.ErrBadConn)
+
+	attempts := 0
+	err = WithTx(context.Background(), db, testRetryPolicy, nil, func(tx *sql.Tx) error {
+		attempts++
+		return nil
+	})
+
+	assert.ErrorIs(t, err, ErrUnavailable)
+	assert.Equal(t, 1, attempts)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestWithTx_StopsRetryingPastDeadline(t *testing.T) {
+	db, dbMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectRollback()
+
+	// The deadline is closer than the shortest backoff, so there is no time left to retry
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	attempts := 0
+	start := time.Now()
+	err = WithTx(ctx, db, policy, nil, func(tx *sql.Tx) error {
+		attempts++
+		return wrapError("failed to insert like", &pq.Error{Code: "40P01"})
+	})
+
+	assert.ErrorIs(t, err, ErrAborted)
+	assert.Equal(t, 1, attempts)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.NoError(t, dbMock.ExpectationsWereMet())
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(&pq.Error{Code: "40001"}))
+	assert.True(t, IsRetryable(wrapError("failed to insert like", &pq.Error{Code: "40P01"})))
+	assert.True(t, IsRetryable(driver.ErrBadConn))
+	assert.False(t, IsRetryable(&pq.Error{Code: "23505"}))
+	assert.False(t, IsRetryable(context.DeadlineExceeded))
+	assert.False(t, IsRetryable(errors.New("boom")))
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+
+	for attempt, bound := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 3: 40 * time.Millisecond, 8: 50 * time.Millisecond} {
+		for i := 0; i < 100; i++ {
+			delay := retryDelay(attempt, policy)
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.Less(t, delay, bound)
+		}
+	}
+}
+
+func TestSQLTx_RejectsForeignTransactions(t *testing.T) {
+	assert.Panics(t, func() { sqlTx(&MockTx{}) })
+}
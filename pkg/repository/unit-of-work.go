@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Tx is a transaction in progress. Repository methods that take a Tx run their reads and writes
+// inside it, and each repository only accepts the transactions opened by its own UnitOfWork.
+// Transactions run with UnitOfWork.WithTx are committed or rolled back by WithTx, so fn must
+// not call Commit or Rollback itself.
+type Tx interface {
+	Commit() error
+	Rollback() error
+}
+
+// UnitOfWork runs a group of repository calls atomically, without tying the caller to a storage.
+type UnitOfWork interface {
+	// WithTx runs fn in a new transaction, committing it if fn returns nil and rolling it back
+	// otherwise. The transaction may be run again when the storage reports a transient failure,
+	// so fn must be safe to run more than once.
+	WithTx(ctx context.Context, fn func(tx Tx) error) error
+}
+
+// sqlTx returns the database transaction behind tx. Passing a transaction opened by another
+// storage is a programming error, so it panics rather than returning an error.
+func sqlTx(tx Tx) *sql.Tx {
+	transaction, ok := tx.(*sql.Tx)
+	if !ok {
+		panic(fmt.Sprintf("repository: %T is not a database transaction", tx))
+	}
+	return transaction
+}
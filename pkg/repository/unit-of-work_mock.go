@@ -0,0 +1,17 @@
+package repository
+
+// MockTx is a Tx for tests using the repository mocks. It records how the transaction ended.
+type MockTx struct {
+	Committed  bool
+	RolledBack bool
+}
+
+func (tx *MockTx) Commit() error {
+	tx.Committed = true
+	return nil
+}
+
+func (tx *MockTx) Rollback() error {
+	tx.RolledBack = true
+	return nil
+}
@@ -0,0 +1,437 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	explore "muzz-backend-challenge/pkg/proto"
+	"slices"
+	"strings"
+	"time"
+)
+
+// birthdateLayout is the format of birthdates in profiles, matching the proto User message.
+const birthdateLayout = time.DateOnly
+
+// UserProfile holds the fields of a user that are chosen by the user.
+type UserProfile struct {
+	Username    string
+	DisplayName string
+	// Birthdate is formatted as YYYY-MM-DD, or empty when unknown.
+	Birthdate string
+	Gender    explore.Gender
+	Bio       string
+}
+
+// UserUpdate holds the profile fields to change. Nil fields are left as they are, and an
+// empty Birthdate clears it.
+type UserUpdate struct {
+	Username    *string
+	DisplayName *string
+	Birthdate   *string
+	Gender      *explore.Gender
+	Bio         *string
+}
+
+// UserData is everything ExportUserData returns about a user. Lists are oldest first.
+type UserData struct {
+	User          *explore.User
+	LikesGiven    []UserDataLike
+	LikesReceived []UserDataLike
+	Decisions     []UserDataDecision
+	Matches       []UserDataMatch
+}
+
+// UserDataLike is a like given to or received from another user.
+type UserDataLike struct {
+	UserID    string    `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserDataDecision is the user's current decision on another user.
+type UserDataDecision struct {
+	UserID    string    `json:"user_id"`
+	Liked     bool      `json:"liked"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserDataMatch is another user who likes the user back. MatchedAt is the later of the two likes.
+type UserDataMatch struct {
+	UserID    string    `json:"user_id"`
+	MatchedAt time.Time `json:"matched_at"`
+}
+
+// UserRepository defines methods for managing users and their profiles.
+type UserRepository interface {
+	CreateUser(ctx context.Context, profile UserProfile) (*explore.User, error)
+	GetUser(ctx context.Context, userID string) (*explore.User, error)
+	UpdateUser(ctx context.Context, userID string, update UserUpdate) (*explore.User, error)
+	DeleteUser(ctx context.Context, userID string) error
+	DeleteUserData(ctx context.Context, userID string) ([]string, error)
+	ExportUserData(ctx context.Context, userID string) (*UserData, error)
+}
+
+// userRepository implements the UserRepository interface.
+type userRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository creates a new instance of userRepository.
+func NewUserRepository(db *sql.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+// userColumns are the columns read by scanUser, in order.
+const userColumns = "user_id, username, display_name, birthdate, gender, bio, created_at"
+
+// CreateUser creates a user with a new ID. A username that is already taken fails with ErrConflict.
+func (r *userRepository) CreateUser(ctx context.Context, profile UserProfile) (*explore.User, error) {
+	query := `
+        INSERT INTO users (username, display_name, birthdate, gender, bio)
+        VALUES ($1, $2, NULLIF($3, '')::date, NULLIF($4, ''), $5)
+        RETURNING ` + userColumns
+
+	row := r.db.QueryRowContext(ctx, query,
+		profile.Username, profile.DisplayName, profile.Birthdate, genderColumn(profile.Gender), profile.Bio)
+	user, err := scanUser(row)
+	if err != nil {
+		return nil, wrapError("failed to create user", err)
+	}
+	return user, nil
+}
+
+// GetUser retrieves a user by ID, or fails with ErrNotFound.
+func (r *userRepository) GetUser(ctx context.Context, userID string) (*explore.User, error) {
+	query := "SELECT " + userColumns + " FROM users WHERE user_id = $1"
+
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, userID))
+	if err != nil {
+		return nil, wrapError("failed to get user", err)
+	}
+	return user, nil
+}
+
+// UpdateUser changes the fields of the update that are set and returns the updated user. It
+// fails with ErrNotFound if the user does not exist and with ErrConflict if the new username is
+// already taken.
+func (r *userRepository) UpdateUser(ctx context.Context, userID string, update UserUpdate) (*explore.User, error) {
+	query := `
+        UPDATE users
+        SET username = COALESCE($2, username),
+            display_name = COALESCE($3, display_name),
+            birthdate = CASE WHEN $4::text IS NULL THEN birthdate ELSE NULLIF($4::text, '')::date END,
+            gender = CASE WHEN $5::text IS NULL THEN gender ELSE NULLIF($5::text, '') END,
+            bio = COALESCE($6, bio)
+        WHERE user_id = $1
+        RETURNING ` + userColumns
+
+	var gender *string
+	if update.Gender != nil {
+		column := genderColumn(*update.Gender)
+		gender = &column
+	}
+
+	row := r.db.QueryRowContext(ctx, query,
+		userID, update.Username, update.DisplayName, update.Birthdate, gender, update.Bio)
+	user, err := scanUser(row)
+	if err != nil {
+		return nil, wrapError("failed to update user", err)
+	}
+	return user, nil
+}
+
+// DeleteUser deletes a user. It fails with ErrNotFound if the user does not exist and with
+// ErrInvalidReference while the user still has likes, decisions, blocks or reports. Like
+// counters that are all zero do not hold the user back and are deleted with them.
+func (r *userRepository) DeleteUser(ctx context.Context, userID string) error {
+	return WithTx(ctx, r.db, DefaultRetryPolicy, nil, func(tx *sql.Tx) error {
+		query := `
+            DELETE FROM like_counters
+            WHERE user_id = $1 AND received = 0 AND new_received = 0 AND matches = 0`
+		if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+			return wrapError("failed to delete like counters", err)
+		}
+
+		result, err := tx.ExecContext(ctx, "DELETE FROM users WHERE user_id = $1", userID)
+		if err != nil {
+			return wrapError("failed to delete user", err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return wrapError("failed to delete user", err)
+		}
+		if rowsAffected == 0 {
+			return fmt.Errorf("failed to delete user: %w: user does not exist", ErrNotFound)
+		}
+		return nil
+	})
+}
+
+// payloadNamesUser matches the outbox events and webhook deliveries whose payload names the
+// user, $1, in any of the fields the events use for user IDs.
+const payloadNamesUser = "(payload->>'actor_user_id' = $1 OR payload->>'recipient_user_id' = $1 OR payload->'user_ids' ? $1)"
+
+// deleteUserDataQueries remove every row about the user, $1, except reports, which keep their
+// reason but lose the user's ID. The user's own row goes last, once nothing references it.
+var deleteUserDataQueries = []string{
+	"DELETE FROM likes WHERE actor_user_id = $1 OR recipient_user_id = $1",
+	"DELETE FROM decisions WHERE actor_user_id = $1 OR recipient_user_id = $1",
+	"DELETE FROM decision_events WHERE actor_user_id = $1 OR recipient_user_id = $1",
+	"DELETE FROM unmatches WHERE actor_user_id = $1 OR recipient_user_id = $1",
+	"DELETE FROM blocks WHERE blocker_user_id = $1 OR blocked_user_id = $1",
+	"UPDATE reports SET reporter_user_id = NULL WHERE reporter_user_id = $1",
+	"UPDATE reports SET reported_user_id = NULL WHERE reported_user_id = $1",
+	"DELETE FROM idempotency_keys WHERE actor_user_id = $1 OR recipient_user_id = $1",
+	"DELETE FROM like_counters WHERE user_id = $1",
+	"DELETE FROM webhook_deliveries WHERE " + payloadNamesUser,
+	"DELETE FROM outbox WHERE " + payloadNamesUser,
+	"DELETE FROM users WHERE user_id = $1",
+}
+
+// DeleteUserData deletes a user together with their likes, decisions, unmatches, blocks,
+// idempotency keys and the outbox events and webhook deliveries naming them in one transaction,
+// and removes their ID from the reports they filed or received. The like counters of the users
+// they liked or were liked by are updated to match. It returns the users they liked, whose
+// likers changed, and fails with ErrNotFound if the user does not exist.
+//
+// Locking the user's row first stops other transactions from adding rows that reference the
+// user until this one ends, so nothing is left behind. A writer that already holds the lock
+// on a pair with the user can deadlock with it; Postgres aborts one of them and it is retried.
+func (r *userRepository) DeleteUserData(ctx context.Context, userID string) ([]string, error) {
+	var likedUserIDs []string
+	err := WithTx(ctx, r.db, DefaultRetryPolicy, nil, func(tx *sql.Tx) error {
+		var locked string
+		err := tx.QueryRowContext(ctx, "SELECT user_id FROM users WHERE user_id = $1 FOR UPDATE", userID).Scan(&locked)
+		if err != nil {
+			return wrapError("failed to lock user", err)
+		}
+
+		pairs, err := likedPairs(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		likedUserIDs = likedBy(pairs, userID)
+
+		return withLikeCounters(ctx, tx, pairs, func() error {
+			for _, query := range deleteUserDataQueries {
+				if _, err := tx.ExecContext(ctx, query, userID); err != nil {
+					return wrapError("failed to delete user data", err)
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return likedUserIDs, nil
+}
+
+// likedBy returns the recipients of the pairs the user is the actor of.
+func likedBy(pairs []UserPair, userID string) []string {
+	var recipientUserIDs []string
+	for _, pair := range pairs {
+		if pair.ActorUserID == userID {
+			recipientUserIDs = append(recipientUserIDs, pair.RecipientUserID)
+		}
+	}
+	return recipientUserIDs
+}
+
+// likedPairs returns the pairs of the likes given or received by the user.
+func likedPairs(ctx context.Context, tx *sql.Tx, userID string) ([]UserPair, error) {
+	query := "SELECT actor_user_id, recipient_user_id FROM likes WHERE actor_user_id = $1 OR recipient_user_id = $1"
+
+	rows, err := tx.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, wrapError("failed to execute query", err)
+	}
+	defer rows.Close()
+
+	var pairs []UserPair
+	for rows.Next() {
+		var pair UserPair
+		if err := rows.Scan(&pair.ActorUserID, &pair.RecipientUserID); err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+		pairs = append(pairs, pair)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	return pairs, nil
+}
+
+// ExportUserData retrieves the user's profile, the likes they gave and received, their
+// decisions and their matches, all from the same snapshot. Hidden likes are included, since
+// they are still stored. It fails with ErrNotFound if the user does not exist.
+func (r *userRepository) ExportUserData(ctx context.Context, userID string) (*UserData, error) {
+	var data *UserData
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	err := WithTx(ctx, r.db, DefaultRetryPolicy, opts, func(tx *sql.Tx) error {
+		user, err := scanUser(tx.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE user_id = $1", userID))
+		if err != nil {
+			return wrapError("failed to get user", err)
+		}
+		data = &UserData{User: user}
+
+		data.LikesGiven, err = queryUserDataLikes(ctx, tx, `
+            SELECT recipient_user_id, created_at
+            FROM likes
+            WHERE actor_user_id = $1
+            ORDER BY created_at, recipient_user_id`, userID)
+		if err != nil {
+			return err
+		}
+
+		data.LikesReceived, err = queryUserDataLikes(ctx, tx, `
+            SELECT actor_user_id, created_at
+            FROM likes
+            WHERE recipient_user_id = $1
+            ORDER BY created_at, actor_user_id`, userID)
+		if err != nil {
+			return err
+		}
+
+		data.Decisions, err = queryUserDataDecisions(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+
+		data.Matches = matchesOf(data.LikesGiven, data.LikesReceived)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// queryUserDataLikes runs a query returning (user_id, created_at) rows of likes.
+func queryUserDataLikes(ctx context.Context, tx *sql.Tx, query, userID string) ([]UserDataLike, error) {
+	rows, err := tx.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, wrapError("failed to execute query", err)
+	}
+	defer rows.Close()
+
+	var likes []UserDataLike
+	for rows.Next() {
+		var like UserDataLike
+		var createdAt sql.NullTime
+		if err := rows.Scan(&like.UserID, &createdAt); err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+		like.CreatedAt = createdAt.Time
+		likes = append(likes, like)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	return likes, nil
+}
+
+// queryUserDataDecisions retrieves the user's current decisions, oldest first.
+func queryUserDataDecisions(ctx context.Context, tx *sql.Tx, userID string) ([]UserDataDecision, error) {
+	query := `
+        SELECT recipient_user_id, liked_recipient, created_at, updated_at
+        FROM decisions
+        WHERE actor_user_id = $1
+        ORDER BY created_at, recipient_user_id`
+
+	rows, err := tx.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, wrapError("failed to execute query", err)
+	}
+	defer rows.Close()
+
+	var decisions []UserDataDecision
+	for rows.Next() {
+		var decision UserDataDecision
+		var createdAt sql.NullTime
+		if err := rows.Scan(&decision.UserID, &decision.Liked, &createdAt, &decision.UpdatedAt); err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+		decision.CreatedAt = createdAt.Time
+		decisions = append(decisions, decision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	return decisions, nil
+}
+
+// matchesOf pairs up the likes a user gave with the likes they received from the same users,
+// oldest match first.
+func matchesOf(given, received []UserDataLike) []UserDataMatch {
+	receivedAt := make(map[string]time.Time, len(received))
+	for _, like := range received {
+		receivedAt[like.UserID] = like.CreatedAt
+	}
+
+	var matches []UserDataMatch
+	for _, like := range given {
+		likedBackAt, ok := receivedAt[like.UserID]
+		if !ok {
+			continue
+		}
+		matches = append(matches, UserDataMatch{UserID: like.UserID, MatchedAt: maxTime(like.CreatedAt, likedBackAt)})
+	}
+
+	slices.SortFunc(matches, func(a, b UserDataMatch) int {
+		if c := a.MatchedAt.Compare(b.MatchedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.UserID, b.UserID)
+	})
+	return matches
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// scanUser reads a row of userColumns.
+func scanUser(row interface{ Scan(...any) error }) (*explore.User, error) {
+	var user explore.User
+	var birthdate, createdAt sql.NullTime
+	var gender sql.NullString
+	if err := row.Scan(&user.UserId, &user.Username, &user.DisplayName, &birthdate, &gender, &user.Bio, &createdAt); err != nil {
+		return nil, err
+	}
+
+	if birthdate.Valid {
+		user.Birthdate = birthdate.Time.Format(birthdateLayout)
+	}
+	user.Gender = genderFromColumn(gender.String)
+	if createdAt.Valid {
+		user.UnixTimestamp = uint64(createdAt.Time.Unix())
+	}
+	return &user, nil
+}
+
+// genderColumn converts a gender to its value in the gender column, such as "non_binary" for
+// GENDER_NON_BINARY. An unspecified gender is stored as NULL, which is an empty string here.
+func genderColumn(gender explore.Gender) string {
+	if gender == explore.Gender_GENDER_UNSPECIFIED {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(gender.String(), "GENDER_"))
+}
+
+// genderFromColumn is the inverse of genderColumn.
+func genderFromColumn(column string) explore.Gender {
+	return explore.Gender(explore.Gender_value["GENDER_"+strings.ToUpper(column)])
+}
@@ -0,0 +1,295 @@
+package repository_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+// userConformanceStore is a UserRepository under test, together with the ExploreRepository of
+// the same storage.
+type userConformanceStore struct {
+	users   repository.UserRepository
+	explore repository.ExploreRepository
+}
+
+// runUserRepositoryConformance checks the behaviour every UserRepository implementation must
+// share. Usernames are random so the subtests do not clash with each other or existing users.
+func runUserRepositoryConformance(t *testing.T, newStore func(t *testing.T) userConformanceStore) {
+	ctx := context.Background()
+
+	username := func() string { return "user-" + uuid.NewString() }
+
+	// create creates a user, deleting it again when the test ends if it still can be
+	create := func(t *testing.T, store userConformanceStore, profile repository.UserProfile) *explore.User {
+		user, err := store.users.CreateUser(ctx, profile)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = store.users.DeleteUser(ctx, user.UserId) })
+		return user
+	}
+
+	t.Run("created users can be read back", func(t *testing.T) {
+		store := newStore(t)
+		profile := repository.UserProfile{
+			Username:    username(),
+			DisplayName: "Alice",
+			Birthdate:   "1990-04-01",
+			Gender:      explore.Gender_GENDER_NON_BINARY,
+			Bio:         "Likes long walks",
+		}
+
+		created := create(t, store, profile)
+		require.NoError(t, uuid.Validate(created.UserId))
+		assert.Equal(t, profile.Username, created.Username)
+		assert.Equal(t, "Alice", created.DisplayName)
+		assert.Equal(t, "1990-04-01", created.Birthdate)
+		assert.Equal(t, explore.Gender_GENDER_NON_BINARY, created.Gender)
+		assert.Equal(t, "Likes long walks", created.Bio)
+		assert.WithinDuration(t, time.Now(), time.Unix(int64(created.UnixTimestamp), 0), time.Minute)
+
+		read, err := store.users.GetUser(ctx, created.UserId)
+		require.NoError(t, err)
+		assert.Equal(t, created, read)
+
+		bare := create(t, store, repository.UserProfile{Username: username()})
+		assert.Empty(t, bare.Birthdate)
+		assert.Equal(t, explore.Gender_GENDER_UNSPECIFIED, bare.Gender)
+	})
+
+	t.Run("usernames are unique", func(t *testing.T) {
+		store := newStore(t)
+		first := create(t, store, repository.UserProfile{Username: username()})
+		second := create(t, store, repository.UserProfile{Username: username()})
+
+		_, err := store.users.CreateUser(ctx, repository.UserProfile{Username: first.Username})
+		assert.ErrorIs(t, err, repository.ErrConflict)
+
+		_, err = store.users.UpdateUser(ctx, second.UserId, repository.UserUpdate{Username: &first.Username})
+		assert.ErrorIs(t, err, repository.ErrConflict)
+
+		// Keeping one's own username is not a conflict
+		updated, err := store.users.UpdateUser(ctx, first.UserId, repository.UserUpdate{Username: &first.Username})
+		require.NoError(t, err)
+		assert.Equal(t, first.Username, updated.Username)
+	})
+
+	t.Run("updates only change the fields that are set", func(t *testing.T) {
+		store := newStore(t)
+		created := create(t, store, repository.UserProfile{
+			Username:    username(),
+			DisplayName: "Bob",
+			Birthdate:   "1985-12-31",
+			Gender:      explore.Gender_GENDER_MALE,
+			Bio:         "Hello",
+		})
+
+		bio := "Updated"
+		updated, err := store.users.UpdateUser(ctx, created.UserId, repository.UserUpdate{Bio: &bio})
+		require.NoError(t, err)
+		assert.Equal(t, "Bob", updated.DisplayName)
+		assert.Equal(t, "1985-12-31", updated.Birthdate)
+		assert.Equal(t, explore.Gender_GENDER_MALE, updated.Gender)
+		assert.Equal(t, "Updated", updated.Bio)
+
+		empty := ""
+		unspecified := explore.Gender_GENDER_UNSPECIFIED
+		cleared, err := store.users.UpdateUser(ctx, created.UserId, repository.UserUpdate{Birthdate: &empty, Gender: &unspecified})
+		require.NoError(t, err)
+		assert.Empty(t, cleared.Birthdate)
+		assert.Equal(t, explore.Gender_GENDER_UNSPECIFIED, cleared.Gender)
+		assert.Equal(t, created.UnixTimestamp, cleared.UnixTimestamp)
+
+		read, err := store.users.GetUser(ctx, created.UserId)
+		require.NoError(t, err)
+		assert.Equal(t, cleared, read)
+	})
+
+	t.Run("unknown users are not found", func(t *testing.T) {
+		store := newStore(t)
+		unknown := uuid.NewString()
+
+		_, err := store.users.GetUser(ctx, unknown)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		bio := "bio"
+		_, err = store.users.UpdateUser(ctx, unknown, repository.UserUpdate{Bio: &bio})
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		assert.ErrorIs(t, store.users.DeleteUser(ctx, unknown), repository.ErrNotFound)
+	})
+
+	t.Run("users with likes cannot be deleted", func(t *testing.T) {
+		store := newStore(t)
+		actor := create(t, store, repository.UserProfile{Username: username()})
+		recipient := create(t, store, repository.UserProfile{Username: username()})
+
+		require.NoError(t, store.explore.WithTx(ctx, func(tx repository.Tx) error {
+			return store.explore.InsertLike(ctx, tx, actor.UserId, recipient.UserId)
+		}))
+		assert.ErrorIs(t, store.users.DeleteUser(ctx, actor.UserId), repository.ErrInvalidReference)
+		assert.ErrorIs(t, store.users.DeleteUser(ctx, recipient.UserId), repository.ErrInvalidReference)
+
+		// Once the like is gone, nothing holds the users back
+		require.NoError(t, store.explore.WithTx(ctx, func(tx repository.Tx) error {
+			return store.explore.DeleteLike(ctx, tx, actor.UserId, recipient.UserId)
+		}))
+		require.NoError(t, store.users.DeleteUser(ctx, recipient.UserId))
+		require.NoError(t, store.users.DeleteUser(ctx, actor.UserId))
+
+		_, err := store.users.GetUser(ctx, recipient.UserId)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+	t.Run("deleting user data removes everything that references the user", func(t *testing.T) {
+		store := newStore(t)
+		deleted := create(t, store, repository.UserProfile{Username: username()})
+		match := create(t, store, repository.UserProfile{Username: username()})
+		liker := create(t, store, repository.UserProfile{Username: username()})
+		blocker := create(t, store, repository.UserProfile{Username: username()})
+
+		require.NoError(t, store.explore.WithTx(ctx, func(tx repository.Tx) error {
+			for _, like := range []struct{ actor, recipient string }{
+				{deleted.UserId, match.UserId},
+				{match.UserId, deleted.UserId},
+				{liker.UserId, deleted.UserId},
+				{liker.UserId, match.UserId},
+			} {
+				if err := store.explore.InsertDecision(ctx, tx, like.actor, like.recipient, true); err != nil {
+					return err
+				}
+				if err := store.explore.InsertLike(ctx, tx, like.actor, like.recipient); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+		require.NoError(t, store.explore.BlockUser(ctx, blocker.UserId, deleted.UserId))
+
+		// The users the deleted user liked are returned, since their likers changed
+		likedUserIDs, err := store.users.DeleteUserData(ctx, deleted.UserId)
+		require.NoError(t, err)
+		assert.Equal(t, []string{match.UserId}, likedUserIDs)
+
+		_, err = store.users.GetUser(ctx, deleted.UserId)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+
+		// Only the like between the remaining users is still counted
+		likes, err := store.explore.CountLikes(ctx, match.UserId)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), likes)
+		matches, err := store.explore.CountMatches(ctx, match.UserId)
+		require.NoError(t, err)
+		assert.Zero(t, matches)
+
+		// Nothing references the deleted user any more, so the blocker has nothing left either
+		require.NoError(t, store.users.DeleteUser(ctx, blocker.UserId))
+		_, err = store.users.DeleteUserData(ctx, liker.UserId)
+		require.NoError(t, err)
+
+		_, err = store.users.DeleteUserData(ctx, deleted.UserId)
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("exported user data lists likes, decisions and matches oldest first", func(t *testing.T) {
+		store := newStore(t)
+		user := create(t, store, repository.UserProfile{Username: username(), Bio: "Exported"})
+		match := create(t, store, repository.UserProfile{Username: username()})
+		liked := create(t, store, repository.UserProfile{Username: username()})
+		passed := create(t, store, repository.UserProfile{Username: username()})
+		t.Cleanup(func() { _, _ = store.users.DeleteUserData(ctx, user.UserId) })
+
+		decide := func(actor, recipient string, liked bool) {
+			require.NoError(t, store.explore.WithTx(ctx, func(tx repository.Tx) error {
+				if err := store.explore.InsertDecision(ctx, tx, actor, recipient, liked); err != nil {
+					return err
+				}
+				if !liked {
+					return nil
+				}
+				return store.explore.InsertLike(ctx, tx, actor, recipient)
+			}))
+		}
+		decide(user.UserId, match.UserId, true)
+		decide(user.UserId, liked.UserId, true)
+		decide(user.UserId, passed.UserId, false)
+		decide(match.UserId, user.UserId, true)
+
+		data, err := store.users.ExportUserData(ctx, user.UserId)
+		require.NoError(t, err)
+		assert.Equal(t, user, data.User)
+
+		likesGiven := make([]string, 0, len(data.LikesGiven))
+		for _, like := range data.LikesGiven {
+			likesGiven = append(likesGiven, like.UserID)
+		}
+		assert.ElementsMatch(t, []string{match.UserId, liked.UserId}, likesGiven)
+		assert.True(t, slices.IsSortedFunc(data.LikesGiven, func(a, b repository.UserDataLike) int {
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}))
+
+		require.Len(t, data.LikesReceived, 1)
+		assert.Equal(t, match.UserId, data.LikesReceived[0].UserID)
+
+		decisions := make(map[string]bool, len(data.Decisions))
+		for _, decision := range data.Decisions {
+			decisions[decision.UserID] = decision.Liked
+		}
+		assert.Equal(t, map[string]bool{match.UserId: true, liked.UserId: true, passed.UserId: false}, decisions)
+
+		require.Len(t, data.Matches, 1)
+		assert.Equal(t, match.UserId, data.Matches[0].UserID)
+		assert.Equal(t, data.LikesReceived[0].CreatedAt, data.Matches[0].MatchedAt)
+
+		_, err = store.users.ExportUserData(ctx, uuid.NewString())
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+	t.Run("likers come with their profile when asked", func(t *testing.T) {
+		store := newStore(t)
+		recipient := create(t, store, repository.UserProfile{Username: username()})
+		liker := create(t, store, repository.UserProfile{
+			Username:    username(),
+			DisplayName: "Carol",
+			Birthdate:   "1992-07-15",
+			Gender:      explore.Gender_GENDER_FEMALE,
+			Bio:         "Hi there",
+		})
+		t.Cleanup(func() { _, _ = store.users.DeleteUserData(ctx, liker.UserId) })
+
+		require.NoError(t, store.explore.WithTx(ctx, func(tx repository.Tx) error {
+			if err := store.explore.InsertDecision(ctx, tx, liker.UserId, recipient.UserId, true); err != nil {
+				return err
+			}
+			return store.explore.InsertLike(ctx, tx, liker.UserId, recipient.UserId)
+		}))
+
+		expected := &explore.LikerProfile{
+			Username:      liker.Username,
+			DisplayName:   "Carol",
+			Birthdate:     "1992-07-15",
+			Gender:        explore.Gender_GENDER_FEMALE,
+			Bio:           "Hi there",
+			UnixTimestamp: liker.UnixTimestamp,
+		}
+		for name, list := range map[string]func(ctx context.Context, recipientUserID string, includeProfile bool, limit int, after *repository.Cursor) ([]repository.LikerRow, error){
+			"liked you":     store.explore.GetLikedYou,
+			"new liked you": store.explore.GetNewLikedYou,
+		} {
+			likers, err := list(ctx, recipient.UserId, true, 10, nil)
+			require.NoError(t, err, name)
+			require.Len(t, likers, 1, name)
+			assert.Equal(t, liker.UserId, likers[0].Liker.ActorId, name)
+			assert.Equal(t, expected, likers[0].Liker.Profile, name)
+
+			likers, err = list(ctx, recipient.UserId, false, 10, nil)
+			require.NoError(t, err, name)
+			require.Len(t, likers, 1, name)
+			assert.Nil(t, likers[0].Liker.Profile, name)
+		}
+	})
+}
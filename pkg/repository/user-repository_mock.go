@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	explore "muzz-backend-challenge/pkg/proto"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) CreateUser(ctx context.Context, profile UserProfile) (*explore.User, error) {
+	args := m.Called(ctx, profile)
+	user, _ := args.Get(0).(*explore.User)
+	return user, args.Error(1)
+}
+
+func (m *MockUserRepository) GetUser(ctx context.Context, userID string) (*explore.User, error) {
+	args := m.Called(ctx, userID)
+	user, _ := args.Get(0).(*explore.User)
+	return user, args.Error(1)
+}
+
+func (m *MockUserRepository) UpdateUser(ctx context.Context, userID string, update UserUpdate) (*explore.User, error) {
+	args := m.Called(ctx, userID, update)
+	user, _ := args.Get(0).(*explore.User)
+	return user, args.Error(1)
+}
+
+func (m *MockUserRepository) DeleteUser(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) DeleteUserData(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	likedUserIDs, _ := args.Get(0).([]string)
+	return likedUserIDs, args.Error(1)
+}
+
+func (m *MockUserRepository) ExportUserData(ctx context.Context, userID string) (*UserData, error) {
+	args := m.Called(ctx, userID)
+	data, _ := args.Get(0).(*UserData)
+	return data, args.Error(1)
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	explore "muzz-backend-challenge/pkg/proto"
+	"sort"
+	"time"
+)
+
+// WebhookDelivery is a pending delivery of an event to a webhook endpoint.
+type WebhookDelivery struct {
+	ID            int64
+	EndpointID    string
+	URL           string
+	Secret        string
+	OutboxEventID int64
+	EventType     string
+	Payload       []byte
+	Attempts      int
+}
+
+// WebhookRepository defines methods for managing webhook endpoints and their deliveries.
+type WebhookRepository interface {
+	CreateWebhookEndpoint(ctx context.Context, url, secret string) (*explore.WebhookEndpoint, error)
+	ListWebhookEndpoints(ctx context.Context) ([]*explore.WebhookEndpoint, error)
+	DisableWebhookEndpoint(ctx context.Context, endpointID string) error
+	EnqueueWebhookDeliveries(ctx context.Context, outboxEventID int64, eventType string, payload []byte) error
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)
+	MarkWebhookDelivered(ctx context.Context, deliveryID int64) error
+	MarkWebhookFailed(ctx context.Context, deliveryID int64, retryIn time.Duration, reason string) error
+	MarkWebhookDead(ctx context.Context, deliveryID int64, reason string) error
+}
+
+// Webhook delivery statuses.
+const (
+	webhookPending   = "pending"
+	webhookDelivered = "delivered"
+	webhookDead      = "dead"
+)
+
+// webhookRepository implements the WebhookRepository interface.
+type webhookRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository creates a new instance of webhookRepository.
+func NewWebhookRepository(db *sql.DB) WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+// CreateWebhookEndpoint registers a new, enabled endpoint.
+func (r *webhookRepository) CreateWebhookEndpoint(ctx context.Context, url, secret string) (*explore.WebhookEndpoint, error) {
+	query := `
+        INSERT INTO webhook_endpoints (url, secret)
+        VALUES ($1, $2)
+        RETURNING id, url, enabled, created_at`
+
+	endpoint, err := scanWebhookEndpoint(r.db.QueryRowContext(ctx, query, url, secret))
+	if err != nil {
+		return nil, wrapError("failed to create webhook endpoint", err)
+	}
+	return endpoint, nil
+}
+
+// ListWebhookEndpoints retrieves every registered endpoint, oldest first.
+func (r *webhookRepository) ListWebhookEndpoints(ctx context.Context) ([]*explore.WebhookEndpoint, error) {
+	query := "SELECT id, url, enabled, created_at FROM webhook_endpoints ORDER BY created_at, id"
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, wrapError("failed to execute query", err)
+	}
+	defer rows.Close()
+
+	var endpoints []*explore.WebhookEndpoint
+	for rows.Next() {
+		endpoint, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	return endpoints, nil
+}
+
+// DisableWebhookEndpoint stops deliveries to an endpoint. Deliveries already queued for it are
+// kept but not attempted. Disabling an already disabled endpoint succeeds.
+func (r *webhookRepository) DisableWebhookEndpoint(ctx context.Context, endpointID string) error {
+	query := "UPDATE webhook_endpoints SET enabled = FALSE WHERE id = $1"
+	result, err := r.db.ExecContext(ctx, query, endpointID)
+	if err != nil {
+		return wrapError("failed to disable webhook endpoint", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return wrapError("failed to disable webhook endpoint", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("failed to disable webhook endpoint: %w: endpoint does not exist", ErrNotFound)
+	}
+	return nil
+}
+
+// EnqueueWebhookDeliveries queues a delivery of the outbox event to every enabled endpoint.
+// Enqueueing the same event twice does not duplicate its deliveries.
+func (r *webhookRepository) EnqueueWebhookDeliveries(ctx context.Context, outboxEventID int64, eventType string, payload []byte) error {
+	query := `
+        INSERT INTO webhook_deliveries (endpoint_id, outbox_event_id, event_type, payload)
+        SELECT id, $1, $2, $3
+        FROM webhook_endpoints
+        WHERE enabled
+        ON CONFLICT (endpoint_id, outbox_event_id) DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, outboxEventID, eventType, payload)
+	if err != nil {
+		return wrapError("failed to enqueue webhook deliveries", err)
+	}
+	return nil
+}
+
+// ClaimWebhookDeliveries claims up to limit pending deliveries to enabled endpoints, oldest
+// first, and hides them from other claimers for the lease duration.
+func (r *webhookRepository) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	query := `
+        UPDATE webhook_deliveries AS deliveries
+        SET attempts = deliveries.attempts + 1,
+            next_attempt_at = CURRENT_TIMESTAMP + $3 * INTERVAL '1 millisecond'
+        FROM webhook_endpoints AS endpoints
+        WHERE endpoints.id = deliveries.endpoint_id
+          AND deliveries.id IN (
+            SELECT pending.id
+            FROM webhook_deliveries AS pending
+            JOIN webhook_endpoints AS enabled ON enabled.id = pending.endpoint_id AND enabled.enabled
+            WHERE pending.status = $1 AND pending.next_attempt_at <= CURRENT_TIMESTAMP
+            ORDER BY pending.id
+            LIMIT $2
+            FOR UPDATE OF pending SKIP LOCKED
+          )
+        RETURNING deliveries.id, deliveries.endpoint_id, endpoints.url, endpoints.secret,
+                  deliveries.outbox_event_id, deliveries.event_type, deliveries.payload, deliveries.attempts`
+
+	rows, err := r.db.QueryContext(ctx, query, webhookPending, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, wrapError("failed to claim webhook deliveries", err)
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		var delivery WebhookDelivery
+		err := rows.Scan(&delivery.ID, &delivery.EndpointID, &delivery.URL, &delivery.Secret,
+			&delivery.OutboxEventID, &delivery.EventType, &delivery.Payload, &delivery.Attempts)
+		if err != nil {
+			return nil, wrapError("failed to scan row", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapError("rows iteration error", err)
+	}
+
+	// RETURNING does not preserve the order of the subquery
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+
+	return deliveries, nil
+}
+
+// MarkWebhookDelivered records a successful delivery.
+func (r *webhookRepository) MarkWebhookDelivered(ctx context.Context, deliveryID int64) error {
+	query := "UPDATE webhook_deliveries SET status = $2, delivered_at = CURRENT_TIMESTAMP, last_error = NULL WHERE id = $1"
+	_, err := r.db.ExecContext(ctx, query, deliveryID, webhookDelivered)
+	if err != nil {
+		return wrapError("failed to mark webhook delivered", err)
+	}
+	return nil
+}
+
+// MarkWebhookFailed records a failed attempt and schedules the next one after retryIn.
+func (r *webhookRepository) MarkWebhookFailed(ctx context.Context, deliveryID int64, retryIn time.Duration, reason string) error {
+	query := `
+        UPDATE webhook_deliveries
+        SET next_attempt_at = CURRENT_TIMESTAMP + $2 * INTERVAL '1 millisecond', last_error = $3
+        WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, deliveryID, retryIn.Milliseconds(), reason)
+	if err != nil {
+		return wrapError("failed to mark webhook failed", err)
+	}
+	return nil
+}
+
+// MarkWebhookDead moves a delivery to the dead-letter state, where it is no longer attempted.
+func (r *webhookRepository) MarkWebhookDead(ctx context.Context, deliveryID int64, reason string) error {
+	query := "UPDATE webhook_deliveries SET status = $2, last_error = $3 WHERE id = $1"
+	_, err := r.db.ExecContext(ctx, query, deliveryID, webhookDead, reason)
+	if err != nil {
+		return wrapError("failed to mark webhook dead", err)
+	}
+	return nil
+}
+
+// scanWebhookEndpoint reads an endpoint selected as id, url, enabled, created_at.
+func scanWebhookEndpoint(row interface{ Scan(...interface{}) error }) (*explore.WebhookEndpoint, error) {
+	var endpoint explore.WebhookEndpoint
+	var createdAt time.Time
+	if err := row.Scan(&endpoint.Id, &endpoint.Url, &endpoint.Enabled, &createdAt); err != nil {
+		return nil, err
+	}
+	endpoint.UnixTimestamp = uint64(createdAt.Unix())
+	return &endpoint, nil
+}
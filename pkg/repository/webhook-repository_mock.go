@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/mock"
+	explore "muzz-backend-challenge/pkg/proto"
+	"time"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) CreateWebhookEndpoint(ctx context.Context, url, secret string) (*explore.WebhookEndpoint, error) {
+	args := m.Called(ctx, url, secret)
+	endpoint, _ := args.Get(0).(*explore.WebhookEndpoint)
+	return endpoint, args.Error(1)
+}
+
+func (m *MockWebhookRepository) ListWebhookEndpoints(ctx context.Context) ([]*explore.WebhookEndpoint, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*explore.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockWebhookRepository) DisableWebhookEndpoint(ctx context.Context, endpointID string) error {
+	args := m.Called(ctx, endpointID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) EnqueueWebhookDeliveries(ctx context.Context, outboxEventID int64, eventType string, payload []byte) error {
+	args := m.Called(ctx, outboxEventID, eventType, payload)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	args := m.Called(ctx, limit, lease)
+	return args.Get(0).([]WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) MarkWebhookDelivered(ctx context.Context, deliveryID int64) error {
+	args := m.Called(ctx, deliveryID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) MarkWebhookFailed(ctx context.Context, deliveryID int64, retryIn time.Duration, reason string) error {
+	args := m.Called(ctx, deliveryID, retryIn, reason)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) MarkWebhookDead(ctx context.Context, deliveryID int64, reason string) error {
+	args := m.Called(ctx, deliveryID, reason)
+	return args.Error(0)
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/repository"
+	"slices"
+	"time"
+)
+
+// DefaultMaxBatchDecisions is the default upper bound on the decisions sent to PutDecisions.
+const DefaultMaxBatchDecisions = 100
+
+// errBatchAborted rolls back the transaction of an all-or-nothing batch with a rejected decision.
+var errBatchAborted = errors.New("batch aborted")
+
+// decisionBatch tracks the outcome of each decision of a PutDecisions request. A decision is
+// pending until it is either rejected, replayed from its idempotency key or recorded.
+type decisionBatch struct {
+	decisions []*explore.PutDecisionRequest
+	results   []*explore.PutDecisionsResponse_Result
+}
+
+func newDecisionBatch(decisions []*explore.PutDecisionRequest) *decisionBatch {
+	return &decisionBatch{
+		decisions: decisions,
+		results:   make([]*explore.PutDecisionsResponse_Result, len(decisions)),
+	}
+}
+
+// clone returns a copy of the batch whose decisions can be settled independently.
+func (batch *decisionBatch) clone() *decisionBatch {
+	return &decisionBatch{decisions: batch.decisions, results: slices.Clone(batch.results)}
+}
+
+// reject settles a decision as not recorded because of err.
+func (batch *decisionBatch) reject(i int, err error) {
+	st := status.Convert(err)
+	batch.results[i] = &explore.PutDecisionsResponse_Result{
+		Error: &explore.PutDecisionsResponse_Error{Code: int32(st.Code()), Message: st.Message()},
+	}
+}
+
+// settle records the response of a decision that was recorded or replayed.
+func (batch *decisionBatch) settle(i int, mutualLikes bool) {
+	batch.results[i] = &explore.PutDecisionsResponse_Result{MutualLikes: mutualLikes}
+}
+
+// pending returns the indexes of the decisions that are not settled yet.
+func (batch *decisionBatch) pending() []int {
+	var indexes []int
+	for i, result := range batch.results {
+		if result == nil {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// firstRejected returns the index of the first rejected decision, or -1 if there is none.
+func (batch *decisionBatch) firstRejected() int {
+	for i, result := range batch.results {
+		if result != nil && result.Error != nil {
+			return i
+		}
+	}
+	return -1
+}
+
+// abort rejects every pending decision because decision failed was rejected.
+func (batch *decisionBatch) abort(failed int) {
+	for _, i := range batch.pending() {
+		batch.reject(i, status.Errorf(codes.Aborted, "decision not recorded because decision %d failed", failed))
+	}
+}
+
+// response builds the PutDecisions response from the settled results.
+func (batch *decisionBatch) response() *explore.PutDecisionsResponse {
+	return &explore.PutDecisionsResponse{Results: batch.results}
+}
+
+// idempotencyKeyID identifies an idempotency key, which is scoped to its actor.
+type idempotencyKeyID struct {
+	actorUserID string
+	key         string
+}
+
+// validate rejects the decisions PutDecision would reject as invalid, the decisions on a pair
+// already decided on earlier in the batch, as their order could not be honoured, and the
+// decisions reusing the idempotency key of an earlier decision of the same actor.
+func (batch *decisionBatch) validate() {
+	seen := make(map[repository.UserPair]bool, len(batch.decisions))
+	keys := make(map[idempotencyKeyID]bool)
+	for i, decision := range batch.decisions {
+		err := validateUserPair("actor user ID", decision.GetActorUserId(), "recipient user ID", decision.GetRecipientUserId())
+		if err == nil {
+			err = validateIdempotencyKey(decision.IdempotencyKey)
+		}
+		if err != nil {
+			batch.reject(i, err)
+			continue
+		}
+
+		pair := repository.UserPair{ActorUserID: decision.ActorUserId, RecipientUserID: decision.RecipientUserId}
+		if seen[pair] {
+			batch.reject(i, status.Error(codes.InvalidArgument, "decision repeats an earlier decision of the batch on the same users"))
+			continue
+		}
+
+		if decision.IdempotencyKey != nil {
+			key := idempotencyKeyID{actorUserID: decision.ActorUserId, key: *decision.IdempotencyKey}
+			if keys[key] {
+				batch.reject(i, status.Error(codes.InvalidArgument, "decision repeats the idempotency key of an earlier decision of the batch"))
+				continue
+			}
+			keys[key] = true
+		}
+		seen[pair] = true
+	}
+}
+
+// replayIdempotentDecisions reserves the idempotency keys of the pending decisions and settles
+// the decisions that were already recorded under their key. It must run after the decisions
+// that cannot be recorded are rejected, so every key it reserves is completed.
+func (service *ExploreService) replayIdempotentDecisions(ctx context.Context, tx repository.Tx, batch *decisionBatch) error {
+	for _, i := range batch.pending() {
+		decision := batch.decisions[i]
+		if decision.IdempotencyKey == nil {
+			continue
+		}
+
+		original, err := service.repository.ReserveIdempotencyKey(ctx, tx, decision.ActorUserId, *decision.IdempotencyKey,
+			decision.RecipientUserId, decision.LikedRecipient, service.idempotencyTTL)
+		if err != nil {
+			return err
+		}
+		if original == nil {
+			continue
+		}
+
+		response, err := replayDecision(decision, original)
+		if err != nil {
+			batch.reject(i, err)
+			continue
+		}
+		batch.settle(i, response.MutualLikes)
+	}
+	return nil
+}
+
+// rejectUnrecordableDecisions rejects the pending decisions involving an unknown user or a
+// blocked pair, which would otherwise fail the whole transaction or be rejected by PutDecision.
+func (service *ExploreService) rejectUnrecordableDecisions(ctx context.Context, tx repository.Tx, batch *decisionBatch) error {
+	pending := batch.pending()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	pairs := make([]repository.UserPair, len(pending))
+	var userIDs []string
+	for n, i := range pending {
+		pairs[n] = repository.UserPair{ActorUserID: batch.decisions[i].ActorUserId, RecipientUserID: batch.decisions[i].RecipientUserId}
+		userIDs = append(userIDs, pairs[n].ActorUserID, pairs[n].RecipientUserID)
+	}
+
+	existing, err := service.repository.FindExistingUsers(ctx, tx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	blocked, err := service.repository.CheckBlockedPairs(ctx, tx, pairs)
+	if err != nil {
+		return err
+	}
+
+	for n, i := range pending {
+		switch {
+		case !existing[pairs[n].ActorUserID] || !existing[pairs[n].RecipientUserID]:
+			batch.reject(i, status.Error(codes.FailedPrecondition, "decision involves an unknown user"))
+		case blocked[n]:
+			batch.reject(i, status.Error(codes.FailedPrecondition, "decision involves a blocked user"))
+		}
+	}
+	return nil
+}
+
+// recordDecisions records every pending decision with batched statements, queues their events
+// and completes their idempotency keys. It returns the recorded decisions' indexes.
+//
+// Decisions are settled in batch order: a like is mutual if the recipient likes the actor back
+// when it is recorded, counting likes recorded earlier in the batch but not later ones. Only the
+// later of two likes between the same users in a batch reports the match, as it would with one
+// PutDecision call each.
+func (service *ExploreService) recordDecisions(ctx context.Context, tx repository.Tx, batch *decisionBatch) ([]int, error) {
+	pending := batch.pending()
+	if len(pending) == 0 {
+		return nil, nil
+	}
+
+	// Decisions on a pair whose reverse was decided earlier in the batch are recorded in a second
+	// round, so the mutual likes of the first round are checked before they change its likes.
+	decisions := make([]repository.BatchDecision, len(pending))
+	seen := make(map[repository.UserPair]bool, len(pending))
+	var rounds [2][]int
+	for n, i := range pending {
+		decision := batch.decisions[i]
+		pair := repository.UserPair{ActorUserID: decision.ActorUserId, RecipientUserID: decision.RecipientUserId}
+		decisions[n] = repository.BatchDecision{UserPair: pair, LikedRecipient: decision.LikedRecipient}
+
+		round := 0
+		if seen[repository.UserPair{ActorUserID: pair.RecipientUserID, RecipientUserID: pair.ActorUserID}] {
+			round = 1
+		}
+		seen[pair] = true
+		rounds[round] = append(rounds[round], i)
+	}
+
+	if err := service.repository.InsertDecisions(ctx, tx, decisions); err != nil {
+		return nil, fmt.Errorf("failed to insert decisions: %w", err)
+	}
+
+	mutualLikes := make(map[int]bool, len(pending))
+	for _, round := range rounds {
+		if err := service.recordLikes(ctx, tx, batch, round, mutualLikes); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	var events []repository.OutboxMessage
+	for _, i := range pending {
+		decision := batch.decisions[i]
+		messages, err := decisionEvents(decision.ActorUserId, decision.RecipientUserId, decision.LikedRecipient, mutualLikes[i], now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to queue decision events: %w", err)
+		}
+		events = append(events, messages...)
+
+		if decision.IdempotencyKey != nil {
+			err := service.repository.CompleteIdempotencyKey(ctx, tx, decision.ActorUserId, *decision.IdempotencyKey, mutualLikes[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to complete idempotency key: %w", err)
+			}
+		}
+
+		batch.settle(i, mutualLikes[i])
+	}
+
+	if err := service.repository.InsertOutboxEvents(ctx, tx, events); err != nil {
+		return nil, fmt.Errorf("failed to queue decision events: %w", err)
+	}
+
+	return pending, nil
+}
+
+// recordLikes inserts the likes and deletes the passes of the decisions at indexes, then sets
+// mutualLikes for their likes. Inserting or deleting a like locks its pair of users, so no other
+// transaction changes the likes checked until this one ends.
+func (service *ExploreService) recordLikes(ctx context.Context, tx repository.Tx, batch *decisionBatch, indexes []int, mutualLikes map[int]bool) error {
+	var liked, passed []repository.UserPair
+	var likedIndexes []int
+	for _, i := range indexes {
+		decision := batch.decisions[i]
+		pair := repository.UserPair{ActorUserID: decision.ActorUserId, RecipientUserID: decision.RecipientUserId}
+		if decision.LikedRecipient {
+			liked = append(liked, pair)
+			likedIndexes = append(likedIndexes, i)
+		} else {
+			passed = append(passed, pair)
+		}
+	}
+
+	if len(passed) > 0 {
+		if err := service.repository.DeleteLikes(ctx, tx, passed); err != nil {
+			return fmt.Errorf("failed to delete likes: %w", err)
+		}
+	}
+
+	if len(liked) == 0 {
+		return nil
+	}
+
+	if err := service.repository.InsertLikes(ctx, tx, liked); err != nil {
+		return fmt.Errorf("failed to insert likes: %w", err)
+	}
+
+	mutual, err := service.repository.CheckMutualLikes(ctx, tx, liked)
+	if err != nil {
+		return fmt.Errorf("failed to check mutual likes: %w", err)
+	}
+	for n, i := range likedIndexes {
+		mutualLikes[i] = mutual[n]
+	}
+	return nil
+}
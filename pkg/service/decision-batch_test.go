@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"muzz-backend-challenge/pkg/outbox"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+const (
+	batchActor   = "00000000-0000-0000-0000-000000000001"
+	batchLiked   = "00000000-0000-0000-0000-000000000002"
+	batchPassed  = "00000000-0000-0000-0000-000000000003"
+	batchBlocked = "00000000-0000-0000-0000-000000000004"
+)
+
+func pair(actorID, recipientID string) repository.UserPair {
+	return repository.UserPair{ActorUserID: actorID, RecipientUserID: recipientID}
+}
+
+func resultCodes(response *explore.PutDecisionsResponse) []codes.Code {
+	var resultCodes []codes.Code
+	for _, result := range response.Results {
+		resultCodes = append(resultCodes, codes.Code(result.GetError().GetCode()))
+	}
+	return resultCodes
+}
+
+func TestPutDecisions(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+
+	request := &explore.PutDecisionsRequest{Decisions: []*explore.PutDecisionRequest{
+		{ActorUserId: batchActor, RecipientUserId: batchLiked, LikedRecipient: true},
+		{ActorUserId: batchActor, RecipientUserId: batchPassed, LikedRecipient: false},
+		{ActorUserId: batchActor, RecipientUserId: "not-a-uuid", LikedRecipient: true},
+		{ActorUserId: batchActor, RecipientUserId: batchBlocked, LikedRecipient: true},
+	}}
+
+	mockTx := &repository.MockTx{}
+
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("FindExistingUsers", ctx, mockTx, []string{batchActor, batchLiked, batchActor, batchPassed, batchActor, batchBlocked}).
+		Return(map[string]bool{batchActor: true, batchLiked: true, batchPassed: true, batchBlocked: true}, nil)
+	repo.On("CheckBlockedPairs", ctx, mockTx, []repository.UserPair{
+		pair(batchActor, batchLiked), pair(batchActor, batchPassed), pair(batchActor, batchBlocked),
+	}).Return([]bool{false, false, true}, nil)
+	repo.On("InsertDecisions", ctx, mockTx, []repository.BatchDecision{
+		{UserPair: pair(batchActor, batchLiked), LikedRecipient: true},
+		{UserPair: pair(batchActor, batchPassed), LikedRecipient: false},
+	}).Return(nil)
+	repo.On("InsertLikes", ctx, mockTx, []repository.UserPair{pair(batchActor, batchLiked)}).Return(nil)
+	repo.On("CheckMutualLikes", ctx, mockTx, []repository.UserPair{pair(batchActor, batchLiked)}).Return([]bool{true}, nil)
+	repo.On("DeleteLikes", ctx, mockTx, []repository.UserPair{pair(batchActor, batchPassed)}).Return(nil)
+	repo.On("InsertOutboxEvents", ctx, mockTx, mock.MatchedBy(func(events []repository.OutboxMessage) bool {
+		var types []string
+		for _, event := range events {
+			types = append(types, event.EventType)
+		}
+		return assert.ObjectsAreEqual([]string{outbox.EventDecisionRecorded, outbox.EventMatchCreated, outbox.EventDecisionRecorded}, types)
+	})).Return(nil)
+
+	response, err := service.PutDecisions(ctx, request)
+
+	require.NoError(t, err)
+	require.Len(t, response.Results, 4)
+	assert.True(t, response.Results[0].MutualLikes)
+	assert.False(t, response.Results[1].MutualLikes)
+	assert.Equal(t, []codes.Code{codes.OK, codes.OK, codes.InvalidArgument, codes.FailedPrecondition}, resultCodes(response))
+	assert.Equal(t, "recipient user ID must be a valid UUID", response.Results[2].Error.Message)
+	assert.Equal(t, "decision involves a blocked user", response.Results[3].Error.Message)
+	repo.AssertExpectations(t)
+	assert.True(t, mockTx.Committed)
+}
+
+func TestPutDecisions_AllOrNothingInvalidDecision(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	request := &explore.PutDecisionsRequest{
+		AllOrNothing: true,
+		Decisions: []*explore.PutDecisionRequest{
+			{ActorUserId: batchActor, RecipientUserId: batchLiked, LikedRecipient: true},
+			{ActorUserId: batchActor, RecipientUserId: batchActor, LikedRecipient: true},
+		},
+	}
+
+	response, err := service.PutDecisions(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.Equal(t, []codes.Code{codes.Aborted, codes.InvalidArgument}, resultCodes(response))
+	assert.Equal(t, "decision not recorded because decision 1 failed", response.Results[0].Error.Message)
+	repo.AssertNotCalled(t, "WithTx", mock.Anything)
+}
+
+func TestPutDecisions_AllOrNothingUnknownUser(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+
+	request := &explore.PutDecisionsRequest{
+		AllOrNothing: true,
+		Decisions: []*explore.PutDecisionRequest{
+			{ActorUserId: batchActor, RecipientUserId: batchLiked, LikedRecipient: true},
+			{ActorUserId: batchActor, RecipientUserId: batchPassed, LikedRecipient: false},
+		},
+	}
+
+	mockTx := &repository.MockTx{}
+
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("FindExistingUsers", ctx, mockTx, mock.Anything).Return(map[string]bool{batchActor: true, batchLiked: true}, nil)
+	repo.On("CheckBlockedPairs", ctx, mockTx, mock.Anything).Return([]bool{false, false}, nil)
+
+	// Nothing is recorded when a decision fails
+	response, err := service.PutDecisions(ctx, request)
+
+	require.NoError(t, err)
+	assert.Equal(t, []codes.Code{codes.Aborted, codes.FailedPrecondition}, resultCodes(response))
+	assert.Equal(t, "decision involves an unknown user", response.Results[1].Error.Message)
+	repo.AssertNotCalled(t, "InsertDecisions", mock.Anything, mock.Anything, mock.Anything)
+	assert.True(t, mockTx.RolledBack)
+}
+
+func TestPutDecisions_ReplaysIdempotentDecision(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	key := "swipe-1"
+
+	request := &explore.PutDecisionsRequest{Decisions: []*explore.PutDecisionRequest{
+		{ActorUserId: batchActor, RecipientUserId: batchLiked, LikedRecipient: true, IdempotencyKey: &key},
+	}}
+
+	mockTx := &repository.MockTx{}
+
+	original := &repository.IdempotentDecision{RecipientUserID: batchLiked, LikedRecipient: true, MutualLikes: true}
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("FindExistingUsers", ctx, mockTx, []string{batchActor, batchLiked}).
+		Return(map[string]bool{batchActor: true, batchLiked: true}, nil)
+	repo.On("CheckBlockedPairs", ctx, mockTx, []repository.UserPair{pair(batchActor, batchLiked)}).Return([]bool{false}, nil)
+	repo.On("ReserveIdempotencyKey", ctx, mockTx, batchActor, key, batchLiked, true, DefaultIdempotencyKeyTTL).Return(original, nil)
+
+	response, err := service.PutDecisions(ctx, request)
+
+	require.NoError(t, err)
+	assert.Equal(t, []codes.Code{codes.OK}, resultCodes(response))
+	assert.True(t, response.Results[0].MutualLikes)
+	repo.AssertNotCalled(t, "InsertDecisions", mock.Anything, mock.Anything, mock.Anything)
+	assert.True(t, mockTx.Committed)
+}
+
+func TestPutDecisions_RetriesBlockedDecisionWithSameKey(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	repo.AddUsers(batchActor, batchBlocked)
+	service := NewExploreService(repo)
+	ctx := context.Background()
+	key := "swipe-1"
+	request := &explore.PutDecisionsRequest{Decisions: []*explore.PutDecisionRequest{
+		{ActorUserId: batchActor, RecipientUserId: batchBlocked, LikedRecipient: true, IdempotencyKey: &key},
+	}}
+
+	require.NoError(t, repo.BlockUser(ctx, batchBlocked, batchActor))
+	response, err := service.PutDecisions(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, []codes.Code{codes.FailedPrecondition}, resultCodes(response))
+
+	// The rejected decision did not reserve its key, so the retry is recorded
+	require.NoError(t, repo.UnblockUser(ctx, batchBlocked, batchActor))
+	response, err = service.PutDecisions(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, []codes.Code{codes.OK}, resultCodes(response))
+
+	decisions, err := repo.GetDecisions(ctx, batchActor, explore.DecisionFilter_DECISION_FILTER_LIKES, 10, nil)
+	require.NoError(t, err)
+	require.Len(t, decisions, 1)
+	assert.Equal(t, batchBlocked, decisions[0].Decision.RecipientUserId)
+}
+
+func TestPutDecisions_UnknownActorWithKey(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	repo.AddUsers(batchActor, batchLiked)
+	service := NewExploreService(repo)
+	ctx := context.Background()
+	unknownKey, key := "swipe-1", "swipe-2"
+
+	response, err := service.PutDecisions(ctx, &explore.PutDecisionsRequest{Decisions: []*explore.PutDecisionRequest{
+		{ActorUserId: batchPassed, RecipientUserId: batchLiked, LikedRecipient: true, IdempotencyKey: &unknownKey},
+		{ActorUserId: batchActor, RecipientUserId: batchLiked, LikedRecipient: true, IdempotencyKey: &key},
+	}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []codes.Code{codes.FailedPrecondition, codes.OK}, resultCodes(response))
+	assert.Equal(t, "decision involves an unknown user", response.Results[0].GetError().GetMessage())
+
+	decisions, err := repo.GetDecisions(ctx, batchActor, explore.DecisionFilter_DECISION_FILTER_LIKES, 10, nil)
+	require.NoError(t, err)
+	require.Len(t, decisions, 1)
+	assert.Equal(t, batchLiked, decisions[0].Decision.RecipientUserId)
+}
+
+func TestPutDecisions_DuplicatePair(t *testing.T) {
+	_, service := setupServiceAndRepo()
+
+	request := &explore.PutDecisionsRequest{
+		AllOrNothing: true,
+		Decisions: []*explore.PutDecisionRequest{
+			{ActorUserId: batchActor, RecipientUserId: batchLiked, LikedRecipient: true},
+			{ActorUserId: batchActor, RecipientUserId: batchLiked, LikedRecipient: false},
+		},
+	}
+
+	response, err := service.PutDecisions(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.Equal(t, []codes.Code{codes.Aborted, codes.InvalidArgument}, resultCodes(response))
+}
+
+func TestPutDecisions_DuplicateIdempotencyKey(t *testing.T) {
+	_, service := setupServiceAndRepo()
+	key := "swipe-1"
+
+	request := &explore.PutDecisionsRequest{
+		AllOrNothing: true,
+		Decisions: []*explore.PutDecisionRequest{
+			{ActorUserId: batchActor, RecipientUserId: batchLiked, LikedRecipient: true, IdempotencyKey: &key},
+			{ActorUserId: batchActor, RecipientUserId: batchPassed, LikedRecipient: false, IdempotencyKey: &key},
+		},
+	}
+
+	response, err := service.PutDecisions(context.Background(), request)
+
+	require.NoError(t, err)
+	assert.Equal(t, []codes.Code{codes.Aborted, codes.InvalidArgument}, resultCodes(response))
+	assert.Equal(t, "decision repeats the idempotency key of an earlier decision of the batch", response.Results[1].Error.Message)
+}
+
+func TestPutDecisions_BatchSize(t *testing.T) {
+	repo := new(repository.MockExploreRepository)
+	service := NewExploreService(repo, WithMaxBatchDecisions(1))
+
+	_, err := service.PutDecisions(context.Background(), &explore.PutDecisionsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "decisions are required", status.Convert(err).Message())
+
+	_, err = service.PutDecisions(context.Background(), &explore.PutDecisionsRequest{Decisions: []*explore.PutDecisionRequest{{}, {}}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "at most 1 decisions can be sent at once", status.Convert(err).Message())
+}
+
+func TestPutDecisions_ReversedLikesReportOneMatch(t *testing.T) {
+	repo := repository.NewMemoryRepository()
+	repo.AddUsers(batchActor, batchLiked)
+	hub := newFakeEventHub()
+	service := NewExploreService(repo, WithEventHub(hub))
+	ctx := context.Background()
+
+	response, err := service.PutDecisions(ctx, &explore.PutDecisionsRequest{Decisions: []*explore.PutDecisionRequest{
+		{ActorUserId: batchActor, RecipientUserId: batchLiked, LikedRecipient: true},
+		{ActorUserId: batchLiked, RecipientUserId: batchActor, LikedRecipient: true},
+	}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []codes.Code{codes.OK, codes.OK}, resultCodes(response))
+	assert.False(t, response.Results[0].MutualLikes)
+	assert.True(t, response.Results[1].MutualLikes)
+
+	events, err := repo.ClaimOutboxEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	var matches int
+	for _, event := range events {
+		if event.EventType == outbox.EventMatchCreated {
+			matches++
+		}
+	}
+	assert.Equal(t, 1, matches)
+
+	var published int
+	for _, userEvents := range hub.published {
+		for _, event := range userEvents {
+			if event.GetMatchCreated() != nil {
+				published++
+			}
+		}
+	}
+	// One match is published to both users
+	assert.Equal(t, 2, published)
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+// statusFromError converts an error returned by the repository into a gRPC status error,
+// prefixing the message with msg.
+//
+// Repository errors are mapped as follows:
+//   - ErrNotFound: NotFound
+//   - ErrConflict, ErrInvalidReference: FailedPrecondition
+//   - ErrUnavailable: Unavailable
+//   - ErrTimeout: DeadlineExceeded
+//   - ErrAborted: Aborted
+//
+// Anything else is reported as Internal.
+func statusFromError(msg string, err error) error {
+	return status.Errorf(codeFromError(err), "%s: %v", msg, err)
+}
+
+// codeFromError picks the gRPC status code for a repository error.
+func codeFromError(err error) codes.Code {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, repository.ErrConflict), errors.Is(err, repository.ErrInvalidReference):
+		return codes.FailedPrecondition
+	case errors.Is(err, repository.ErrUnavailable):
+		return codes.Unavailable
+	case errors.Is(err, repository.ErrAborted):
+		return codes.Aborted
+	case errors.Is(err, repository.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	}
+	return codes.Internal
+}
+
+// statusFromTxError converts an error returned by a transaction run with WithTx into a gRPC
+// status error. Errors that already carry a status, such as rejections decided inside the
+// transaction, are returned unchanged; anything else is mapped like statusFromError.
+func statusFromTxError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(codeFromError(err), err.Error())
+}
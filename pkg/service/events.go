@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"log"
+	explore "muzz-backend-challenge/pkg/proto"
+	"time"
+)
+
+// EventHub publishes explore events and lets clients subscribe to the ones addressed to them.
+type EventHub interface {
+	Publish(ctx context.Context, userID string, event *explore.ExploreEvent) error
+	Subscribe(userID string) (<-chan *explore.ExploreEvent, func())
+}
+
+// publishDecisionEvents notifies the recipient of a like and, when the like completed a match,
+// both users of the new match. It runs after the decision is committed, so failures are only
+// logged: the decision stands and clients can still find it by listing.
+func (service *ExploreService) publishDecisionEvents(ctx context.Context, actorUserID, recipientUserID string, mutualLikes bool) {
+	if service.events == nil {
+		return
+	}
+
+	now := uint64(time.Now().Unix())
+	service.publish(ctx, recipientUserID, &explore.ExploreEvent{
+		UnixTimestamp: now,
+		Event: &explore.ExploreEvent_LikeReceived_{
+			LikeReceived: &explore.ExploreEvent_LikeReceived{ActorId: actorUserID},
+		},
+	})
+
+	if !mutualLikes {
+		return
+	}
+
+	for _, pair := range [][2]string{{actorUserID, recipientUserID}, {recipientUserID, actorUserID}} {
+		service.publish(ctx, pair[0], &explore.ExploreEvent{
+			UnixTimestamp: now,
+			Event: &explore.ExploreEvent_MatchCreated_{
+				MatchCreated: &explore.ExploreEvent_MatchCreated{UserId: pair[1]},
+			},
+		})
+	}
+}
+
+// publish sends a single event, logging rather than returning failures.
+func (service *ExploreService) publish(ctx context.Context, userID string, event *explore.ExploreEvent) {
+	if err := service.events.Publish(ctx, userID, event); err != nil {
+		log.Printf("Failed to publish explore event to user %s: %v", userID, err)
+	}
+}
@@ -3,103 +3,171 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log"
 	explore "muzz-backend-challenge/pkg/proto"
 	"muzz-backend-challenge/pkg/repository"
-	"strconv"
+	"time"
 )
 
 // ExploreService implements the ExploreServiceServer interface.
 type ExploreService struct {
-	repository repository.ExploreRepository
+	repository      repository.ExploreRepository
+	defaultPageSize int
+	maxPageSize     int
+	events          EventHub
+	idempotencyTTL  time.Duration
+	maxBatchSize    int
+	ranking         RankingStrategy
 	explore.UnimplementedExploreServiceServer
 }
 
-// NewExploreService creates a new instance of ExploreService.
-func NewExploreService(repo repository.ExploreRepository) *ExploreService {
-	return &ExploreService{repository: repo}
+// Option configures optional ExploreService settings.
+type Option func(*ExploreService)
+
+// WithDefaultPageSize sets the page size used when a list request does not specify one.
+func WithDefaultPageSize(size int) Option {
+	return func(service *ExploreService) {
+		service.defaultPageSize = size
+	}
 }
 
-// ListLikedYou retrieves a list of users who liked the recipient user.
-//
-// It retrieves likers for the given recipient user ID. Pagination is supported
-// via the pagination token, which allows fetching the next set of results.
-func (service ExploreService) ListLikedYou(
-	ctx context.Context,
-	request *explore.ListLikedYouRequest,
-) (*explore.ListLikedYouResponse, error) {
-	recipientID := request.GetRecipientUserId()
-	if recipientID == "" {
-		return nil, status.Error(codes.InvalidArgument, "recipient user ID is required")
+// WithMaxPageSize sets the largest page size a client may request.
+func WithMaxPageSize(size int) Option {
+	return func(service *ExploreService) {
+		service.maxPageSize = size
 	}
+}
 
-	limit := 10
-	offset := 0
+// WithEventHub enables real-time events: decisions are published to the hub and clients
+// can subscribe to them with SubscribeExploreEvents.
+func WithEventHub(hub EventHub) Option {
+	return func(service *ExploreService) {
+		service.events = hub
+	}
+}
 
-	if request.GetPaginationToken() != "" {
-		// Parse the pagination token if provided
-		var err error
-		offset, err = strconv.Atoi(request.GetPaginationToken())
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid pagination token: %v", err)
+// WithIdempotencyKeyTTL sets how long PutDecision remembers an idempotency key. Retries
+// arriving after the TTL are recorded as new decisions.
+func WithIdempotencyKeyTTL(ttl time.Duration) Option {
+	return func(service *ExploreService) {
+		if ttl > 0 {
+			service.idempotencyTTL = ttl
 		}
 	}
+}
 
-	likers, err := service.repository.GetLikedYou(ctx, recipientID, limit, offset)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list likers: %v", err)
+// WithMaxBatchDecisions sets the largest number of decisions a client may send to PutDecisions.
+func WithMaxBatchDecisions(size int) Option {
+	return func(service *ExploreService) {
+		if size > 0 {
+			service.maxBatchSize = size
+		}
 	}
+}
 
-	nextPaginationToken := fmt.Sprintf("%d", offset+limit)
+// WithRankingStrategy sets the strategy that orders GetExploreFeed: the order the repository
+// reads the feed in, if the strategy implements FeedOrderer, and the order within each page.
+// The default is LikersFirst.
+func WithRankingStrategy(strategy RankingStrategy) Option {
+	return func(service *ExploreService) {
+		if strategy != nil {
+			service.ranking = strategy
+		}
+	}
+}
 
-	response := &explore.ListLikedYouResponse{
-		Likers:              likers,
-		NextPaginationToken: &nextPaginationToken,
+// NewExploreService creates a new instance of ExploreService.
+func NewExploreService(repo repository.ExploreRepository, opts ...Option) *ExploreService {
+	service := &ExploreService{
+		repository:      repo,
+		defaultPageSize: DefaultPageSize,
+		maxPageSize:     MaxPageSize,
+		idempotencyTTL:  DefaultIdempotencyKeyTTL,
+		maxBatchSize:    DefaultMaxBatchDecisions,
+		ranking:         LikersFirst,
 	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	return service
+}
 
-	return response, nil
+// ListLikedYou retrieves a list of users who liked the recipient user.
+//
+// It retrieves likers for the given recipient user ID. Pagination is supported
+// via the pagination token, which allows fetching the next set of results. With
+// include_profile, each liker comes with their profile, read in the same query.
+func (service ExploreService) ListLikedYou(
+	ctx context.Context,
+	request *explore.ListLikedYouRequest,
+) (*explore.ListLikedYouResponse, error) {
+	return service.listLikers(ctx, request, listLikedYou, service.repository.GetLikedYou)
 }
 
-// ListNewLikedYou retrieves a list of new users who liked the recipient user. These are users who the recipient has seen or liked yet.
+// ListNewLikedYou retrieves a list of new users who liked the recipient user. These are users the recipient has not yet liked or passed on.
 //
 // It retrieves new likers for the given recipient user ID. Pagination is supported
-// via the pagination token, which allows fetching the next set of results.
+// via the pagination token, which allows fetching the next set of results. With
+// include_profile, each liker comes with their profile, read in the same query.
 func (service ExploreService) ListNewLikedYou(
 	ctx context.Context,
 	request *explore.ListLikedYouRequest,
+) (*explore.ListLikedYouResponse, error) {
+	return service.listLikers(ctx, request, listNewLikedYou, service.repository.GetNewLikedYou)
+}
+
+// likerLister is the shape shared by the repository's liker list queries.
+type likerLister func(ctx context.Context, recipientUserID string, includeProfile bool, limit int, after *repository.Cursor) ([]repository.LikerRow, error)
+
+// listLikers runs a keyset-paginated liker query and wraps the result in a response.
+//
+// The pagination token is an opaque cursor bound to the recipient and the list kind;
+// tokens issued for another recipient or list are rejected. The next pagination token
+// is only set when there are more results after the returned page.
+func (service ExploreService) listLikers(
+	ctx context.Context,
+	request *explore.ListLikedYouRequest,
+	list listKind,
+	fetch likerLister,
 ) (*explore.ListLikedYouResponse, error) {
 	recipientID := request.GetRecipientUserId()
-	if recipientID == "" {
-		return nil, status.Error(codes.InvalidArgument, "recipient user ID is required")
+	if err := validateUUID("recipient user ID", recipientID); err != nil {
+		return nil, err
 	}
 
-	limit := 10
-	offset := 0
+	limit, err := service.pageSize(request.PageSize)
+	if err != nil {
+		return nil, err
+	}
 
-	if request.GetPaginationToken() != "" {
-		var err error
-		offset, err = strconv.Atoi(request.GetPaginationToken())
-		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "invalid pagination token: %v", err)
-		}
+	after, err := decodePageToken(request.GetPaginationToken(), list, recipientID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	likers, err := service.repository.GetNewLikedYou(ctx, recipientID, limit, offset)
+	// Fetch one extra row to find out whether another page exists without a separate count.
+	rows, err := fetch(ctx, recipientID, request.GetIncludeProfile(), limit+1, after)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list likers: %v", err)
+		return nil, statusFromError("failed to list likers", err)
 	}
 
-	nextPaginationToken := fmt.Sprintf("%d", offset+limit)
+	rows, nextPaginationToken := trimPage(rows, limit, list, recipientID, func(row repository.LikerRow) repository.Cursor {
+		return row.Cursor
+	})
 
-	response := &explore.ListLikedYouResponse{
-		Likers:              likers,
-		NextPaginationToken: &nextPaginationToken,
+	likers := make([]*explore.ListLikedYouResponse_Liker, 0, len(rows))
+	for _, row := range rows {
+		likers = append(likers, row.Liker)
 	}
 
-	return response, nil
+	return &explore.ListLikedYouResponse{
+		Likers:              likers,
+		NextPaginationToken: nextPaginationToken,
+	}, nil
 }
 
 // CountLikedYou counts the number of users who liked the recipient user.
@@ -109,10 +177,32 @@ func (service ExploreService) CountLikedYou(
 	ctx context.Context,
 	request *explore.CountLikedYouRequest,
 ) (*explore.CountLikedYouResponse, error) {
+	if err := validateUUID("recipient user ID", request.GetRecipientUserId()); err != nil {
+		return nil, err
+	}
+
 	count, err := service.repository.CountLikes(ctx, request.RecipientUserId)
 	if err != nil {
+		return nil, statusFromError("failed to count likes", err)
+	}
+	return &explore.CountLikedYouResponse{Count: uint64(count)}, nil
+}
+
+// CountNewLikedYou counts the number of new users who liked the recipient user.
+//
+// It counts the same users as ListNewLikedYou: likers the recipient has not yet liked or passed on.
+func (service ExploreService) CountNewLikedYou(
+	ctx context.Context,
+	request *explore.CountLikedYouRequest,
+) (*explore.CountLikedYouResponse, error) {
+	if err := validateUUID("recipient user ID", request.GetRecipientUserId()); err != nil {
 		return nil, err
 	}
+
+	count, err := service.repository.CountNewLikes(ctx, request.RecipientUserId)
+	if err != nil {
+		return nil, statusFromError("failed to count new likes", err)
+	}
 	return &explore.CountLikedYouResponse{Count: uint64(count)}, nil
 }
 
@@ -120,57 +210,459 @@ func (service ExploreService) CountLikedYou(
 //
 // It records the decision made by the actor user regarding the recipient user.
 // If the decision results in a mutual like, it returns true in MutualLikes field.
+// Requests carrying an idempotency key already used by the actor within the TTL are not
+// applied again; they get the response of the original request.
 func (service *ExploreService) PutDecision(
 	ctx context.Context,
 	request *explore.PutDecisionRequest,
 ) (*explore.PutDecisionResponse, error) {
-	// Start a transaction
-	tx, err := service.repository.BeginTransaction(ctx)
+	err := validateUserPair("actor user ID", request.GetActorUserId(), "recipient user ID", request.GetRecipientUserId())
 	if err != nil {
-		log.Printf("Failed to begin transaction: %v", err)
-		return nil, status.Errorf(codes.Internal, "failed to begin transaction: %v", err)
+		return nil, err
 	}
 
-	defer tx.Rollback()
-
-	// Insert the decision into the decision database
-	err = service.repository.InsertDecision(ctx, tx, request.ActorUserId, request.RecipientUserId, request.LikedRecipient)
-	if err != nil {
-		log.Printf("Failed to insert decision: %v", err)
-		return nil, status.Errorf(codes.Internal, "failed to insert decision: %v", err)
+	if err := validateIdempotencyKey(request.IdempotencyKey); err != nil {
+		return nil, err
 	}
 
-	mutualLikes := false
+	var response *explore.PutDecisionResponse
+	replayed := false
+
+	// The transaction may run more than once when it hits a retryable error, so everything
+	// it decides is reset on each attempt
+	err = service.repository.WithTx(ctx, func(tx repository.Tx) error {
+		response, replayed = nil, false
+
+		// A retry of a decision already recorded under the same key gets the original response
+		if request.IdempotencyKey != nil {
+			original, err := service.repository.ReserveIdempotencyKey(ctx, tx, request.ActorUserId, *request.IdempotencyKey,
+				request.RecipientUserId, request.LikedRecipient, service.idempotencyTTL)
+			if err != nil {
+				return fmt.Errorf("failed to reserve idempotency key: %w", err)
+			}
+			if original != nil {
+				replayed = true
+				response, err = replayDecision(request, original)
+				return err
+			}
+		}
 
-	// If the user liked the recipient, record the like
-	if request.LikedRecipient {
-		// Insert the like into the like database
-		err = service.repository.InsertLike(ctx, tx, request.ActorUserId, request.RecipientUserId)
+		// Decisions are not allowed between users who have blocked each other
+		blocked, err := service.repository.IsBlocked(ctx, tx, request.ActorUserId, request.RecipientUserId)
 		if err != nil {
-			log.Printf("Failed to insert like: %v", err)
-			return nil, status.Errorf(codes.Internal, "failed to insert like: %v", err)
+			return fmt.Errorf("failed to check block: %w", err)
+		}
+		if blocked {
+			return status.Error(codes.FailedPrecondition, "decision involves a blocked user")
 		}
 
-		// Check if the recipient also liked the actor
-		mutualLikes, err = service.repository.CheckMutualLike(ctx, tx, request.ActorUserId, request.RecipientUserId)
+		// Insert the decision into the decision database
+		err = service.repository.InsertDecision(ctx, tx, request.ActorUserId, request.RecipientUserId, request.LikedRecipient)
 		if err != nil {
-			log.Printf("Failed to check mutual like: %v", err)
-			return nil, status.Errorf(codes.Internal, "failed to check mutual like: %v", err)
+			return fmt.Errorf("failed to insert decision: %w", err)
+		}
+
+		mutualLikes := false
+
+		// If the user liked the recipient, record the like
+		if request.LikedRecipient {
+			// Insert the like into the like database
+			err = service.repository.InsertLike(ctx, tx, request.ActorUserId, request.RecipientUserId)
+			if err != nil {
+				return fmt.Errorf("failed to insert like: %w", err)
+			}
+
+			// Check if the recipient also liked the actor
+			mutualLikes, err = service.repository.CheckMutualLike(ctx, tx, request.ActorUserId, request.RecipientUserId)
+			if err != nil {
+				return fmt.Errorf("failed to check mutual like: %w", err)
+			}
+		} else {
+			// Delete the like if the actor passes on the recipient (unmatched)
+			err = service.repository.DeleteLike(ctx, tx, request.ActorUserId, request.RecipientUserId)
+			if err != nil {
+				return fmt.Errorf("failed to delete like: %w", err)
+			}
 		}
-	} else {
-		// Delete the like if the actor passes on the recipient (unmatched)
-		err = service.repository.DeleteLike(ctx, tx, request.ActorUserId, request.RecipientUserId)
+
+		// Queue the events describing the decision so they are published once it commits
+		err = service.queueDecisionEvents(ctx, tx, request.ActorUserId, request.RecipientUserId, request.LikedRecipient, mutualLikes)
 		if err != nil {
-			log.Printf("Failed to delete like: %v", err)
-			return nil, status.Errorf(codes.Internal, "failed to delete like: %v", err)
+			return fmt.Errorf("failed to queue decision events: %w", err)
+		}
+
+		// Remember the response so retries with the same key can replay it
+		if request.IdempotencyKey != nil {
+			err = service.repository.CompleteIdempotencyKey(ctx, tx, request.ActorUserId, *request.IdempotencyKey, mutualLikes)
+			if err != nil {
+				return fmt.Errorf("failed to complete idempotency key: %w", err)
+			}
 		}
+
+		response = &explore.PutDecisionResponse{MutualLikes: mutualLikes}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to put decision: %v", err)
+		return nil, statusFromTxError(err)
+	}
+
+	if request.LikedRecipient && !replayed {
+		service.publishDecisionEvents(ctx, request.ActorUserId, request.RecipientUserId, response.MutualLikes)
 	}
 
-	// Commit the transaction
-	if err = tx.Commit(); err != nil {
-		log.Printf("Failed to commit transaction: %v", err)
-		return nil, status.Errorf(codes.Internal, "failed to commit transaction: %v", err)
+	return response, nil
+}
+
+// PutDecisions records a batch of decisions in a single transaction.
+//
+// Each decision is handled as PutDecision would handle it and gets its own result, in request
+// order. A decision that cannot be recorded gets an error and, unless AllOrNothing is set, does
+// not prevent the others from being recorded. With AllOrNothing, no decision is recorded if any
+// of them fails, and the others get an Aborted error.
+func (service *ExploreService) PutDecisions(
+	ctx context.Context,
+	request *explore.PutDecisionsRequest,
+) (*explore.PutDecisionsResponse, error) {
+	decisions := request.GetDecisions()
+	if len(decisions) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "decisions are required")
+	}
+	if len(decisions) > service.maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d decisions can be sent at once", service.maxBatchSize)
+	}
+
+	validated := newDecisionBatch(decisions)
+	validated.validate()
+	if failed := validated.firstRejected(); failed >= 0 && request.AllOrNothing {
+		validated.abort(failed)
+		return validated.response(), nil
+	}
+
+	var batch *decisionBatch
+	var recorded []int
+	err := service.repository.WithTx(ctx, func(tx repository.Tx) error {
+		// Start over from the validated batch, as a failed attempt may have settled decisions
+		batch = validated.clone()
+
+		// Keys are only reserved for decisions that can be recorded, since a rejected
+		// decision's reservation would commit with the others and block its retries
+		if err := service.rejectUnrecordableDecisions(ctx, tx, batch); err != nil {
+			return fmt.Errorf("failed to check decisions: %w", err)
+		}
+
+		if err := service.replayIdempotentDecisions(ctx, tx, batch); err != nil {
+			return fmt.Errorf("failed to reserve idempotency keys: %w", err)
+		}
+
+		if failed := batch.firstRejected(); failed >= 0 && request.AllOrNothing {
+			batch.abort(failed)
+			return errBatchAborted
+		}
+
+		var err error
+		recorded, err = service.recordDecisions(ctx, tx, batch)
+		if err != nil {
+			return fmt.Errorf("failed to record decisions: %w", err)
+		}
+		return nil
+	})
+	if errors.Is(err, errBatchAborted) {
+		return batch.response(), nil
+	}
+	if err != nil {
+		log.Printf("Failed to put decisions: %v", err)
+		return nil, statusFromTxError(err)
+	}
+
+	for _, i := range recorded {
+		if decision := decisions[i]; decision.LikedRecipient {
+			service.publishDecisionEvents(ctx, decision.ActorUserId, decision.RecipientUserId, batch.results[i].MutualLikes)
+		}
+	}
+
+	return batch.response(), nil
+}
+
+// ListMatches retrieves the users who share a mutual like with the given user.
+//
+// Matches are ordered by match time, newest first, where the match time is the later of
+// the two likes. Pagination works the same way as for ListLikedYou.
+func (service ExploreService) ListMatches(
+	ctx context.Context,
+	request *explore.ListMatchesRequest,
+) (*explore.ListMatchesResponse, error) {
+	userID := request.GetUserId()
+	if err := validateUUID("user ID", userID); err != nil {
+		return nil, err
 	}
 
-	return &explore.PutDecisionResponse{MutualLikes: mutualLikes}, nil
+	limit, err := service.pageSize(request.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := decodePageToken(request.GetPaginationToken(), listMatches, userID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rows, err := service.repository.GetMatches(ctx, userID, limit+1, after)
+	if err != nil {
+		return nil, statusFromError("failed to list matches", err)
+	}
+
+	rows, nextPaginationToken := trimPage(rows, limit, listMatches, userID, func(row repository.MatchRow) repository.Cursor {
+		return row.Cursor
+	})
+
+	matches := make([]*explore.ListMatchesResponse_Match, 0, len(rows))
+	for _, row := range rows {
+		matches = append(matches, row.Match)
+	}
+
+	return &explore.ListMatchesResponse{
+		Matches:             matches,
+		NextPaginationToken: nextPaginationToken,
+	}, nil
+}
+
+// CountMatches counts the users who share a mutual like with the given user.
+func (service ExploreService) CountMatches(
+	ctx context.Context,
+	request *explore.CountMatchesRequest,
+) (*explore.CountMatchesResponse, error) {
+	if err := validateUUID("user ID", request.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	count, err := service.repository.CountMatches(ctx, request.GetUserId())
+	if err != nil {
+		return nil, statusFromError("failed to count matches", err)
+	}
+	return &explore.CountMatchesResponse{Count: uint64(count)}, nil
+}
+
+// Unmatch breaks the mutual match between the actor user and the recipient user.
+//
+// Both likes are removed in a single transaction and the unmatch is recorded, so neither
+// user shows up in the other's liker or match lists afterwards. It returns NotFound if
+// the users are not matched.
+func (service *ExploreService) Unmatch(
+	ctx context.Context,
+	request *explore.UnmatchRequest,
+) (*explore.UnmatchResponse, error) {
+	err := validateUserPair("actor user ID", request.GetActorUserId(), "recipient user ID", request.GetRecipientUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = service.repository.WithTx(ctx, func(tx repository.Tx) error {
+		if err := service.repository.Unmatch(ctx, tx, request.ActorUserId, request.RecipientUserId); err != nil {
+			return fmt.Errorf("failed to unmatch: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Failed to unmatch: %v", err)
+		return nil, statusFromTxError(err)
+	}
+
+	return &explore.UnmatchResponse{}, nil
+}
+
+// BlockUser blocks the given user on behalf of the actor user.
+//
+// Blocked pairs are hidden from each other's liker and match lists in both directions,
+// and further decisions between them are rejected. Blocking an already blocked user succeeds.
+func (service ExploreService) BlockUser(
+	ctx context.Context,
+	request *explore.BlockUserRequest,
+) (*explore.BlockUserResponse, error) {
+	err := validateUserPair("actor user ID", request.GetActorUserId(), "blocked user ID", request.GetBlockedUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.repository.BlockUser(ctx, request.ActorUserId, request.BlockedUserId); err != nil {
+		return nil, statusFromError("failed to block user", err)
+	}
+	return &explore.BlockUserResponse{}, nil
+}
+
+// UnblockUser removes a block the actor user previously placed on the given user.
+//
+// Unblocking a user that is not blocked succeeds.
+func (service ExploreService) UnblockUser(
+	ctx context.Context,
+	request *explore.UnblockUserRequest,
+) (*explore.UnblockUserResponse, error) {
+	err := validateUserPair("actor user ID", request.GetActorUserId(), "blocked user ID", request.GetBlockedUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.repository.UnblockUser(ctx, request.ActorUserId, request.BlockedUserId); err != nil {
+		return nil, statusFromError("failed to unblock user", err)
+	}
+	return &explore.UnblockUserResponse{}, nil
+}
+
+// ReportUser files a report from the actor user against the given user.
+func (service ExploreService) ReportUser(
+	ctx context.Context,
+	request *explore.ReportUserRequest,
+) (*explore.ReportUserResponse, error) {
+	err := validateUserPair("actor user ID", request.GetActorUserId(), "reported user ID", request.GetReportedUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateReportReason(request.GetReason()); err != nil {
+		return nil, err
+	}
+
+	err = service.repository.ReportUser(ctx, request.ActorUserId, request.ReportedUserId, request.Reason)
+	if err != nil {
+		return nil, statusFromError("failed to report user", err)
+	}
+	return &explore.ReportUserResponse{}, nil
+}
+
+// ListDecisions retrieves the decisions the actor user has made on other users.
+//
+// Decisions are ordered by the time of the latest decision, newest first, and can be
+// restricted to likes or passes with the filter. Pagination works the same way as for ListLikedYou.
+func (service ExploreService) ListDecisions(
+	ctx context.Context,
+	request *explore.ListDecisionsRequest,
+) (*explore.ListDecisionsResponse, error) {
+	actorID := request.GetActorUserId()
+	if err := validateUUID("actor user ID", actorID); err != nil {
+		return nil, err
+	}
+
+	filter := request.GetFilter()
+	if _, ok := explore.DecisionFilter_name[int32(filter)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown decision filter %d", filter)
+	}
+
+	limit, err := service.pageSize(request.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	list := decisionsList(filter)
+	after, err := decodePageToken(request.GetPaginationToken(), list, actorID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rows, err := service.repository.GetDecisions(ctx, actorID, filter, limit+1, after)
+	if err != nil {
+		return nil, statusFromError("failed to list decisions", err)
+	}
+
+	rows, nextPaginationToken := trimPage(rows, limit, list, actorID, func(row repository.DecisionRow) repository.Cursor {
+		return row.Cursor
+	})
+
+	decisions := make([]*explore.ListDecisionsResponse_Decision, 0, len(rows))
+	for _, row := range rows {
+		decisions = append(decisions, row.Decision)
+	}
+
+	return &explore.ListDecisionsResponse{
+		Decisions:           decisions,
+		NextPaginationToken: nextPaginationToken,
+	}, nil
+}
+
+// GetExploreFeed retrieves the users the given user can decide on next: everyone they have not
+// liked or passed on yet, except themselves and users hidden from them by a block or unmatch.
+//
+// The service's ranking strategy picks the order the repository reads the feed in, by default
+// users who liked the requester before everyone else, and then orders each page. Pagination
+// works the same way as for ListLikedYou.
+func (service ExploreService) GetExploreFeed(
+	ctx context.Context,
+	request *explore.GetExploreFeedRequest,
+) (*explore.GetExploreFeedResponse, error) {
+	userID := request.GetUserId()
+	if err := validateUUID("user ID", userID); err != nil {
+		return nil, err
+	}
+
+	limit, err := service.pageSize(request.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	order := feedOrder(service.ranking)
+	after, err := decodeFeedPageToken(request.GetPaginationToken(), userID, order)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rows, err := service.repository.GetExploreFeed(ctx, userID, order, limit+1, after)
+	if err != nil {
+		return nil, statusFromError("failed to get explore feed", err)
+	}
+
+	// The token points at the last candidate in repository order, so however the page is
+	// ranked, the next page neither skips nor repeats candidates
+	var nextPaginationToken *string
+	if len(rows) > limit {
+		rows = rows[:limit]
+		token := encodeFeedPageToken(userID, order, rows[limit-1].Cursor)
+		nextPaginationToken = &token
+	}
+
+	rows = service.ranking.Rank(ctx, userID, rows)
+
+	candidates := make([]*explore.GetExploreFeedResponse_Candidate, 0, len(rows))
+	for _, row := range rows {
+		candidates = append(candidates, row.Candidate)
+	}
+
+	return &explore.GetExploreFeedResponse{
+		Candidates:          candidates,
+		NextPaginationToken: nextPaginationToken,
+	}, nil
+}
+
+// SubscribeExploreEvents streams real-time events addressed to the given user.
+//
+// The stream receives a LikeReceived event whenever someone likes the user and a
+// MatchCreated event whenever a like results in a mutual match. It stays open until the
+// client cancels it.
+func (service ExploreService) SubscribeExploreEvents(
+	request *explore.SubscribeExploreEventsRequest,
+	stream explore.ExploreService_SubscribeExploreEventsServer,
+) error {
+	userID := request.GetUserId()
+	if err := validateUUID("user ID", userID); err != nil {
+		return err
+	}
+
+	if service.events == nil {
+		return status.Error(codes.Unimplemented, "explore events are not enabled")
+	}
+
+	events, cancel := service.events.Subscribe(userID)
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return status.Error(codes.Unavailable, "explore events subscription closed")
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
 }
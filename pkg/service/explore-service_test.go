@@ -2,13 +2,20 @@ package service
 
 import (
 	"context"
-	"github.com/DATA-DOG/go-sqlmock"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"muzz-backend-challenge/pkg/outbox"
 	explore "muzz-backend-challenge/pkg/proto"
 	"muzz-backend-challenge/pkg/repository"
 )
@@ -19,27 +26,277 @@ func setupServiceAndRepo() (*repository.MockExploreRepository, *ExploreService)
 	return repo, service
 }
 
+// expectOutboxEvent expects a single outbox event of the given type whose payload, ignoring
+// its timestamp, equals wantJSON.
+func expectOutboxEvent(repo *repository.MockExploreRepository, ctx context.Context, tx repository.Tx, eventType, wantJSON string) {
+	var want map[string]interface{}
+	if err := json.Unmarshal([]byte(wantJSON), &want); err != nil {
+		panic(err)
+	}
+
+	repo.On("InsertOutboxEvent", ctx, tx, eventType, mock.MatchedBy(func(payload []byte) bool {
+		var got map[string]interface{}
+		if err := json.Unmarshal(payload, &got); err != nil {
+			return false
+		}
+		delete(got, "unix_timestamp")
+		return reflect.DeepEqual(want, got)
+	})).Return(nil).Once()
+}
+
+func likerRow(actorID string, createdAt time.Time) repository.LikerRow {
+	return repository.LikerRow{
+		Liker:  &explore.ListLikedYouResponse_Liker{ActorId: actorID, UnixTimestamp: uint64(createdAt.Unix())},
+		Cursor: repository.Cursor{CreatedAt: createdAt.UTC(), UserID: actorID},
+	}
+}
+
+// likerRows builds n liker rows ordered newest first, as the repository returns them.
+func likerRows(n int) []repository.LikerRow {
+	rows := make([]repository.LikerRow, 0, n)
+	for i := 0; i < n; i++ {
+		rows = append(rows, likerRow(fmt.Sprintf("user%d", i), time.Unix(int64(1700000000-i), 0)))
+	}
+	return rows
+}
+
 func TestListLikedYou(t *testing.T) {
 	repo, service := setupServiceAndRepo()
 
 	ctx := context.Background()
-	recipientUserID := "test-recipient"
-	paginationToken := "0"
+	recipientUserID := "00000000-0000-0000-0000-000000000001"
 
 	request := &explore.ListLikedYouRequest{
 		RecipientUserId: recipientUserID,
-		PaginationToken: &paginationToken,
 	}
 
-	likers := []*explore.ListLikedYouResponse_Liker{{ActorId: "user1"}}
-	repo.On("GetLikedYou", mock.Anything, recipientUserID, 10, 0).Return(likers, nil)
+	rows := []repository.LikerRow{likerRow("user1", time.Unix(1700000000, 0))}
+	repo.On("GetLikedYou", mock.Anything, recipientUserID, false, 11, (*repository.Cursor)(nil)).Return(rows, nil)
 
 	response, err := service.ListLikedYou(ctx, request)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
-	assert.Equal(t, likers, response.Likers)
-	assert.Equal(t, "10", *response.NextPaginationToken)
+	assert.Equal(t, []*explore.ListLikedYouResponse_Liker{rows[0].Liker}, response.Likers)
+	assert.Nil(t, response.NextPaginationToken)
+	repo.AssertExpectations(t)
+}
+
+func TestListLikedYou_IncludeProfile(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	recipientUserID := "00000000-0000-0000-0000-000000000001"
+
+	request := &explore.ListLikedYouRequest{
+		RecipientUserId: recipientUserID,
+		IncludeProfile:  true,
+	}
+
+	rows := []repository.LikerRow{likerRow("user1", time.Unix(1700000000, 0))}
+	rows[0].Liker.Profile = &explore.LikerProfile{Username: "user1", DisplayName: "User One"}
+	repo.On("GetLikedYou", mock.Anything, recipientUserID, true, 11, (*repository.Cursor)(nil)).Return(rows, nil)
+
+	response, err := service.ListLikedYou(ctx, request)
+
+	assert.NoError(t, err)
+	require.Len(t, response.Likers, 1)
+	assert.Equal(t, "User One", response.Likers[0].GetProfile().GetDisplayName())
+	repo.AssertExpectations(t)
+}
+
+func TestListLikedYou_MoreResults(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	recipientUserID := "00000000-0000-0000-0000-000000000001"
+
+	request := &explore.ListLikedYouRequest{
+		RecipientUserId: recipientUserID,
+	}
+
+	rows := likerRows(11)
+	repo.On("GetLikedYou", mock.Anything, recipientUserID, false, 11, (*repository.Cursor)(nil)).Return(rows, nil)
+
+	response, err := service.ListLikedYou(ctx, request)
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Likers, 10)
+	assert.Equal(t, rows[9].Liker, response.Likers[9])
+	assert.NotNil(t, response.NextPaginationToken)
+
+	cursor, err := decodePageToken(response.GetNextPaginationToken(), listLikedYou, recipientUserID)
+	assert.NoError(t, err)
+	assert.Equal(t, rows[9].Cursor, *cursor)
+	repo.AssertExpectations(t)
+}
+
+func TestListLikedYou_ExactMultipleOfPageSize(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	recipientUserID := "00000000-0000-0000-0000-000000000001"
+
+	request := &explore.ListLikedYouRequest{
+		RecipientUserId: recipientUserID,
+	}
+
+	rows := likerRows(10)
+	repo.On("GetLikedYou", mock.Anything, recipientUserID, false, 11, (*repository.Cursor)(nil)).Return(rows, nil)
+
+	response, err := service.ListLikedYou(ctx, request)
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Likers, 10)
+	assert.Nil(t, response.NextPaginationToken)
+	repo.AssertExpectations(t)
+}
+
+func TestListLikedYou_Empty(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	recipientUserID := "00000000-0000-0000-0000-000000000001"
+
+	request := &explore.ListLikedYouRequest{
+		RecipientUserId: recipientUserID,
+	}
+
+	repo.On("GetLikedYou", mock.Anything, recipientUserID, false, 11, (*repository.Cursor)(nil)).Return([]repository.LikerRow(nil), nil)
+
+	response, err := service.ListLikedYou(ctx, request)
+
+	assert.NoError(t, err)
+	assert.Empty(t, response.Likers)
+	assert.Nil(t, response.NextPaginationToken)
+	repo.AssertExpectations(t)
+}
+
+func TestListLikedYou_NextPage(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	recipientUserID := "00000000-0000-0000-0000-000000000001"
+	cursor := repository.Cursor{CreatedAt: time.UnixMicro(1700000000123456).UTC(), UserID: "user1"}
+	paginationToken := encodePageToken(listLikedYou, recipientUserID, cursor)
+
+	request := &explore.ListLikedYouRequest{
+		RecipientUserId: recipientUserID,
+		PaginationToken: &paginationToken,
+	}
+
+	rows := []repository.LikerRow{likerRow("user2", time.Unix(1690000000, 0))}
+	repo.On("GetLikedYou", mock.Anything, recipientUserID, false, 11, &cursor).Return(rows, nil)
+
+	response, err := service.ListLikedYou(ctx, request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*explore.ListLikedYouResponse_Liker{rows[0].Liker}, response.Likers)
+	assert.Nil(t, response.NextPaginationToken)
+	repo.AssertExpectations(t)
+}
+
+func TestListLikedYou_TokenFromAnotherRecipient(t *testing.T) {
+	_, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	cursor := repository.Cursor{CreatedAt: time.Unix(1700000000, 0).UTC(), UserID: "user1"}
+	paginationToken := encodePageToken(listLikedYou, "00000000-0000-0000-0000-000000000003", cursor)
+
+	request := &explore.ListLikedYouRequest{
+		RecipientUserId: "00000000-0000-0000-0000-000000000001",
+		PaginationToken: &paginationToken,
+	}
+
+	response, err := service.ListLikedYou(ctx, request)
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "invalid pagination token")
+}
+
+func TestListLikedYou_TokenFromAnotherList(t *testing.T) {
+	_, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	recipientID := "00000000-0000-0000-0000-000000000001"
+	cursor := repository.Cursor{CreatedAt: time.Unix(1700000000, 0).UTC(), UserID: "user1"}
+	paginationToken := encodePageToken(listNewLikedYou, recipientID, cursor)
+
+	request := &explore.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+		PaginationToken: &paginationToken,
+	}
+
+	response, err := service.ListLikedYou(ctx, request)
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "invalid pagination token")
+}
+
+func TestListLikedYou_CustomPageSize(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	recipientUserID := "00000000-0000-0000-0000-000000000001"
+	pageSize := uint32(3)
+
+	request := &explore.ListLikedYouRequest{
+		RecipientUserId: recipientUserID,
+		PageSize:        &pageSize,
+	}
+
+	rows := likerRows(4)
+	repo.On("GetLikedYou", mock.Anything, recipientUserID, false, 4, (*repository.Cursor)(nil)).Return(rows, nil)
+
+	response, err := service.ListLikedYou(ctx, request)
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Likers, 3)
+	assert.NotNil(t, response.NextPaginationToken)
+	repo.AssertExpectations(t)
+}
+
+func TestListLikedYou_PageSizeOutOfRange(t *testing.T) {
+	repo := new(repository.MockExploreRepository)
+	service := NewExploreService(repo, WithMaxPageSize(50))
+
+	ctx := context.Background()
+
+	for _, size := range []uint32{0, 51} {
+		pageSize := size
+		request := &explore.ListLikedYouRequest{
+			RecipientUserId: "00000000-0000-0000-0000-000000000001",
+			PageSize:        &pageSize,
+		}
+
+		response, err := service.ListLikedYou(ctx, request)
+
+		assert.Nil(t, response)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "page size must be between 1 and 50", status.Convert(err).Message())
+	}
+	repo.AssertNotCalled(t, "GetLikedYou", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListLikedYou_DefaultPageSizeOption(t *testing.T) {
+	repo := new(repository.MockExploreRepository)
+	service := NewExploreService(repo, WithDefaultPageSize(25))
+
+	ctx := context.Background()
+	recipientUserID := "00000000-0000-0000-0000-000000000001"
+
+	request := &explore.ListLikedYouRequest{
+		RecipientUserId: recipientUserID,
+	}
+
+	repo.On("GetLikedYou", mock.Anything, recipientUserID, false, 26, (*repository.Cursor)(nil)).Return(likerRows(2), nil)
+
+	response, err := service.ListLikedYou(ctx, request)
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Likers, 2)
 	repo.AssertExpectations(t)
 }
 
@@ -60,7 +317,7 @@ func TestListLikedYou_InvalidPaginationToken(t *testing.T) {
 	_, service := setupServiceAndRepo()
 
 	ctx := context.Background()
-	recipientID := "test-recipient"
+	recipientID := "00000000-0000-0000-0000-000000000001"
 	paginationToken := "invalid"
 
 	request := &explore.ListLikedYouRequest{
@@ -79,23 +336,63 @@ func TestListNewLikedYou(t *testing.T) {
 	repo, service := setupServiceAndRepo()
 
 	ctx := context.Background()
-	recipientID := "test-recipient"
-	paginationToken := "0"
+	recipientID := "00000000-0000-0000-0000-000000000001"
 
 	request := &explore.ListLikedYouRequest{
 		RecipientUserId: recipientID,
-		PaginationToken: &paginationToken,
 	}
 
-	likers := []*explore.ListLikedYouResponse_Liker{{ActorId: "user1"}}
-	repo.On("GetNewLikedYou", mock.Anything, recipientID, 10, 0).Return(likers, nil)
+	rows := []repository.LikerRow{likerRow("user1", time.Unix(1700000000, 0))}
+	repo.On("GetNewLikedYou", mock.Anything, recipientID, false, 11, (*repository.Cursor)(nil)).Return(rows, nil)
 
 	response, err := service.ListNewLikedYou(ctx, request)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, response)
-	assert.Equal(t, likers, response.Likers)
-	assert.Equal(t, "10", *response.NextPaginationToken)
+	assert.Equal(t, []*explore.ListLikedYouResponse_Liker{rows[0].Liker}, response.Likers)
+	assert.Nil(t, response.NextPaginationToken)
+	repo.AssertExpectations(t)
+}
+
+func TestListNewLikedYou_MoreResults(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	recipientID := "00000000-0000-0000-0000-000000000001"
+
+	request := &explore.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+	}
+
+	rows := likerRows(11)
+	repo.On("GetNewLikedYou", mock.Anything, recipientID, false, 11, (*repository.Cursor)(nil)).Return(rows, nil)
+
+	response, err := service.ListNewLikedYou(ctx, request)
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Likers, 10)
+	assert.NotNil(t, response.NextPaginationToken)
+	repo.AssertExpectations(t)
+}
+
+func TestListNewLikedYou_ExactMultipleOfPageSize(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	recipientID := "00000000-0000-0000-0000-000000000001"
+
+	request := &explore.ListLikedYouRequest{
+		RecipientUserId: recipientID,
+	}
+
+	rows := likerRows(10)
+	repo.On("GetNewLikedYou", mock.Anything, recipientID, false, 11, (*repository.Cursor)(nil)).Return(rows, nil)
+
+	response, err := service.ListNewLikedYou(ctx, request)
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Likers, 10)
+	assert.Nil(t, response.NextPaginationToken)
 	repo.AssertExpectations(t)
 }
 
@@ -116,7 +413,7 @@ func TestListNewLikedYou_InvalidPaginationToken(t *testing.T) {
 	_, service := setupServiceAndRepo()
 
 	ctx := context.Background()
-	recipientID := "test-recipient"
+	recipientID := "00000000-0000-0000-0000-000000000001"
 	paginationToken := "invalid"
 
 	request := &explore.ListLikedYouRequest{
@@ -134,7 +431,7 @@ func TestListNewLikedYou_InvalidPaginationToken(t *testing.T) {
 func TestCountLikedYou(t *testing.T) {
 	repo, service := setupServiceAndRepo()
 	ctx := context.Background()
-	recipientID := "recipient-user"
+	recipientID := "00000000-0000-0000-0000-000000000001"
 	expectedCount := int64(5)
 
 	repo.On("CountLikes", ctx, recipientID).Return(expectedCount, nil)
@@ -151,7 +448,7 @@ func TestCountLikedYou_Error(t *testing.T) {
 	repo, service := setupServiceAndRepo()
 
 	ctx := context.Background()
-	recipientID := "test-recipient"
+	recipientID := "00000000-0000-0000-0000-000000000001"
 
 	request := &explore.CountLikedYouRequest{
 		RecipientUserId: recipientID,
@@ -169,8 +466,8 @@ func TestCountLikedYou_Error(t *testing.T) {
 func TestPutDecision_LikedRecipient(t *testing.T) {
 	repo, service := setupServiceAndRepo()
 	ctx := context.Background()
-	actorID := "actor-user"
-	recipientID := "recipient-user"
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
 	likedRecipient := true
 
 	request := &explore.PutDecisionRequest{
@@ -179,23 +476,18 @@ func TestPutDecision_LikedRecipient(t *testing.T) {
 		LikedRecipient:  likedRecipient,
 	}
 
-	// Use sqlmock to create a valid mock transaction
-	db, mock, err := sqlmock.New()
-	assert.NoError(t, err)
-	defer db.Close()
-
-	mock.ExpectBegin()
-	mockTx, err := db.Begin()
-	assert.NoError(t, err)
+	mockTx := &repository.MockTx{}
 
 	// Mocking the repository methods
-	repo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("IsBlocked", ctx, mockTx, actorID, recipientID).Return(false, nil)
 	repo.On("InsertDecision", ctx, mockTx, actorID, recipientID, likedRecipient).Return(nil)
 	repo.On("InsertLike", ctx, mockTx, actorID, recipientID).Return(nil)
 	repo.On("CheckMutualLike", ctx, mockTx, actorID, recipientID).Return(true, nil)
-
-	// Expect the transaction to commit
-	mock.ExpectCommit()
+	expectOutboxEvent(repo, ctx, mockTx, outbox.EventDecisionRecorded,
+		`{"actor_user_id":"`+actorID+`","recipient_user_id":"`+recipientID+`","liked_recipient":true}`)
+	expectOutboxEvent(repo, ctx, mockTx, outbox.EventMatchCreated,
+		`{"user_ids":["`+recipientID+`","`+actorID+`"]}`)
 
 	response, err := service.PutDecision(ctx, request)
 
@@ -203,14 +495,14 @@ func TestPutDecision_LikedRecipient(t *testing.T) {
 	assert.NotNil(t, response)
 	assert.True(t, response.MutualLikes)
 	repo.AssertExpectations(t)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.True(t, mockTx.Committed)
 }
 
 func TestPutDecision_NotLikedRecipient(t *testing.T) {
 	repo, service := setupServiceAndRepo()
 	ctx := context.Background()
-	actorID := "actor-user"
-	recipientID := "recipient-user"
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
 	likedRecipient := false
 
 	request := &explore.PutDecisionRequest{
@@ -219,22 +511,15 @@ func TestPutDecision_NotLikedRecipient(t *testing.T) {
 		LikedRecipient:  likedRecipient,
 	}
 
-	// Use sqlmock to create a valid mock transaction
-	db, mock, err := sqlmock.New()
-	assert.NoError(t, err)
-	defer db.Close()
-
-	mock.ExpectBegin()
-	mockTx, err := db.Begin()
-	assert.NoError(t, err)
+	mockTx := &repository.MockTx{}
 
 	// Mocking the repository methods
-	repo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("IsBlocked", ctx, mockTx, actorID, recipientID).Return(false, nil)
 	repo.On("InsertDecision", ctx, mockTx, actorID, recipientID, likedRecipient).Return(nil)
 	repo.On("DeleteLike", ctx, mockTx, actorID, recipientID).Return(nil)
-
-	// Expect the transaction to commit
-	mock.ExpectCommit()
+	expectOutboxEvent(repo, ctx, mockTx, outbox.EventDecisionRecorded,
+		`{"actor_user_id":"`+actorID+`","recipient_user_id":"`+recipientID+`","liked_recipient":false}`)
 
 	response, err := service.PutDecision(ctx, request)
 
@@ -242,14 +527,14 @@ func TestPutDecision_NotLikedRecipient(t *testing.T) {
 	assert.NotNil(t, response)
 	assert.False(t, response.MutualLikes)
 	repo.AssertExpectations(t)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.True(t, mockTx.Committed)
 }
 
 func TestPutDecision_InsertDecisionError(t *testing.T) {
 	repo, service := setupServiceAndRepo()
 	ctx := context.Background()
-	actorID := "actor-user"
-	recipientID := "recipient-user"
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
 	likedRecipient := true
 
 	request := &explore.PutDecisionRequest{
@@ -258,36 +543,27 @@ func TestPutDecision_InsertDecisionError(t *testing.T) {
 		LikedRecipient:  likedRecipient,
 	}
 
-	// Use sqlmock to create a valid mock transaction
-	db, mock, err := sqlmock.New()
-	assert.NoError(t, err)
-	defer db.Close()
-
-	mock.ExpectBegin()
-	mockTx, err := db.Begin()
-	assert.NoError(t, err)
+	mockTx := &repository.MockTx{}
 
 	// Mocking the repository methods
-	repo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("IsBlocked", ctx, mockTx, actorID, recipientID).Return(false, nil)
 	repo.On("InsertDecision", ctx, mockTx, actorID, recipientID, likedRecipient).Return(status.Errorf(codes.Internal, "insert decision error"))
 
-	// Expect the transaction to rollback
-	mock.ExpectRollback()
-
 	response, err := service.PutDecision(ctx, request)
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
 	assert.Equal(t, codes.Internal, status.Code(err))
 	repo.AssertExpectations(t)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.True(t, mockTx.RolledBack)
 }
 
 func TestPutDecision_InsertLikeError(t *testing.T) {
 	repo, service := setupServiceAndRepo()
 	ctx := context.Background()
-	actorID := "actor-user"
-	recipientID := "recipient-user"
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
 	likedRecipient := true
 
 	request := &explore.PutDecisionRequest{
@@ -296,37 +572,59 @@ func TestPutDecision_InsertLikeError(t *testing.T) {
 		LikedRecipient:  likedRecipient,
 	}
 
-	// Use sqlmock to create a valid mock transaction
-	db, mock, err := sqlmock.New()
-	assert.NoError(t, err)
-	defer db.Close()
-
-	mock.ExpectBegin()
-	mockTx, err := db.Begin()
-	assert.NoError(t, err)
+	mockTx := &repository.MockTx{}
 
 	// Mocking the repository methods
-	repo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("IsBlocked", ctx, mockTx, actorID, recipientID).Return(false, nil)
 	repo.On("InsertDecision", ctx, mockTx, actorID, recipientID, likedRecipient).Return(nil)
 	repo.On("InsertLike", ctx, mockTx, actorID, recipientID).Return(status.Errorf(codes.Internal, "insert like error"))
 
-	// Expect the transaction to rollback
-	mock.ExpectRollback()
-
 	response, err := service.PutDecision(ctx, request)
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
 	assert.Equal(t, codes.Internal, status.Code(err))
 	repo.AssertExpectations(t)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.True(t, mockTx.RolledBack)
+}
+
+func TestPutDecision_QueueEventsError(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
+
+	request := &explore.PutDecisionRequest{
+		ActorUserId:     actorID,
+		RecipientUserId: recipientID,
+		LikedRecipient:  false,
+	}
+
+	mockTx := &repository.MockTx{}
+
+	// Mocking the repository methods
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("IsBlocked", ctx, mockTx, actorID, recipientID).Return(false, nil)
+	repo.On("InsertDecision", ctx, mockTx, actorID, recipientID, false).Return(nil)
+	repo.On("DeleteLike", ctx, mockTx, actorID, recipientID).Return(nil)
+	repo.On("InsertOutboxEvent", ctx, mockTx, outbox.EventDecisionRecorded, mock.Anything).
+		Return(fmt.Errorf("failed to insert outbox event: %w", repository.ErrUnavailable))
+
+	// The decision must not commit without its events
+	response, err := service.PutDecision(ctx, request)
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	repo.AssertExpectations(t)
+	assert.True(t, mockTx.RolledBack)
 }
 
 func TestPutDecision_CheckMutualLikeError(t *testing.T) {
 	repo, service := setupServiceAndRepo()
 	ctx := context.Background()
-	actorID := "actor-user"
-	recipientID := "recipient-user"
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
 	likedRecipient := true
 
 	request := &explore.PutDecisionRequest{
@@ -335,29 +633,876 @@ func TestPutDecision_CheckMutualLikeError(t *testing.T) {
 		LikedRecipient:  likedRecipient,
 	}
 
-	// Use sqlmock to create a valid mock transaction
-	db, mock, err := sqlmock.New()
-	assert.NoError(t, err)
-	defer db.Close()
-
-	mock.ExpectBegin()
-	mockTx, err := db.Begin()
-	assert.NoError(t, err)
+	mockTx := &repository.MockTx{}
 
 	// Mocking the repository methods
-	repo.On("BeginTransaction", ctx).Return(mockTx, nil)
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("IsBlocked", ctx, mockTx, actorID, recipientID).Return(false, nil)
 	repo.On("InsertDecision", ctx, mockTx, actorID, recipientID, likedRecipient).Return(nil)
 	repo.On("InsertLike", ctx, mockTx, actorID, recipientID).Return(nil)
 	repo.On("CheckMutualLike", ctx, mockTx, actorID, recipientID).Return(false, status.Errorf(codes.Internal, "check mutual like error"))
 
-	// Expect the transaction to rollback
-	mock.ExpectRollback()
-
 	response, err := service.PutDecision(ctx, request)
 
 	assert.Error(t, err)
 	assert.Nil(t, response)
 	assert.Equal(t, codes.Internal, status.Code(err))
 	repo.AssertExpectations(t)
-	assert.NoError(t, mock.ExpectationsWereMet())
+	assert.True(t, mockTx.RolledBack)
+}
+
+func TestPutDecision_TransactionAborted(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+
+	request := &explore.PutDecisionRequest{
+		ActorUserId:     "00000000-0000-0000-0000-000000000002",
+		RecipientUserId: "00000000-0000-0000-0000-000000000001",
+		LikedRecipient:  true,
+	}
+
+	// The transaction runner gave up retrying after repeated serialization failures
+	aborted := fmt.Errorf("failed to commit transaction: %w", repository.ErrAborted)
+	repo.On("WithTx", ctx).Return(nil, aborted)
+
+	response, err := service.PutDecision(ctx, request)
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.Aborted, status.Code(err))
+	assert.Equal(t, "failed to commit transaction: aborted", status.Convert(err).Message())
+	repo.AssertExpectations(t)
+}
+
+func TestListLikedYou_MalformedRecipientID(t *testing.T) {
+	_, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	request := &explore.ListLikedYouRequest{RecipientUserId: "not-a-uuid"}
+
+	response, err := service.ListLikedYou(ctx, request)
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "recipient user ID must be a valid UUID", status.Convert(err).Message())
+}
+
+func TestCountLikedYou_InvalidRecipientID(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+
+	for _, recipientID := range []string{"", "not-a-uuid"} {
+		request := &explore.CountLikedYouRequest{RecipientUserId: recipientID}
+		response, err := service.CountLikedYou(ctx, request)
+
+		assert.Nil(t, response)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+	repo.AssertNotCalled(t, "CountLikes", mock.Anything, mock.Anything)
+}
+
+func TestPutDecision_InvalidUserIDs(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	validID := "00000000-0000-0000-0000-000000000001"
+
+	testCases := []struct {
+		name        string
+		actorID     string
+		recipientID string
+		message     string
+	}{
+		{"missing actor", "", validID, "actor user ID is required"},
+		{"malformed actor", "actor", validID, "actor user ID must be a valid UUID"},
+		{"missing recipient", validID, "", "recipient user ID is required"},
+		{"malformed recipient", validID, "recipient", "recipient user ID must be a valid UUID"},
+		{"self decision", validID, validID, "actor user ID and recipient user ID must be different users"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := &explore.PutDecisionRequest{
+				ActorUserId:     testCase.actorID,
+				RecipientUserId: testCase.recipientID,
+				LikedRecipient:  true,
+			}
+
+			response, err := service.PutDecision(ctx, request)
+
+			assert.Nil(t, response)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Equal(t, testCase.message, status.Convert(err).Message())
+		})
+	}
+	repo.AssertNotCalled(t, "WithTx", mock.Anything)
+}
+
+func TestCountLikedYou_RepositoryErrorCodes(t *testing.T) {
+	ctx := context.Background()
+	recipientID := "00000000-0000-0000-0000-000000000001"
+
+	testCases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{fmt.Errorf("failed to count likes: %w", repository.ErrNotFound), codes.NotFound},
+		{fmt.Errorf("failed to count likes: %w", repository.ErrUnavailable), codes.Unavailable},
+		{fmt.Errorf("failed to count likes: %w", repository.ErrTimeout), codes.DeadlineExceeded},
+		{fmt.Errorf("failed to count likes: %w", context.DeadlineExceeded), codes.DeadlineExceeded},
+		{fmt.Errorf("failed to count likes: boom"), codes.Internal},
+	}
+
+	for _, testCase := range testCases {
+		repo, service := setupServiceAndRepo()
+		repo.On("CountLikes", mock.Anything, recipientID).Return(int64(0), testCase.err)
+
+		response, err := service.CountLikedYou(ctx, &explore.CountLikedYouRequest{RecipientUserId: recipientID})
+
+		assert.Nil(t, response)
+		assert.Equal(t, testCase.code, status.Code(err), testCase.err.Error())
+	}
+}
+
+func TestPutDecision_UnknownUser(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
+
+	request := &explore.PutDecisionRequest{
+		ActorUserId:     actorID,
+		RecipientUserId: recipientID,
+		LikedRecipient:  true,
+	}
+
+	mockTx := &repository.MockTx{}
+
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("IsBlocked", ctx, mockTx, actorID, recipientID).Return(false, nil)
+	repo.On("InsertDecision", ctx, mockTx, actorID, recipientID, true).
+		Return(fmt.Errorf("failed to insert decision: %w", repository.ErrInvalidReference))
+
+	response, err := service.PutDecision(ctx, request)
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	repo.AssertExpectations(t)
+	assert.True(t, mockTx.RolledBack)
+}
+
+func matchRows(n int) []repository.MatchRow {
+	rows := make([]repository.MatchRow, 0, n)
+	for i := 0; i < n; i++ {
+		userID := fmt.Sprintf("match%d", i)
+		matchedAt := time.Unix(int64(1700000000-i), 0)
+		rows = append(rows, repository.MatchRow{
+			Match:  &explore.ListMatchesResponse_Match{UserId: userID, UnixTimestamp: uint64(matchedAt.Unix())},
+			Cursor: repository.Cursor{CreatedAt: matchedAt.UTC(), UserID: userID},
+		})
+	}
+	return rows
+}
+
+func TestListMatches(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+
+	rows := matchRows(11)
+	repo.On("GetMatches", mock.Anything, userID, 11, (*repository.Cursor)(nil)).Return(rows, nil)
+
+	response, err := service.ListMatches(ctx, &explore.ListMatchesRequest{UserId: userID})
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Matches, 10)
+	assert.Equal(t, rows[0].Match, response.Matches[0])
+	assert.NotNil(t, response.NextPaginationToken)
+
+	cursor, err := decodePageToken(response.GetNextPaginationToken(), listMatches, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, rows[9].Cursor, *cursor)
+	repo.AssertExpectations(t)
+}
+
+func TestListMatches_LastPage(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+
+	repo.On("GetMatches", mock.Anything, userID, 11, (*repository.Cursor)(nil)).Return(matchRows(2), nil)
+
+	response, err := service.ListMatches(ctx, &explore.ListMatchesRequest{UserId: userID})
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Matches, 2)
+	assert.Nil(t, response.NextPaginationToken)
+	repo.AssertExpectations(t)
+}
+
+func TestListMatches_TokenFromLikerList(t *testing.T) {
+	_, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+	paginationToken := encodePageToken(listLikedYou, userID, repository.Cursor{CreatedAt: time.Unix(1700000000, 0).UTC(), UserID: "user1"})
+
+	response, err := service.ListMatches(ctx, &explore.ListMatchesRequest{UserId: userID, PaginationToken: &paginationToken})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestCountMatches(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+
+	repo.On("CountMatches", mock.Anything, userID).Return(int64(3), nil)
+
+	response, err := service.CountMatches(ctx, &explore.CountMatchesRequest{UserId: userID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), response.Count)
+	repo.AssertExpectations(t)
+}
+
+func TestCountMatches_InvalidUserID(t *testing.T) {
+	_, service := setupServiceAndRepo()
+
+	response, err := service.CountMatches(context.Background(), &explore.CountMatchesRequest{UserId: "nope"})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "user ID must be a valid UUID", status.Convert(err).Message())
+}
+
+func TestUnmatch(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
+
+	mockTx := &repository.MockTx{}
+
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("Unmatch", ctx, mockTx, actorID, recipientID).Return(nil)
+
+	response, err := service.Unmatch(ctx, &explore.UnmatchRequest{ActorUserId: actorID, RecipientUserId: recipientID})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	repo.AssertExpectations(t)
+	assert.True(t, mockTx.Committed)
+}
+
+func TestUnmatch_NotMatched(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
+
+	mockTx := &repository.MockTx{}
+
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("Unmatch", ctx, mockTx, actorID, recipientID).
+		Return(fmt.Errorf("failed to unmatch: %w: users are not matched", repository.ErrNotFound))
+
+	response, err := service.Unmatch(ctx, &explore.UnmatchRequest{ActorUserId: actorID, RecipientUserId: recipientID})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	repo.AssertExpectations(t)
+	assert.True(t, mockTx.RolledBack)
+}
+
+func TestUnmatch_SameUser(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	userID := "00000000-0000-0000-0000-000000000001"
+
+	response, err := service.Unmatch(context.Background(), &explore.UnmatchRequest{ActorUserId: userID, RecipientUserId: userID})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	repo.AssertNotCalled(t, "WithTx", mock.Anything)
+}
+
+func TestPutDecision_BlockedUser(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
+
+	request := &explore.PutDecisionRequest{
+		ActorUserId:     actorID,
+		RecipientUserId: recipientID,
+		LikedRecipient:  true,
+	}
+
+	mockTx := &repository.MockTx{}
+
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("IsBlocked", ctx, mockTx, actorID, recipientID).Return(true, nil)
+
+	response, err := service.PutDecision(ctx, request)
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	repo.AssertExpectations(t)
+	assert.True(t, mockTx.RolledBack)
+}
+
+func TestBlockUser(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	blockedID := "00000000-0000-0000-0000-000000000001"
+
+	repo.On("BlockUser", ctx, actorID, blockedID).Return(nil)
+
+	response, err := service.BlockUser(ctx, &explore.BlockUserRequest{ActorUserId: actorID, BlockedUserId: blockedID})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	repo.AssertExpectations(t)
+}
+
+func TestBlockUser_Self(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	userID := "00000000-0000-0000-0000-000000000001"
+
+	response, err := service.BlockUser(context.Background(), &explore.BlockUserRequest{ActorUserId: userID, BlockedUserId: userID})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	repo.AssertNotCalled(t, "BlockUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUnblockUser(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	blockedID := "00000000-0000-0000-0000-000000000001"
+
+	repo.On("UnblockUser", ctx, actorID, blockedID).Return(nil)
+
+	response, err := service.UnblockUser(ctx, &explore.UnblockUserRequest{ActorUserId: actorID, BlockedUserId: blockedID})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	repo.AssertExpectations(t)
+}
+
+func TestReportUser(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	reportedID := "00000000-0000-0000-0000-000000000001"
+
+	repo.On("ReportUser", ctx, actorID, reportedID, "spam").Return(nil)
+
+	response, err := service.ReportUser(ctx, &explore.ReportUserRequest{ActorUserId: actorID, ReportedUserId: reportedID, Reason: "spam"})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	repo.AssertExpectations(t)
+}
+
+func TestReportUser_MissingReason(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	reportedID := "00000000-0000-0000-0000-000000000001"
+
+	response, err := service.ReportUser(context.Background(), &explore.ReportUserRequest{ActorUserId: actorID, ReportedUserId: reportedID, Reason: "  "})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "reason is required", status.Convert(err).Message())
+	repo.AssertNotCalled(t, "ReportUser", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func decisionRows(n int, liked bool) []repository.DecisionRow {
+	rows := make([]repository.DecisionRow, 0, n)
+	for i := 0; i < n; i++ {
+		recipientID := fmt.Sprintf("recipient%d", i)
+		decidedAt := time.Unix(int64(1700000000-i), 0)
+		rows = append(rows, repository.DecisionRow{
+			Decision: &explore.ListDecisionsResponse_Decision{
+				RecipientUserId: recipientID,
+				LikedRecipient:  liked,
+				UnixTimestamp:   uint64(decidedAt.Unix()),
+			},
+			Cursor: repository.Cursor{CreatedAt: decidedAt.UTC(), UserID: recipientID},
+		})
+	}
+	return rows
+}
+
+func TestListDecisions(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	filter := explore.DecisionFilter_DECISION_FILTER_LIKES
+
+	rows := decisionRows(11, true)
+	repo.On("GetDecisions", mock.Anything, actorID, filter, 11, (*repository.Cursor)(nil)).Return(rows, nil)
+
+	response, err := service.ListDecisions(ctx, &explore.ListDecisionsRequest{ActorUserId: actorID, Filter: filter})
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Decisions, 10)
+	assert.Equal(t, rows[0].Decision, response.Decisions[0])
+	assert.NotNil(t, response.NextPaginationToken)
+	repo.AssertExpectations(t)
+
+	// The token is bound to the filter it was issued for
+	_, err = service.ListDecisions(ctx, &explore.ListDecisionsRequest{
+		ActorUserId:     actorID,
+		Filter:          explore.DecisionFilter_DECISION_FILTER_PASSES,
+		PaginationToken: response.NextPaginationToken,
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListDecisions_NextPage(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	filter := explore.DecisionFilter_DECISION_FILTER_ALL
+	cursor := repository.Cursor{CreatedAt: time.UnixMicro(1700000000123456).UTC(), UserID: "recipient9"}
+	paginationToken := encodePageToken(decisionsList(filter), actorID, cursor)
+
+	repo.On("GetDecisions", mock.Anything, actorID, filter, 11, &cursor).Return(decisionRows(3, false), nil)
+
+	response, err := service.ListDecisions(ctx, &explore.ListDecisionsRequest{ActorUserId: actorID, PaginationToken: &paginationToken})
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Decisions, 3)
+	assert.Nil(t, response.NextPaginationToken)
+	repo.AssertExpectations(t)
+}
+
+func TestListDecisions_UnknownFilter(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	request := &explore.ListDecisionsRequest{
+		ActorUserId: "00000000-0000-0000-0000-000000000002",
+		Filter:      explore.DecisionFilter(42),
+	}
+
+	response, err := service.ListDecisions(context.Background(), request)
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	repo.AssertNotCalled(t, "GetDecisions", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func candidateRows(n int, likedYou bool) []repository.CandidateRow {
+	rows := make([]repository.CandidateRow, 0, n)
+	for i := 0; i < n; i++ {
+		candidateID := fmt.Sprintf("candidate%d", i)
+		rankedAt := time.Unix(int64(1700000000-i), 0).UTC()
+		rows = append(rows, repository.CandidateRow{
+			Candidate: &explore.GetExploreFeedResponse_Candidate{
+				UserId:   candidateID,
+				Username: "user " + candidateID,
+				LikedYou: likedYou,
+			},
+			Cursor: repository.FeedCursor{LikedYou: likedYou, Cursor: repository.Cursor{CreatedAt: rankedAt, UserID: candidateID}},
+		})
+	}
+	return rows
+}
+
+func TestGetExploreFeed(t *testing.T) {
+	repo := new(repository.MockExploreRepository)
+	reverse := RankingFunc(func(ctx context.Context, userID string, candidates []repository.CandidateRow) []repository.CandidateRow {
+		slices.Reverse(candidates)
+		return candidates
+	})
+	service := NewExploreService(repo, WithRankingStrategy(reverse))
+
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+	pageSize := uint32(3)
+
+	rows := candidateRows(4, true)
+	repo.On("GetExploreFeed", mock.Anything, userID, repository.FeedOrderLikersFirst, 4, (*repository.FeedCursor)(nil)).Return(rows, nil)
+
+	response, err := service.GetExploreFeed(ctx, &explore.GetExploreFeedRequest{UserId: userID, PageSize: &pageSize})
+
+	assert.NoError(t, err)
+	require.Len(t, response.Candidates, 3)
+	assert.Equal(t, "candidate2", response.Candidates[0].UserId)
+	assert.Equal(t, "candidate0", response.Candidates[2].UserId)
+	repo.AssertExpectations(t)
+
+	// The next page starts after the last candidate read, whatever the ranking
+	require.NotNil(t, response.NextPaginationToken)
+	after, err := decodeFeedPageToken(*response.NextPaginationToken, userID, repository.FeedOrderLikersFirst)
+	require.NoError(t, err)
+	assert.Equal(t, repository.FeedCursor{LikedYou: true, Cursor: repository.Cursor{CreatedAt: time.Unix(1699999998, 0).UTC(), UserID: "candidate2"}}, *after)
+}
+
+func TestGetExploreFeed_NextPage(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	ctx := context.Background()
+	userID := "00000000-0000-0000-0000-000000000001"
+	cursor := repository.FeedCursor{
+		LikedYou: true,
+		Cursor:   repository.Cursor{CreatedAt: time.UnixMicro(1700000000123456).UTC(), UserID: "candidate9"},
+	}
+	paginationToken := encodeFeedPageToken(userID, repository.FeedOrderLikersFirst, cursor)
+
+	repo.On("GetExploreFeed", mock.Anything, userID, repository.FeedOrderLikersFirst, 11, &cursor).Return(candidateRows(2, false), nil)
+
+	response, err := service.GetExploreFeed(ctx, &explore.GetExploreFeedRequest{UserId: userID, PaginationToken: &paginationToken})
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Candidates, 2)
+	assert.Nil(t, response.NextPaginationToken)
+	repo.AssertExpectations(t)
+}
+
+func TestGetExploreFeed_InvalidPaginationToken(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	userID := "00000000-0000-0000-0000-000000000001"
+	matchesToken := encodePageToken(listMatches, userID, repository.Cursor{CreatedAt: time.Unix(1, 0), UserID: "user"})
+
+	response, err := service.GetExploreFeed(context.Background(), &explore.GetExploreFeedRequest{UserId: userID, PaginationToken: &matchesToken})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	repo.AssertNotCalled(t, "GetExploreFeed", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// newestUsersFirst is a custom strategy that has the repository read the feed newest user first.
+type newestUsersFirst struct{}
+
+func (newestUsersFirst) FeedOrder() repository.FeedOrder { return repository.FeedOrderNewestFirst }
+
+func (newestUsersFirst) Rank(ctx context.Context, userID string, candidates []repository.CandidateRow) []repository.CandidateRow {
+	return candidates
+}
+
+func TestGetExploreFeed_FeedOrderChangesPages(t *testing.T) {
+	const (
+		requester = "00000000-0000-0000-0000-000000000001"
+		liker     = "00000000-0000-0000-0000-000000000002"
+		older     = "00000000-0000-0000-0000-000000000003"
+		newer     = "00000000-0000-0000-0000-000000000004"
+	)
+	repo := repository.NewMemoryRepository()
+	// Users are added one at a time so they join in this order
+	for _, userID := range []string{requester, liker, older, newer} {
+		repo.AddUsers(userID)
+		time.Sleep(time.Millisecond)
+	}
+	ctx := context.Background()
+	_, err := NewExploreService(repo).PutDecision(ctx, &explore.PutDecisionRequest{ActorUserId: liker, RecipientUserId: requester, LikedRecipient: true})
+	require.NoError(t, err)
+
+	readFeed := func(strategy RankingStrategy) []string {
+		service := NewExploreService(repo, WithRankingStrategy(strategy))
+		pageSize := uint32(1)
+		request := &explore.GetExploreFeedRequest{UserId: requester, PageSize: &pageSize}
+		var candidateIDs []string
+		for {
+			response, err := service.GetExploreFeed(ctx, request)
+			require.NoError(t, err)
+			for _, candidate := range response.Candidates {
+				candidateIDs = append(candidateIDs, candidate.UserId)
+			}
+			if response.NextPaginationToken == nil {
+				return candidateIDs
+			}
+			request.PaginationToken = response.NextPaginationToken
+		}
+	}
+
+	// Reordering one-candidate pages changes nothing, but the feed order decides what each page holds
+	reverse := RankingFunc(func(ctx context.Context, userID string, candidates []repository.CandidateRow) []repository.CandidateRow {
+		slices.Reverse(candidates)
+		return candidates
+	})
+	assert.Equal(t, []string{liker, newer, older}, readFeed(LikersFirst))
+	assert.Equal(t, []string{liker, newer, older}, readFeed(reverse))
+	assert.Equal(t, []string{newer, older, liker}, readFeed(newestUsersFirst{}))
+	assert.Equal(t, []string{newer, older, liker}, readFeed(NewestFirst))
+}
+
+func TestGetExploreFeed_RejectsTokensOfAnotherFeedOrder(t *testing.T) {
+	repo := new(repository.MockExploreRepository)
+	service := NewExploreService(repo, WithRankingStrategy(NewestFirst))
+
+	userID := "00000000-0000-0000-0000-000000000001"
+	likersFirstToken := encodeFeedPageToken(userID, repository.FeedOrderLikersFirst, repository.FeedCursor{
+		LikedYou: true,
+		Cursor:   repository.Cursor{CreatedAt: time.Unix(1, 0), UserID: "candidate"},
+	})
+
+	response, err := service.GetExploreFeed(context.Background(), &explore.GetExploreFeedRequest{UserId: userID, PaginationToken: &likersFirstToken})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	repo.AssertNotCalled(t, "GetExploreFeed", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLikersFirst(t *testing.T) {
+	likers := candidateRows(2, true)
+	others := candidateRows(2, false)
+	candidates := []repository.CandidateRow{others[1], likers[1], others[0], likers[0]}
+
+	ranked := LikersFirst.Rank(context.Background(), "user", candidates)
+
+	assert.Equal(t, []repository.CandidateRow{likers[0], likers[1], others[0], others[1]}, ranked)
+}
+
+func TestCountNewLikedYou(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	recipientID := "00000000-0000-0000-0000-000000000001"
+
+	repo.On("CountNewLikes", ctx, recipientID).Return(int64(2), nil)
+
+	response, err := service.CountNewLikedYou(ctx, &explore.CountLikedYouRequest{RecipientUserId: recipientID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), response.Count)
+	repo.AssertExpectations(t)
+}
+
+func TestCountNewLikedYou_InvalidRecipientID(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+
+	response, err := service.CountNewLikedYou(context.Background(), &explore.CountLikedYouRequest{})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	repo.AssertNotCalled(t, "CountNewLikes", mock.Anything, mock.Anything)
+}
+
+// fakeEventHub records published events and hands out a single subscription channel.
+type fakeEventHub struct {
+	published map[string][]*explore.ExploreEvent
+	events    chan *explore.ExploreEvent
+	cancelled bool
+}
+
+func newFakeEventHub() *fakeEventHub {
+	return &fakeEventHub{
+		published: make(map[string][]*explore.ExploreEvent),
+		events:    make(chan *explore.ExploreEvent, 1),
+	}
+}
+
+func (h *fakeEventHub) Publish(_ context.Context, userID string, event *explore.ExploreEvent) error {
+	h.published[userID] = append(h.published[userID], event)
+	return nil
+}
+
+func (h *fakeEventHub) Subscribe(string) (<-chan *explore.ExploreEvent, func()) {
+	return h.events, func() { h.cancelled = true }
+}
+
+// fakeEventStream captures the events sent on a SubscribeExploreEvents stream.
+type fakeEventStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*explore.ExploreEvent
+}
+
+func (s *fakeEventStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeEventStream) Send(event *explore.ExploreEvent) error {
+	s.sent = append(s.sent, event)
+	return nil
+}
+
+func TestPutDecision_PublishesEvents(t *testing.T) {
+	repo := new(repository.MockExploreRepository)
+	hub := newFakeEventHub()
+	service := NewExploreService(repo, WithEventHub(hub))
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
+
+	request := &explore.PutDecisionRequest{
+		ActorUserId:     actorID,
+		RecipientUserId: recipientID,
+		LikedRecipient:  true,
+	}
+
+	mockTx := &repository.MockTx{}
+
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("IsBlocked", ctx, mockTx, actorID, recipientID).Return(false, nil)
+	repo.On("InsertDecision", ctx, mockTx, actorID, recipientID, true).Return(nil)
+	repo.On("InsertLike", ctx, mockTx, actorID, recipientID).Return(nil)
+	repo.On("CheckMutualLike", ctx, mockTx, actorID, recipientID).Return(true, nil)
+	repo.On("InsertOutboxEvent", ctx, mockTx, outbox.EventDecisionRecorded, mock.Anything).Return(nil)
+	repo.On("InsertOutboxEvent", ctx, mockTx, outbox.EventMatchCreated, mock.Anything).Return(nil)
+
+	response, err := service.PutDecision(ctx, request)
+
+	assert.NoError(t, err)
+	assert.True(t, response.MutualLikes)
+
+	require.Len(t, hub.published[recipientID], 2)
+	assert.Equal(t, actorID, hub.published[recipientID][0].GetLikeReceived().GetActorId())
+	assert.Equal(t, actorID, hub.published[recipientID][1].GetMatchCreated().GetUserId())
+	require.Len(t, hub.published[actorID], 1)
+	assert.Equal(t, recipientID, hub.published[actorID][0].GetMatchCreated().GetUserId())
+	assert.True(t, mockTx.Committed)
+}
+
+func TestSubscribeExploreEvents(t *testing.T) {
+	repo := new(repository.MockExploreRepository)
+	hub := newFakeEventHub()
+	service := NewExploreService(repo, WithEventHub(hub))
+	userID := "00000000-0000-0000-0000-000000000001"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeEventStream{ctx: ctx}
+
+	event := &explore.ExploreEvent{
+		Event: &explore.ExploreEvent_LikeReceived_{LikeReceived: &explore.ExploreEvent_LikeReceived{ActorId: "someone"}},
+	}
+	hub.events <- event
+
+	done := make(chan error)
+	go func() {
+		done <- service.SubscribeExploreEvents(&explore.SubscribeExploreEventsRequest{UserId: userID}, stream)
+	}()
+
+	require.Eventually(t, func() bool { return len(hub.events) == 0 }, time.Second, time.Millisecond)
+	cancel()
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, []*explore.ExploreEvent{event}, stream.sent)
+	assert.True(t, hub.cancelled)
+}
+
+func TestSubscribeExploreEvents_Disabled(t *testing.T) {
+	_, service := setupServiceAndRepo()
+	stream := &fakeEventStream{ctx: context.Background()}
+
+	err := service.SubscribeExploreEvents(&explore.SubscribeExploreEventsRequest{UserId: "00000000-0000-0000-0000-000000000001"}, stream)
+
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestPutDecision_IdempotencyKeyFirstRequest(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
+	key := "swipe-1"
+
+	request := &explore.PutDecisionRequest{
+		ActorUserId:     actorID,
+		RecipientUserId: recipientID,
+		LikedRecipient:  true,
+		IdempotencyKey:  &key,
+	}
+
+	mockTx := &repository.MockTx{}
+
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("ReserveIdempotencyKey", ctx, mockTx, actorID, key, recipientID, true, DefaultIdempotencyKeyTTL).Return(nil, nil)
+	repo.On("IsBlocked", ctx, mockTx, actorID, recipientID).Return(false, nil)
+	repo.On("InsertDecision", ctx, mockTx, actorID, recipientID, true).Return(nil)
+	repo.On("InsertLike", ctx, mockTx, actorID, recipientID).Return(nil)
+	repo.On("CheckMutualLike", ctx, mockTx, actorID, recipientID).Return(true, nil)
+	repo.On("InsertOutboxEvent", ctx, mockTx, mock.Anything, mock.Anything).Return(nil)
+	repo.On("CompleteIdempotencyKey", ctx, mockTx, actorID, key, true).Return(nil)
+
+	response, err := service.PutDecision(ctx, request)
+
+	assert.NoError(t, err)
+	assert.True(t, response.MutualLikes)
+	repo.AssertExpectations(t)
+	assert.True(t, mockTx.Committed)
+}
+
+func TestPutDecision_IdempotencyKeyReplay(t *testing.T) {
+	repo := new(repository.MockExploreRepository)
+	hub := newFakeEventHub()
+	service := NewExploreService(repo, WithEventHub(hub), WithIdempotencyKeyTTL(time.Hour))
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
+	key := "swipe-1"
+
+	request := &explore.PutDecisionRequest{
+		ActorUserId:     actorID,
+		RecipientUserId: recipientID,
+		LikedRecipient:  true,
+		IdempotencyKey:  &key,
+	}
+
+	mockTx := &repository.MockTx{}
+
+	original := &repository.IdempotentDecision{RecipientUserID: recipientID, LikedRecipient: true, MutualLikes: true}
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("ReserveIdempotencyKey", ctx, mockTx, actorID, key, recipientID, true, time.Hour).Return(original, nil)
+
+	response, err := service.PutDecision(ctx, request)
+
+	assert.NoError(t, err)
+	assert.True(t, response.MutualLikes)
+	repo.AssertExpectations(t)
+	// Nothing is written by a replay
+	repo.AssertNotCalled(t, "InsertDecision", ctx, mockTx, actorID, recipientID, true)
+	assert.Empty(t, hub.published)
+	assert.True(t, mockTx.Committed)
+}
+
+func TestPutDecision_IdempotencyKeyReusedForDifferentDecision(t *testing.T) {
+	repo, service := setupServiceAndRepo()
+	ctx := context.Background()
+	actorID := "00000000-0000-0000-0000-000000000002"
+	recipientID := "00000000-0000-0000-0000-000000000001"
+	key := "swipe-1"
+
+	request := &explore.PutDecisionRequest{
+		ActorUserId:     actorID,
+		RecipientUserId: recipientID,
+		LikedRecipient:  false,
+		IdempotencyKey:  &key,
+	}
+
+	mockTx := &repository.MockTx{}
+
+	original := &repository.IdempotentDecision{RecipientUserID: recipientID, LikedRecipient: true}
+	repo.On("WithTx", ctx).Return(mockTx, nil)
+	repo.On("ReserveIdempotencyKey", ctx, mockTx, actorID, key, recipientID, false, DefaultIdempotencyKeyTTL).Return(original, nil)
+
+	response, err := service.PutDecision(ctx, request)
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.True(t, mockTx.RolledBack)
+}
+
+func TestPutDecision_InvalidIdempotencyKey(t *testing.T) {
+	_, service := setupServiceAndRepo()
+	empty := ""
+
+	response, err := service.PutDecision(context.Background(), &explore.PutDecisionRequest{
+		ActorUserId:     "00000000-0000-0000-0000-000000000002",
+		RecipientUserId: "00000000-0000-0000-0000-000000000001",
+		IdempotencyKey:  &empty,
+	})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "idempotency key must be between 1 and 255 characters", status.Convert(err).Message())
 }
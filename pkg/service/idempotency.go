@@ -0,0 +1,22 @@
+package service
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/repository"
+	"time"
+)
+
+// DefaultIdempotencyKeyTTL is how long a PutDecision idempotency key is remembered by default.
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+// replayDecision answers a PutDecision retry with the response of the decision originally
+// recorded under its idempotency key. Reusing a key for a different decision is rejected, so a
+// retry can never silently apply or hide a change of mind.
+func replayDecision(request *explore.PutDecisionRequest, original *repository.IdempotentDecision) (*explore.PutDecisionResponse, error) {
+	if original.RecipientUserID != request.RecipientUserId || original.LikedRecipient != request.LikedRecipient {
+		return nil, status.Error(codes.FailedPrecondition, "idempotency key was already used for a different decision")
+	}
+	return &explore.PutDecisionResponse{MutualLikes: original.MutualLikes}, nil
+}
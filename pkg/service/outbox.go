@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"muzz-backend-challenge/pkg/outbox"
+	"muzz-backend-challenge/pkg/repository"
+	"time"
+)
+
+// queueDecisionEvents writes the events describing a decision to the outbox within the decision's
+// transaction, so they are published if and only if the decision commits.
+func (service *ExploreService) queueDecisionEvents(ctx context.Context, tx repository.Tx, actorUserID, recipientUserID string, likedRecipient, mutualLikes bool) error {
+	messages, err := decisionEvents(actorUserID, recipientUserID, likedRecipient, mutualLikes, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		if err := service.repository.InsertOutboxEvent(ctx, tx, message.EventType, message.Payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decisionEvents builds the outbox events describing a decision: a decision.recorded event and,
+// when the like completed a match, a match.created event.
+func decisionEvents(actorUserID, recipientUserID string, likedRecipient, mutualLikes bool, at time.Time) ([]repository.OutboxMessage, error) {
+	now := uint64(at.Unix())
+
+	decision, err := outboxMessage(outbox.EventDecisionRecorded, outbox.DecisionRecorded{
+		ActorUserID:     actorUserID,
+		RecipientUserID: recipientUserID,
+		LikedRecipient:  likedRecipient,
+		UnixTimestamp:   now,
+	})
+	if err != nil || !mutualLikes {
+		return []repository.OutboxMessage{decision}, err
+	}
+
+	match, err := outboxMessage(outbox.EventMatchCreated, outbox.MatchCreated{
+		UserIDs:       [2]string{recipientUserID, actorUserID},
+		UnixTimestamp: now,
+	})
+	return []repository.OutboxMessage{decision, match}, err
+}
+
+// outboxMessage encodes the payload of an outbox event.
+func outboxMessage(eventType string, payload interface{}) (repository.OutboxMessage, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return repository.OutboxMessage{}, fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+	return repository.OutboxMessage{EventType: eventType, Payload: encoded}, nil
+}
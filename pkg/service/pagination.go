@@ -0,0 +1,168 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/repository"
+	"time"
+)
+
+const (
+	// DefaultPageSize is the number of results returned when a request does not set a page size.
+	DefaultPageSize = 10
+	// MaxPageSize is the default upper bound on the page size a client may request.
+	MaxPageSize = 100
+)
+
+// listKind identifies the list a pagination token was issued for, so a token
+// from one list cannot be replayed against another.
+type listKind string
+
+const (
+	listLikedYou    listKind = "liked_you"
+	listNewLikedYou listKind = "new_liked_you"
+	listMatches     listKind = "matches"
+	listExploreFeed listKind = "explore_feed"
+)
+
+// feedList returns the list kind for an explore feed read in the given order, so a token
+// issued for one order cannot be used with another.
+func feedList(order repository.FeedOrder) listKind {
+	if order == repository.FeedOrderLikersFirst {
+		return listExploreFeed
+	}
+	return listKind(string(listExploreFeed) + ":" + order.String())
+}
+
+// decisionsList returns the list kind for a decision list, so a token issued for one
+// filter cannot be used with another.
+func decisionsList(filter explore.DecisionFilter) listKind {
+	return listKind("decisions:" + filter.String())
+}
+
+// errInvalidPageToken is returned when a pagination token cannot be decoded
+// or was issued for a different user or list.
+var errInvalidPageToken = errors.New("invalid pagination token")
+
+// pageToken is the decoded form of an opaque pagination token.
+type pageToken struct {
+	List      listKind `json:"l"`
+	Owner     string   `json:"o"`
+	CreatedAt int64    `json:"t"`
+	UserID    string   `json:"u"`
+	// LikedYou is only set in explore feed tokens, see repository.FeedCursor
+	LikedYou bool `json:"k,omitempty"`
+}
+
+// encodePageToken builds an opaque token that resumes the given list after the cursor.
+func encodePageToken(list listKind, owner string, cursor repository.Cursor) string {
+	return encodeToken(pageToken{
+		List:      list,
+		Owner:     owner,
+		CreatedAt: cursor.CreatedAt.UnixMicro(),
+		UserID:    cursor.UserID,
+	})
+}
+
+// encodeFeedPageToken builds an opaque token that resumes the owner's explore feed, read in the
+// given order, after the cursor.
+func encodeFeedPageToken(owner string, order repository.FeedOrder, cursor repository.FeedCursor) string {
+	return encodeToken(pageToken{
+		List:      feedList(order),
+		Owner:     owner,
+		CreatedAt: cursor.CreatedAt.UnixMicro(),
+		UserID:    cursor.UserID,
+		LikedYou:  cursor.LikedYou,
+	})
+}
+
+func encodeToken(token pageToken) string {
+	payload, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodePageToken parses a token produced by encodePageToken. An empty token
+// yields a nil cursor, meaning the first page.
+func decodePageToken(token string, list listKind, owner string) (*repository.Cursor, error) {
+	decoded, err := parsePageToken(token, list, owner)
+	if decoded == nil {
+		return nil, err
+	}
+
+	return &repository.Cursor{
+		CreatedAt: time.UnixMicro(decoded.CreatedAt).UTC(),
+		UserID:    decoded.UserID,
+	}, nil
+}
+
+// decodeFeedPageToken parses a token produced by encodeFeedPageToken. An empty token
+// yields a nil cursor, meaning the first page.
+func decodeFeedPageToken(token string, owner string, order repository.FeedOrder) (*repository.FeedCursor, error) {
+	decoded, err := parsePageToken(token, feedList(order), owner)
+	if decoded == nil {
+		return nil, err
+	}
+
+	return &repository.FeedCursor{
+		LikedYou: decoded.LikedYou,
+		Cursor: repository.Cursor{
+			CreatedAt: time.UnixMicro(decoded.CreatedAt).UTC(),
+			UserID:    decoded.UserID,
+		},
+	}, nil
+}
+
+// parsePageToken decodes a token and checks it was issued for the given list and owner. An
+// empty token yields nil.
+func parsePageToken(token string, list listKind, owner string) (*pageToken, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	var decoded pageToken
+	if err := json.Unmarshal(payload, &decoded); err != nil {
+		return nil, errInvalidPageToken
+	}
+
+	if decoded.List != list || decoded.Owner != owner || decoded.UserID == "" {
+		return nil, errInvalidPageToken
+	}
+
+	return &decoded, nil
+}
+
+// pageSize resolves the requested page size, falling back to the service default
+// and rejecting values outside 1..maxPageSize.
+func (service ExploreService) pageSize(requested *uint32) (int, error) {
+	if requested == nil {
+		return service.defaultPageSize, nil
+	}
+
+	size := int(*requested)
+	if size < 1 || size > service.maxPageSize {
+		return 0, status.Errorf(codes.InvalidArgument, "page size must be between 1 and %d", service.maxPageSize)
+	}
+
+	return size, nil
+}
+
+// trimPage cuts rows fetched with a limit of pageSize+1 down to pageSize. When the extra
+// row was present, it returns a token that resumes the list after the last kept row.
+func trimPage[T any](rows []T, pageSize int, list listKind, owner string, cursor func(T) repository.Cursor) ([]T, *string) {
+	if len(rows) <= pageSize {
+		return rows, nil
+	}
+
+	rows = rows[:pageSize]
+	token := encodePageToken(list, owner, cursor(rows[len(rows)-1]))
+	return rows, &token
+}
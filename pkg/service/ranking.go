@@ -0,0 +1,75 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"muzz-backend-challenge/pkg/repository"
+	"slices"
+)
+
+// RankingStrategy orders the candidates of an explore feed page before they are returned.
+//
+// The repository reads the feed in pages, likers of the user first unless the strategy also
+// implements FeedOrderer, and each page is ranked on its own: Rank decides the order within a
+// page, the feed order which candidates make up the page. Rank must return the candidates it is
+// given, in any order, and may reorder the slice in place.
+type RankingStrategy interface {
+	Rank(ctx context.Context, userID string, candidates []repository.CandidateRow) []repository.CandidateRow
+}
+
+// FeedOrderer is implemented by ranking strategies that have the repository read the explore
+// feed in another order than repository.FeedOrderLikersFirst.
+type FeedOrderer interface {
+	FeedOrder() repository.FeedOrder
+}
+
+// feedOrder returns the order the repository reads the feed in for the given strategy.
+func feedOrder(strategy RankingStrategy) repository.FeedOrder {
+	if orderer, ok := strategy.(FeedOrderer); ok {
+		return orderer.FeedOrder()
+	}
+	return repository.FeedOrderLikersFirst
+}
+
+// RankingFunc adapts an ordinary function to a RankingStrategy.
+type RankingFunc func(ctx context.Context, userID string, candidates []repository.CandidateRow) []repository.CandidateRow
+
+// Rank calls f(ctx, userID, candidates).
+func (f RankingFunc) Rank(ctx context.Context, userID string, candidates []repository.CandidateRow) []repository.CandidateRow {
+	return f(ctx, userID, candidates)
+}
+
+// LikersFirst is the default ranking: users who liked the requester, most recent like first,
+// then everyone else, newest user first. It is the order the repository reads the feed in.
+var LikersFirst RankingStrategy = RankingFunc(func(ctx context.Context, userID string, candidates []repository.CandidateRow) []repository.CandidateRow {
+	slices.SortStableFunc(candidates, func(a, b repository.CandidateRow) int {
+		if a.Cursor.LikedYou != b.Cursor.LikedYou {
+			if a.Cursor.LikedYou {
+				return -1
+			}
+			return 1
+		}
+		if c := b.Cursor.CreatedAt.Compare(a.Cursor.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.Cursor.UserID, a.Cursor.UserID)
+	})
+	return candidates
+})
+
+// NewestFirst ranks all candidates newest user first, without putting the users who liked the
+// requester first. It has the repository read the feed in that order.
+var NewestFirst RankingStrategy = orderedRanking(repository.FeedOrderNewestFirst)
+
+// orderedRanking keeps the order the repository reads the feed in.
+type orderedRanking repository.FeedOrder
+
+// FeedOrder returns the order itself.
+func (o orderedRanking) FeedOrder() repository.FeedOrder {
+	return repository.FeedOrder(o)
+}
+
+// Rank returns the candidates unchanged.
+func (o orderedRanking) Rank(ctx context.Context, userID string, candidates []repository.CandidateRow) []repository.CandidateRow {
+	return candidates
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/repository"
+	"time"
+)
+
+// UserService implements the UserServiceServer interface.
+type UserService struct {
+	repository repository.UserRepository
+	explore.UnimplementedUserServiceServer
+}
+
+// NewUserService creates a new instance of UserService.
+func NewUserService(repo repository.UserRepository) *UserService {
+	return &UserService{repository: repo}
+}
+
+// CreateUser creates a user with the given profile and returns it with its new ID.
+//
+// The username must not be taken by another user, or AlreadyExists is returned.
+func (service UserService) CreateUser(
+	ctx context.Context,
+	request *explore.CreateUserRequest,
+) (*explore.CreateUserResponse, error) {
+	profile := repository.UserProfile{
+		Username:    request.GetUsername(),
+		DisplayName: request.GetDisplayName(),
+		Birthdate:   request.GetBirthdate(),
+		Gender:      request.GetGender(),
+		Bio:         request.GetBio(),
+	}
+	if err := validateUserProfile(profile); err != nil {
+		return nil, err
+	}
+
+	user, err := service.repository.CreateUser(ctx, profile)
+	if err != nil {
+		return nil, statusFromUserError("failed to create user", err)
+	}
+	return &explore.CreateUserResponse{User: user}, nil
+}
+
+// GetUser retrieves a user and their profile.
+func (service UserService) GetUser(
+	ctx context.Context,
+	request *explore.GetUserRequest,
+) (*explore.GetUserResponse, error) {
+	if err := validateUUID("user ID", request.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	user, err := service.repository.GetUser(ctx, request.UserId)
+	if err != nil {
+		return nil, statusFromError("failed to get user", err)
+	}
+	return &explore.GetUserResponse{User: user}, nil
+}
+
+// UpdateUser changes the profile fields set in the request and returns the updated user.
+//
+// Fields that are not set keep their value. A new username must not be taken by another user,
+// or AlreadyExists is returned.
+func (service UserService) UpdateUser(
+	ctx context.Context,
+	request *explore.UpdateUserRequest,
+) (*explore.UpdateUserResponse, error) {
+	if err := validateUUID("user ID", request.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	update := repository.UserUpdate{
+		Username:    request.Username,
+		DisplayName: request.DisplayName,
+		Birthdate:   request.Birthdate,
+		Gender:      request.Gender,
+		Bio:         request.Bio,
+	}
+	if err := validateUserUpdate(update); err != nil {
+		return nil, err
+	}
+
+	user, err := service.repository.UpdateUser(ctx, request.UserId, update)
+	if err != nil {
+		return nil, statusFromUserError("failed to update user", err)
+	}
+	return &explore.UpdateUserResponse{User: user}, nil
+}
+
+// DeleteUser deletes a user.
+//
+// Users who still have likes, decisions, blocks or reports cannot be deleted, and
+// FailedPrecondition is returned; DeleteUserData deletes them along with their data.
+func (service UserService) DeleteUser(
+	ctx context.Context,
+	request *explore.DeleteUserRequest,
+) (*explore.DeleteUserResponse, error) {
+	if err := validateUUID("user ID", request.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	if err := service.repository.DeleteUser(ctx, request.UserId); err != nil {
+		if errors.Is(err, repository.ErrInvalidReference) {
+			return nil, status.Error(codes.FailedPrecondition, "failed to delete user: user still has likes, decisions, blocks or reports, use DeleteUserData")
+		}
+		return nil, statusFromError("failed to delete user", err)
+	}
+	return &explore.DeleteUserResponse{}, nil
+}
+
+// DeleteUserData deletes a user and all their data in one go: their likes, decisions, unmatches,
+// blocks, idempotency keys and the events naming them that are still in the outbox or queued
+// for webhooks are deleted, and their ID is removed from the reports they filed or received,
+// which are kept for moderation.
+func (service UserService) DeleteUserData(
+	ctx context.Context,
+	request *explore.DeleteUserDataRequest,
+) (*explore.DeleteUserDataResponse, error) {
+	if err := validateUUID("user ID", request.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	if _, err := service.repository.DeleteUserData(ctx, request.UserId); err != nil {
+		return nil, statusFromError("failed to delete user data", err)
+	}
+	return &explore.DeleteUserDataResponse{}, nil
+}
+
+// userDataDocument is the JSON document returned by ExportUserData.
+type userDataDocument struct {
+	User          userDocument                  `json:"user"`
+	LikesGiven    []repository.UserDataLike     `json:"likes_given"`
+	LikesReceived []repository.UserDataLike     `json:"likes_received"`
+	Decisions     []repository.UserDataDecision `json:"decisions"`
+	Matches       []repository.UserDataMatch    `json:"matches"`
+}
+
+type userDocument struct {
+	UserID      string    `json:"user_id"`
+	Username    string    `json:"username"`
+	DisplayName string    `json:"display_name"`
+	Birthdate   string    `json:"birthdate"`
+	Gender      string    `json:"gender"`
+	Bio         string    `json:"bio"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ExportUserData returns everything stored about a user as a JSON document: their profile, the
+// likes they gave and received, their decisions and their matches, oldest first.
+func (service UserService) ExportUserData(
+	ctx context.Context,
+	request *explore.ExportUserDataRequest,
+) (*explore.ExportUserDataResponse, error) {
+	if err := validateUUID("user ID", request.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	data, err := service.repository.ExportUserData(ctx, request.UserId)
+	if err != nil {
+		return nil, statusFromError("failed to export user data", err)
+	}
+
+	document, err := json.Marshal(userDataDocument{
+		User: userDocument{
+			UserID:      data.User.GetUserId(),
+			Username:    data.User.GetUsername(),
+			DisplayName: data.User.GetDisplayName(),
+			Birthdate:   data.User.GetBirthdate(),
+			Gender:      data.User.GetGender().String(),
+			Bio:         data.User.GetBio(),
+			CreatedAt:   time.Unix(int64(data.User.GetUnixTimestamp()), 0).UTC(),
+		},
+		LikesGiven:    orEmpty(data.LikesGiven),
+		LikesReceived: orEmpty(data.LikesReceived),
+		Decisions:     orEmpty(data.Decisions),
+		Matches:       orEmpty(data.Matches),
+	})
+	if err != nil {
+		return nil, statusFromError("failed to encode user data", err)
+	}
+	return &explore.ExportUserDataResponse{Document: string(document)}, nil
+}
+
+// orEmpty returns an empty slice for nil, so that it is encoded as [] rather than null.
+func orEmpty[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// statusFromUserError is statusFromError with a taken username reported as AlreadyExists,
+// the only conflict a user write can run into.
+func statusFromUserError(msg string, err error) error {
+	if errors.Is(err, repository.ErrConflict) {
+		return status.Errorf(codes.AlreadyExists, "%s: username is already taken", msg)
+	}
+	return statusFromError(msg, err)
+}
+
+// validateUserProfile checks every field of a new user's profile.
+func validateUserProfile(profile repository.UserProfile) error {
+	if err := validateUsername(profile.Username); err != nil {
+		return err
+	}
+	if err := validateProfileText("display name", profile.DisplayName, maxDisplayNameLength); err != nil {
+		return err
+	}
+	if err := validateBirthdate(profile.Birthdate); err != nil {
+		return err
+	}
+	if err := validateGender(profile.Gender); err != nil {
+		return err
+	}
+	return validateProfileText("bio", profile.Bio, maxBioLength)
+}
+
+// validateUserUpdate checks the fields of a profile update that are set.
+func validateUserUpdate(update repository.UserUpdate) error {
+	if update.Username != nil {
+		if err := validateUsername(*update.Username); err != nil {
+			return err
+		}
+	}
+	if update.DisplayName != nil {
+		if err := validateProfileText("display name", *update.DisplayName, maxDisplayNameLength); err != nil {
+			return err
+		}
+	}
+	if update.Birthdate != nil {
+		if err := validateBirthdate(*update.Birthdate); err != nil {
+			return err
+		}
+	}
+	if update.Gender != nil {
+		if err := validateGender(*update.Gender); err != nil {
+			return err
+		}
+	}
+	if update.Bio != nil {
+		return validateProfileText("bio", *update.Bio, maxBioLength)
+	}
+	return nil
+}
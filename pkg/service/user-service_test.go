@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+func setupUserService() (*repository.MockUserRepository, *UserService) {
+	repo := new(repository.MockUserRepository)
+	return repo, NewUserService(repo)
+}
+
+const testUserID = "00000000-0000-0000-0000-000000000001"
+
+func TestCreateUser(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+	profile := repository.UserProfile{
+		Username:    "alice",
+		DisplayName: "Alice",
+		Birthdate:   "1990-04-01",
+		Gender:      explore.Gender_GENDER_FEMALE,
+		Bio:         "Hi",
+	}
+	user := &explore.User{UserId: testUserID, Username: "alice", DisplayName: "Alice"}
+
+	repo.On("CreateUser", ctx, profile).Return(user, nil)
+
+	response, err := service.CreateUser(ctx, &explore.CreateUserRequest{
+		Username:    "alice",
+		DisplayName: "Alice",
+		Birthdate:   "1990-04-01",
+		Gender:      explore.Gender_GENDER_FEMALE,
+		Bio:         "Hi",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, response.User)
+	repo.AssertExpectations(t)
+}
+
+func TestCreateUser_UsernameTaken(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+
+	repo.On("CreateUser", ctx, repository.UserProfile{Username: "alice"}).
+		Return(nil, fmt.Errorf("failed to create user: %w", repository.ErrConflict))
+
+	response, err := service.CreateUser(ctx, &explore.CreateUserRequest{Username: "alice"})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestCreateUser_InvalidProfile(t *testing.T) {
+	_, service := setupUserService()
+
+	tests := []struct {
+		name    string
+		request *explore.CreateUserRequest
+	}{
+		{"missing username", &explore.CreateUserRequest{}},
+		{"short username", &explore.CreateUserRequest{Username: "al"}},
+		{"long username", &explore.CreateUserRequest{Username: strings.Repeat("a", maxUsernameLength+1)}},
+		{"username with spaces", &explore.CreateUserRequest{Username: "alice smith"}},
+		{"long display name", &explore.CreateUserRequest{Username: "alice", DisplayName: strings.Repeat("é", maxDisplayNameLength+1)}},
+		{"malformed birthdate", &explore.CreateUserRequest{Username: "alice", Birthdate: "01/04/1990"}},
+		{"future birthdate", &explore.CreateUserRequest{Username: "alice", Birthdate: "2999-01-01"}},
+		{"unknown gender", &explore.CreateUserRequest{Username: "alice", Gender: explore.Gender(42)}},
+		{"long bio", &explore.CreateUserRequest{Username: "alice", Bio: strings.Repeat("a", maxBioLength+1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := service.CreateUser(context.Background(), tt.request)
+
+			assert.Nil(t, response)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+	user := &explore.User{UserId: testUserID, Username: "alice"}
+
+	repo.On("GetUser", ctx, testUserID).Return(user, nil)
+
+	response, err := service.GetUser(ctx, &explore.GetUserRequest{UserId: testUserID})
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, response.User)
+}
+
+func TestGetUser_NotFound(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+
+	repo.On("GetUser", ctx, testUserID).Return(nil, fmt.Errorf("failed to get user: %w", repository.ErrNotFound))
+
+	response, err := service.GetUser(ctx, &explore.GetUserRequest{UserId: testUserID})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGetUser_InvalidUserID(t *testing.T) {
+	repo, service := setupUserService()
+
+	response, err := service.GetUser(context.Background(), &explore.GetUserRequest{UserId: "not-a-uuid"})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	repo.AssertNotCalled(t, "GetUser", mock.Anything, mock.Anything)
+}
+
+func TestUpdateUser(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+	user := &explore.User{UserId: testUserID, Username: "alice", Bio: "Updated"}
+
+	repo.On("UpdateUser", ctx, testUserID, repository.UserUpdate{Bio: proto.String("Updated"), Birthdate: proto.String("")}).Return(user, nil)
+
+	response, err := service.UpdateUser(ctx, &explore.UpdateUserRequest{
+		UserId:    testUserID,
+		Bio:       proto.String("Updated"),
+		Birthdate: proto.String(""),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, response.User)
+	repo.AssertExpectations(t)
+}
+
+func TestUpdateUser_UsernameTaken(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+
+	repo.On("UpdateUser", ctx, testUserID, repository.UserUpdate{Username: proto.String("bob")}).
+		Return(nil, fmt.Errorf("failed to update user: %w", repository.ErrConflict))
+
+	response, err := service.UpdateUser(ctx, &explore.UpdateUserRequest{UserId: testUserID, Username: proto.String("bob")})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+func TestUpdateUser_InvalidField(t *testing.T) {
+	repo, service := setupUserService()
+
+	response, err := service.UpdateUser(context.Background(), &explore.UpdateUserRequest{
+		UserId:   testUserID,
+		Username: proto.String(""),
+	})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	repo.AssertNotCalled(t, "UpdateUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteUser(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+
+	repo.On("DeleteUser", ctx, testUserID).Return(nil)
+
+	_, err := service.DeleteUser(ctx, &explore.DeleteUserRequest{UserId: testUserID})
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestDeleteUser_StillReferenced(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+
+	repo.On("DeleteUser", ctx, testUserID).Return(fmt.Errorf("failed to delete user: %w", repository.ErrInvalidReference))
+
+	response, err := service.DeleteUser(ctx, &explore.DeleteUserRequest{UserId: testUserID})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestDeleteUserData(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+
+	repo.On("DeleteUserData", ctx, testUserID).Return([]string{}, nil)
+
+	_, err := service.DeleteUserData(ctx, &explore.DeleteUserDataRequest{UserId: testUserID})
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestDeleteUserData_NotFound(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+
+	repo.On("DeleteUserData", ctx, testUserID).Return(nil, fmt.Errorf("failed to delete user data: %w", repository.ErrNotFound))
+
+	response, err := service.DeleteUserData(ctx, &explore.DeleteUserDataRequest{UserId: testUserID})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestExportUserData(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+	otherUserID := "00000000-0000-0000-0000-000000000002"
+	likedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	likedBackAt := likedAt.Add(time.Hour)
+
+	repo.On("ExportUserData", ctx, testUserID).Return(&repository.UserData{
+		User: &explore.User{
+			UserId:        testUserID,
+			Username:      "alice",
+			Gender:        explore.Gender_GENDER_FEMALE,
+			UnixTimestamp: uint64(likedAt.Unix()),
+		},
+		LikesGiven:    []repository.UserDataLike{{UserID: otherUserID, CreatedAt: likedAt}},
+		LikesReceived: []repository.UserDataLike{{UserID: otherUserID, CreatedAt: likedBackAt}},
+		Matches:       []repository.UserDataMatch{{UserID: otherUserID, MatchedAt: likedBackAt}},
+	}, nil)
+
+	response, err := service.ExportUserData(ctx, &explore.ExportUserDataRequest{UserId: testUserID})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"user": {
+			"user_id": "00000000-0000-0000-0000-000000000001",
+			"username": "alice",
+			"display_name": "",
+			"birthdate": "",
+			"gender": "GENDER_FEMALE",
+			"bio": "",
+			"created_at": "2024-05-01T12:00:00Z"
+		},
+		"likes_given": [{"user_id": "00000000-0000-0000-0000-000000000002", "created_at": "2024-05-01T12:00:00Z"}],
+		"likes_received": [{"user_id": "00000000-0000-0000-0000-000000000002", "created_at": "2024-05-01T13:00:00Z"}],
+		"decisions": [],
+		"matches": [{"user_id": "00000000-0000-0000-0000-000000000002", "matched_at": "2024-05-01T13:00:00Z"}]
+	}`, response.Document)
+}
+
+func TestExportUserData_NotFound(t *testing.T) {
+	repo, service := setupUserService()
+	ctx := context.Background()
+
+	repo.On("ExportUserData", ctx, testUserID).Return(nil, fmt.Errorf("failed to get user: %w", repository.ErrNotFound))
+
+	response, err := service.ExportUserData(ctx, &explore.ExportUserDataRequest{UserId: testUserID})
+
+	assert.Nil(t, response)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/webhook"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxReportReasonLength is the longest report reason, in characters, that is accepted.
+const maxReportReasonLength = 1000
+
+// maxIdempotencyKeyLength is the longest idempotency key, in characters, that is accepted.
+const maxIdempotencyKeyLength = 255
+
+// minWebhookSecretLength is the shortest webhook signing secret, in characters, that is accepted.
+const minWebhookSecretLength = 16
+
+// Bounds on the user profile fields, in characters. Display names are limited by their column.
+const (
+	minUsernameLength    = 3
+	maxUsernameLength    = 30
+	maxDisplayNameLength = 100
+	maxBioLength         = 500
+)
+
+// validateUUID checks that an ID is present and is a well-formed UUID.
+// The field name is used in the InvalidArgument message returned to the client.
+func validateUUID(field, id string) error {
+	if id == "" {
+		return status.Errorf(codes.InvalidArgument, "%s is required", field)
+	}
+
+	if _, err := uuid.Parse(id); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%s must be a valid UUID", field)
+	}
+
+	return nil
+}
+
+// validateUserPair checks both IDs of an actor/recipient pair and rejects a user
+// acting on themselves.
+func validateUserPair(actorField, actorUserID, recipientField, recipientUserID string) error {
+	if err := validateUUID(actorField, actorUserID); err != nil {
+		return err
+	}
+
+	if err := validateUUID(recipientField, recipientUserID); err != nil {
+		return err
+	}
+
+	if uuid.MustParse(actorUserID) == uuid.MustParse(recipientUserID) {
+		return status.Errorf(codes.InvalidArgument, "%s and %s must be different users", actorField, recipientField)
+	}
+
+	return nil
+}
+
+// validateReportReason checks that a report carries a non-blank reason of reasonable length.
+func validateReportReason(reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return status.Error(codes.InvalidArgument, "reason is required")
+	}
+
+	if utf8.RuneCountInString(reason) > maxReportReasonLength {
+		return status.Errorf(codes.InvalidArgument, "reason must be at most %d characters", maxReportReasonLength)
+	}
+
+	return nil
+}
+
+// validateIdempotencyKey checks that an idempotency key, when set, is neither empty nor too long.
+func validateIdempotencyKey(key *string) error {
+	if key == nil {
+		return nil
+	}
+
+	if length := utf8.RuneCountInString(*key); length == 0 || length > maxIdempotencyKeyLength {
+		return status.Errorf(codes.InvalidArgument, "idempotency key must be between 1 and %d characters", maxIdempotencyKeyLength)
+	}
+
+	return nil
+}
+
+// validateWebhookURL checks that a webhook endpoint is an absolute http or https URL.
+func validateWebhookURL(endpoint string) error {
+	if endpoint == "" {
+		return status.Error(codes.InvalidArgument, "url is required")
+	}
+
+	parsed, err := url.Parse(endpoint)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return status.Error(codes.InvalidArgument, "url must be an absolute http or https URL")
+	}
+
+	// Endpoints are called from inside our network, so they must not point back into it. Host
+	// names are checked again once resolved, when deliveries are sent.
+	host := strings.ToLower(parsed.Hostname())
+	ip := net.ParseIP(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || (ip != nil && !webhook.PublicIP(ip)) {
+		return status.Error(codes.InvalidArgument, "url must not point to a loopback, private or link-local address")
+	}
+
+	return nil
+}
+
+// validateWebhookSecret checks that a caller-provided signing secret is long enough to be
+// hard to guess. An empty secret is accepted and replaced by a generated one.
+func validateWebhookSecret(secret string) error {
+	if secret != "" && utf8.RuneCountInString(secret) < minWebhookSecretLength {
+		return status.Errorf(codes.InvalidArgument, "secret must be at least %d characters", minWebhookSecretLength)
+	}
+	return nil
+}
+
+// validateUsername checks that a username has a reasonable length and only contains ASCII
+// letters, digits, dots, underscores and hyphens.
+func validateUsername(username string) error {
+	if username == "" {
+		return status.Error(codes.InvalidArgument, "username is required")
+	}
+
+	if length := len(username); length < minUsernameLength || length > maxUsernameLength {
+		return status.Errorf(codes.InvalidArgument, "username must be between %d and %d characters", minUsernameLength, maxUsernameLength)
+	}
+
+	for _, r := range username {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-') {
+			return status.Error(codes.InvalidArgument, "username may only contain letters, digits, dots, underscores and hyphens")
+		}
+	}
+
+	return nil
+}
+
+// validateProfileText checks that a free text profile field is at most maxLength characters.
+func validateProfileText(field, value string, maxLength int) error {
+	if utf8.RuneCountInString(value) > maxLength {
+		return status.Errorf(codes.InvalidArgument, "%s must be at most %d characters", field, maxLength)
+	}
+	return nil
+}
+
+// validateBirthdate checks that a birthdate, when set, is a YYYY-MM-DD date that is not in the future.
+func validateBirthdate(birthdate string) error {
+	if birthdate == "" {
+		return nil
+	}
+
+	date, err := time.Parse(time.DateOnly, birthdate)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "birthdate must be a date formatted as YYYY-MM-DD")
+	}
+
+	if date.After(time.Now()) {
+		return status.Error(codes.InvalidArgument, "birthdate must not be in the future")
+	}
+
+	return nil
+}
+
+// validateGender checks that a gender is one of the values of the Gender enum.
+func validateGender(gender explore.Gender) error {
+	if _, ok := explore.Gender_name[int32(gender)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown gender %d", gender)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+// generatedSecretBytes is the number of random bytes in a generated webhook secret.
+const generatedSecretBytes = 32
+
+// WebhookAdminService implements the WebhookAdminServiceServer interface.
+type WebhookAdminService struct {
+	repository repository.WebhookRepository
+	explore.UnimplementedWebhookAdminServiceServer
+}
+
+// NewWebhookAdminService creates a new instance of WebhookAdminService.
+func NewWebhookAdminService(repo repository.WebhookRepository) *WebhookAdminService {
+	return &WebhookAdminService{repository: repo}
+}
+
+// RegisterWebhookEndpoint registers an endpoint to receive match events.
+//
+// The response carries the secret used to sign the payloads sent to the endpoint. When the
+// request does not provide one, a random secret is generated; it cannot be retrieved later.
+func (service WebhookAdminService) RegisterWebhookEndpoint(
+	ctx context.Context,
+	request *explore.RegisterWebhookEndpointRequest,
+) (*explore.RegisterWebhookEndpointResponse, error) {
+	if err := validateWebhookURL(request.GetUrl()); err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhookSecret(request.GetSecret()); err != nil {
+		return nil, err
+	}
+
+	secret := request.GetSecret()
+	if secret == "" {
+		generated := make([]byte, generatedSecretBytes)
+		if _, err := rand.Read(generated); err != nil {
+			log.Printf("Failed to generate webhook secret: %v", err)
+			return nil, status.Error(codes.Internal, "failed to generate webhook secret")
+		}
+		secret = hex.EncodeToString(generated)
+	}
+
+	endpoint, err := service.repository.CreateWebhookEndpoint(ctx, request.Url, secret)
+	if err != nil {
+		log.Printf("Failed to create webhook endpoint: %v", err)
+		return nil, statusFromError("failed to create webhook endpoint", err)
+	}
+
+	return &explore.RegisterWebhookEndpointResponse{Endpoint: endpoint, Secret: secret}, nil
+}
+
+// ListWebhookEndpoints retrieves every registered endpoint, including disabled ones.
+func (service WebhookAdminService) ListWebhookEndpoints(
+	ctx context.Context,
+	_ *explore.ListWebhookEndpointsRequest,
+) (*explore.ListWebhookEndpointsResponse, error) {
+	endpoints, err := service.repository.ListWebhookEndpoints(ctx)
+	if err != nil {
+		return nil, statusFromError("failed to list webhook endpoints", err)
+	}
+	return &explore.ListWebhookEndpointsResponse{Endpoints: endpoints}, nil
+}
+
+// DisableWebhookEndpoint stops sending events to an endpoint.
+//
+// Deliveries already queued for the endpoint are not attempted. Disabling an endpoint that is
+// already disabled succeeds.
+func (service WebhookAdminService) DisableWebhookEndpoint(
+	ctx context.Context,
+	request *explore.DisableWebhookEndpointRequest,
+) (*explore.DisableWebhookEndpointResponse, error) {
+	if err := validateUUID("endpoint ID", request.GetEndpointId()); err != nil {
+		return nil, err
+	}
+
+	if err := service.repository.DisableWebhookEndpoint(ctx, request.EndpointId); err != nil {
+		return nil, statusFromError("failed to disable webhook endpoint", err)
+	}
+	return &explore.DisableWebhookEndpointResponse{}, nil
+}
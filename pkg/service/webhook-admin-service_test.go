@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	explore "muzz-backend-challenge/pkg/proto"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+func setupWebhookAdminService() (*repository.MockWebhookRepository, *WebhookAdminService) {
+	repo := new(repository.MockWebhookRepository)
+	return repo, NewWebhookAdminService(repo)
+}
+
+func TestRegisterWebhookEndpoint(t *testing.T) {
+	repo, service := setupWebhookAdminService()
+	ctx := context.Background()
+	secret := "0123456789abcdef-secret"
+	endpoint := &explore.WebhookEndpoint{Id: "00000000-0000-0000-0000-0000000000aa", Url: "https://example.com/hooks", Enabled: true}
+
+	repo.On("CreateWebhookEndpoint", ctx, "https://example.com/hooks", secret).Return(endpoint, nil)
+
+	response, err := service.RegisterWebhookEndpoint(ctx, &explore.RegisterWebhookEndpointRequest{
+		Url:    "https://example.com/hooks",
+		Secret: secret,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, endpoint, response.Endpoint)
+	assert.Equal(t, secret, response.Secret)
+	repo.AssertExpectations(t)
+}
+
+func TestRegisterWebhookEndpoint_GeneratesSecret(t *testing.T) {
+	repo, service := setupWebhookAdminService()
+	ctx := context.Background()
+
+	var stored string
+	repo.On("CreateWebhookEndpoint", ctx, "http://hooks.example.com:9000", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { stored = args.String(2) }).
+		Return(&explore.WebhookEndpoint{Id: "00000000-0000-0000-0000-0000000000aa"}, nil)
+
+	response, err := service.RegisterWebhookEndpoint(ctx, &explore.RegisterWebhookEndpointRequest{Url: "http://hooks.example.com:9000"})
+
+	assert.NoError(t, err)
+	assert.Len(t, response.Secret, 2*generatedSecretBytes)
+	assert.Equal(t, stored, response.Secret)
+}
+
+func TestRegisterWebhookEndpoint_InvalidRequest(t *testing.T) {
+	_, service := setupWebhookAdminService()
+
+	tests := []struct {
+		name    string
+		request *explore.RegisterWebhookEndpointRequest
+		message string
+	}{
+		{"missing url", &explore.RegisterWebhookEndpointRequest{}, "url is required"},
+		{"relative url", &explore.RegisterWebhookEndpointRequest{Url: "/hooks"}, "url must be an absolute http or https URL"},
+		{"unsupported scheme", &explore.RegisterWebhookEndpointRequest{Url: "ftp://example.com"}, "url must be an absolute http or https URL"},
+		{"short secret", &explore.RegisterWebhookEndpointRequest{Url: "https://example.com", Secret: "short"}, "secret must be at least 16 characters"},
+		{"localhost", &explore.RegisterWebhookEndpointRequest{Url: "http://localhost:9000/hooks"}, "url must not point to a loopback, private or link-local address"},
+		{"loopback address", &explore.RegisterWebhookEndpointRequest{Url: "http://127.0.0.1/hooks"}, "url must not point to a loopback, private or link-local address"},
+		{"private address", &explore.RegisterWebhookEndpointRequest{Url: "https://10.1.2.3/hooks"}, "url must not point to a loopback, private or link-local address"},
+		{"link-local address", &explore.RegisterWebhookEndpointRequest{Url: "http://169.254.169.254/latest"}, "url must not point to a loopback, private or link-local address"},
+		{"private IPv6 address", &explore.RegisterWebhookEndpointRequest{Url: "http://[fd00::1]/hooks"}, "url must not point to a loopback, private or link-local address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := service.RegisterWebhookEndpoint(context.Background(), tt.request)
+
+			assert.Nil(t, response)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Equal(t, tt.message, status.Convert(err).Message())
+		})
+	}
+}
+
+func TestListWebhookEndpoints(t *testing.T) {
+	repo, service := setupWebhookAdminService()
+	ctx := context.Background()
+	endpoints := []*explore.WebhookEndpoint{
+		{Id: "00000000-0000-0000-0000-0000000000aa", Url: "https://example.com/a", Enabled: true},
+		{Id: "00000000-0000-0000-0000-0000000000bb", Url: "https://example.com/b", Enabled: false},
+	}
+
+	repo.On("ListWebhookEndpoints", ctx).Return(endpoints, nil)
+
+	response, err := service.ListWebhookEndpoints(ctx, &explore.ListWebhookEndpointsRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, endpoints, response.Endpoints)
+}
+
+func TestDisableWebhookEndpoint(t *testing.T) {
+	repo, service := setupWebhookAdminService()
+	ctx := context.Background()
+	endpointID := "00000000-0000-0000-0000-0000000000aa"
+
+	repo.On("DisableWebhookEndpoint", ctx, endpointID).Return(nil)
+
+	_, err := service.DisableWebhookEndpoint(ctx, &explore.DisableWebhookEndpointRequest{EndpointId: endpointID})
+
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestDisableWebhookEndpoint_NotFound(t *testing.T) {
+	repo, service := setupWebhookAdminService()
+	ctx := context.Background()
+	endpointID := "00000000-0000-0000-0000-0000000000aa"
+
+	repo.On("DisableWebhookEndpoint", ctx, endpointID).
+		Return(fmt.Errorf("failed to disable webhook endpoint: %w: endpoint does not exist", repository.ErrNotFound))
+
+	_, err := service.DisableWebhookEndpoint(ctx, &explore.DisableWebhookEndpointRequest{EndpointId: endpointID})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDisableWebhookEndpoint_InvalidID(t *testing.T) {
+	_, service := setupWebhookAdminService()
+
+	_, err := service.DisableWebhookEndpoint(context.Background(), &explore.DisableWebhookEndpointRequest{EndpointId: "not-a-uuid"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "endpoint ID must be a valid UUID", status.Convert(err).Message())
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned when a delivery would connect to an address that webhooks
+// must not reach, such as a host on the server's own network.
+var ErrForbiddenAddress = errors.New("webhook address is not public")
+
+// PublicIP reports whether ip is a public unicast address that webhooks may be sent to, as
+// opposed to a loopback, private, link-local, multicast or unspecified one.
+func PublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
+
+// NewHTTPClient creates a client for sending deliveries that refuses to connect to addresses
+// that are not public. The check runs on the address actually dialled, after DNS resolution,
+// so it also covers host names that resolve to internal addresses and redirects. Proxies from
+// the environment are not used, since they would connect on the client's behalf.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !PublicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
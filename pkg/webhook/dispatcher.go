@@ -0,0 +1,218 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"muzz-backend-challenge/pkg/outbox"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+const (
+	// DefaultBatchSize is the number of deliveries claimed per batch.
+	DefaultBatchSize = 50
+	// DefaultPollInterval is how long the dispatcher waits when no deliveries are due.
+	DefaultPollInterval = time.Second
+	// DefaultTimeout bounds a single delivery request.
+	DefaultTimeout = 10 * time.Second
+	// DefaultMaxAttempts is the number of attempts before a delivery is dead-lettered.
+	DefaultMaxAttempts = 10
+	// DefaultBaseBackoff is the delay before the first retry of a failed delivery.
+	DefaultBaseBackoff = 5 * time.Second
+	// DefaultMaxBackoff caps the delay between retries of a failed delivery.
+	DefaultMaxBackoff = time.Hour
+)
+
+// body is the JSON document POSTed to endpoints. ID identifies the event and is the same for
+// every endpoint and every retry, so receivers can discard duplicates.
+type body struct {
+	ID   int64           `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// Dispatcher POSTs queued deliveries to their endpoints.
+type Dispatcher struct {
+	store        repository.WebhookRepository
+	client       *http.Client
+	batchSize    int
+	pollInterval time.Duration
+	maxAttempts  int
+	baseBackoff  time.Duration
+	maxBackoff   time.Duration
+	now          func() time.Time
+}
+
+// Option configures optional settings of a Dispatcher.
+type Option func(*Dispatcher)
+
+// WithHTTPClient sets the client used to send deliveries. Its timeout bounds each attempt.
+// The default client, from NewHTTPClient, only connects to public addresses.
+func WithHTTPClient(client *http.Client) Option {
+	return func(dispatcher *Dispatcher) {
+		if client != nil {
+			dispatcher.client = client
+		}
+	}
+}
+
+// WithBatchSize sets the number of deliveries claimed per batch.
+func WithBatchSize(size int) Option {
+	return func(dispatcher *Dispatcher) {
+		if size > 0 {
+			dispatcher.batchSize = size
+		}
+	}
+}
+
+// WithPollInterval sets how long the dispatcher waits when no deliveries are due.
+func WithPollInterval(interval time.Duration) Option {
+	return func(dispatcher *Dispatcher) {
+		if interval > 0 {
+			dispatcher.pollInterval = interval
+		}
+	}
+}
+
+// WithMaxAttempts sets the number of attempts before a delivery is dead-lettered.
+func WithMaxAttempts(attempts int) Option {
+	return func(dispatcher *Dispatcher) {
+		if attempts > 0 {
+			dispatcher.maxAttempts = attempts
+		}
+	}
+}
+
+// WithBackoff sets the delay before the first retry of a failed delivery and the cap the
+// doubling delay never exceeds.
+func WithBackoff(base, max time.Duration) Option {
+	return func(dispatcher *Dispatcher) {
+		if base > 0 && max >= base {
+			dispatcher.baseBackoff = base
+			dispatcher.maxBackoff = max
+		}
+	}
+}
+
+// NewDispatcher creates a new Dispatcher delivering the deliveries queued in store.
+func NewDispatcher(store repository.WebhookRepository, opts ...Option) *Dispatcher {
+	dispatcher := &Dispatcher{
+		store:        store,
+		client:       NewHTTPClient(DefaultTimeout),
+		batchSize:    DefaultBatchSize,
+		pollInterval: DefaultPollInterval,
+		maxAttempts:  DefaultMaxAttempts,
+		baseBackoff:  DefaultBaseBackoff,
+		maxBackoff:   DefaultMaxBackoff,
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(dispatcher)
+	}
+	return dispatcher
+}
+
+// Run dispatches batches until ctx is done. Full batches are followed immediately by the next
+// one; otherwise the dispatcher waits for the poll interval.
+func (dispatcher *Dispatcher) Run(ctx context.Context) {
+	for {
+		dispatched, err := dispatcher.DispatchBatch(ctx)
+		if err != nil {
+			log.Printf("Failed to dispatch webhooks: %v", err)
+		}
+
+		if err == nil && dispatched == dispatcher.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(dispatcher.pollInterval):
+		}
+	}
+}
+
+// DispatchBatch claims a batch of due deliveries and attempts each of them. It returns the
+// number of deliveries claimed.
+func (dispatcher *Dispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	// Each attempt is bounded by the client timeout, so the lease covers a whole batch
+	lease := time.Duration(dispatcher.batchSize)*dispatcher.client.Timeout + time.Minute
+	deliveries, err := dispatcher.store.ClaimWebhookDeliveries(ctx, dispatcher.batchSize, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		dispatcher.attempt(ctx, delivery)
+	}
+
+	return len(deliveries), nil
+}
+
+// attempt sends a delivery and records the outcome. Failed deliveries are retried with
+// exponential backoff until they run out of attempts and are dead-lettered.
+func (dispatcher *Dispatcher) attempt(ctx context.Context, delivery repository.WebhookDelivery) {
+	err := dispatcher.send(ctx, delivery)
+	if err == nil {
+		if err := dispatcher.store.MarkWebhookDelivered(ctx, delivery.ID); err != nil {
+			log.Printf("Failed to mark webhook delivery %d delivered: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	if delivery.Attempts >= dispatcher.maxAttempts {
+		log.Printf("Dead-lettering webhook delivery %d after %d attempts: %v", delivery.ID, delivery.Attempts, err)
+		if err := dispatcher.store.MarkWebhookDead(ctx, delivery.ID, err.Error()); err != nil {
+			log.Printf("Failed to mark webhook delivery %d dead: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	retryIn := outbox.Backoff(delivery.Attempts, dispatcher.baseBackoff, dispatcher.maxBackoff)
+	log.Printf("Failed to deliver webhook %d (attempt %d), retrying in %s: %v", delivery.ID, delivery.Attempts, retryIn, err)
+	if err := dispatcher.store.MarkWebhookFailed(ctx, delivery.ID, retryIn, err.Error()); err != nil {
+		log.Printf("Failed to mark webhook delivery %d failed: %v", delivery.ID, err)
+	}
+}
+
+// send POSTs a signed delivery and fails unless the endpoint answers with a 2xx status.
+func (dispatcher *Dispatcher) send(ctx context.Context, delivery repository.WebhookDelivery) error {
+	payload, err := json.Marshal(body{ID: delivery.OutboxEventID, Type: delivery.EventType, Data: delivery.Payload})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	timestamp := dispatcher.now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderEvent, delivery.EventType)
+	request.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, payload))
+
+	response, err := dispatcher.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer response.Body.Close()
+
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded with status %d", response.StatusCode)
+	}
+	return nil
+}
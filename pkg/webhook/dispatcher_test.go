@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"muzz-backend-challenge/pkg/outbox"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+const testSecret = "test-secret"
+
+// receivedRequest is a webhook request captured by the test server.
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newEndpoint starts a server answering with status and sending every request it receives on the returned channel.
+func newEndpoint(t *testing.T, status int) (*httptest.Server, <-chan receivedRequest) {
+	received := make(chan receivedRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		received <- receivedRequest{header: r.Header, body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func delivery(url string, attempts int) repository.WebhookDelivery {
+	return repository.WebhookDelivery{
+		ID:            42,
+		EndpointID:    "00000000-0000-0000-0000-0000000000aa",
+		URL:           url,
+		Secret:        testSecret,
+		OutboxEventID: 7,
+		EventType:     outbox.EventMatchCreated,
+		Payload:       []byte(`{"user_ids":["a","b"],"unix_timestamp":1700000000}`),
+		Attempts:      attempts,
+	}
+}
+
+func TestDispatchBatch_Delivered(t *testing.T) {
+	server, received := newEndpoint(t, http.StatusNoContent)
+	store := new(repository.MockWebhookRepository)
+	dispatcher := NewDispatcher(store, WithHTTPClient(server.Client()))
+	ctx := context.Background()
+
+	store.On("ClaimWebhookDeliveries", ctx, DefaultBatchSize, mock.Anything).Return([]repository.WebhookDelivery{delivery(server.URL, 1)}, nil)
+	store.On("MarkWebhookDelivered", ctx, int64(42)).Return(nil)
+
+	dispatched, err := dispatcher.DispatchBatch(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, dispatched)
+	store.AssertExpectations(t)
+
+	request := <-received
+	assert.JSONEq(t, `{"id":7,"type":"match.created","data":{"user_ids":["a","b"],"unix_timestamp":1700000000}}`, string(request.body))
+	assert.Equal(t, "application/json", request.header.Get("Content-Type"))
+	assert.Equal(t, outbox.EventMatchCreated, request.header.Get(HeaderEvent))
+	assert.Equal(t, "42", request.header.Get(HeaderDelivery))
+
+	timestamp, err := strconv.ParseInt(request.header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.True(t, Verify(testSecret, timestamp, request.body, request.header.Get(HeaderSignature)))
+	assert.False(t, Verify("other-secret", timestamp, request.body, request.header.Get(HeaderSignature)))
+}
+
+func TestDispatchBatch_RetriesWithBackoff(t *testing.T) {
+	server, _ := newEndpoint(t, http.StatusInternalServerError)
+	store := new(repository.MockWebhookRepository)
+	dispatcher := NewDispatcher(store, WithHTTPClient(server.Client()), WithBackoff(time.Second, time.Minute))
+	ctx := context.Background()
+
+	store.On("ClaimWebhookDeliveries", ctx, DefaultBatchSize, mock.Anything).Return([]repository.WebhookDelivery{delivery(server.URL, 3)}, nil)
+	store.On("MarkWebhookFailed", ctx, int64(42), 4*time.Second, "endpoint responded with status 500").Return(nil)
+
+	_, err := dispatcher.DispatchBatch(ctx)
+
+	require.NoError(t, err)
+	store.AssertExpectations(t)
+}
+
+func TestDispatchBatch_DeadLettersAfterMaxAttempts(t *testing.T) {
+	server, _ := newEndpoint(t, http.StatusBadGateway)
+	store := new(repository.MockWebhookRepository)
+	dispatcher := NewDispatcher(store, WithHTTPClient(server.Client()), WithMaxAttempts(3))
+	ctx := context.Background()
+
+	store.On("ClaimWebhookDeliveries", ctx, DefaultBatchSize, mock.Anything).Return([]repository.WebhookDelivery{delivery(server.URL, 3)}, nil)
+	store.On("MarkWebhookDead", ctx, int64(42), "endpoint responded with status 502").Return(nil)
+
+	_, err := dispatcher.DispatchBatch(ctx)
+
+	require.NoError(t, err)
+	store.AssertExpectations(t)
+	store.AssertNotCalled(t, "MarkWebhookFailed", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDispatchBatch_UnreachableEndpoint(t *testing.T) {
+	server, _ := newEndpoint(t, http.StatusOK)
+	url := server.URL
+	server.Close()
+
+	store := new(repository.MockWebhookRepository)
+	dispatcher := NewDispatcher(store)
+	ctx := context.Background()
+
+	store.On("ClaimWebhookDeliveries", ctx, DefaultBatchSize, mock.Anything).Return([]repository.WebhookDelivery{delivery(url, 1)}, nil)
+	store.On("MarkWebhookFailed", ctx, int64(42), DefaultBaseBackoff, mock.AnythingOfType("string")).Return(nil)
+
+	_, err := dispatcher.DispatchBatch(ctx)
+
+	require.NoError(t, err)
+	store.AssertExpectations(t)
+}
+
+func TestPublisher_QueuesOnlyMatchEvents(t *testing.T) {
+	store := new(repository.MockWebhookRepository)
+	publisher := NewPublisher(store)
+	ctx := context.Background()
+	payload := []byte(`{"user_ids":["a","b"]}`)
+
+	store.On("EnqueueWebhookDeliveries", ctx, int64(2), outbox.EventMatchCreated, payload).Return(nil)
+
+	require.NoError(t, publisher.Publish(ctx, repository.OutboxEvent{ID: 1, EventType: outbox.EventDecisionRecorded}))
+	require.NoError(t, publisher.Publish(ctx, repository.OutboxEvent{ID: 2, EventType: outbox.EventMatchCreated, Payload: payload}))
+
+	store.AssertExpectations(t)
+	store.AssertNumberOfCalls(t, "EnqueueWebhookDeliveries", 1)
+}
+
+func TestDispatchBatch_RefusesPrivateAddresses(t *testing.T) {
+	var received bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+	defer server.Close()
+
+	store := new(repository.MockWebhookRepository)
+	dispatcher := NewDispatcher(store)
+	ctx := context.Background()
+
+	store.On("ClaimWebhookDeliveries", ctx, DefaultBatchSize, mock.Anything).Return([]repository.WebhookDelivery{delivery(server.URL, 1)}, nil)
+	store.On("MarkWebhookFailed", ctx, int64(42), DefaultBaseBackoff, mock.MatchedBy(func(reason string) bool {
+		return strings.Contains(reason, ErrForbiddenAddress.Error())
+	})).Return(nil)
+
+	_, err := dispatcher.DispatchBatch(ctx)
+
+	require.NoError(t, err)
+	assert.False(t, received)
+	store.AssertExpectations(t)
+}
+
+func TestPublicIP(t *testing.T) {
+	for address, public := range map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1":    true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"10.0.0.1":        false,
+		"172.16.5.4":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"fe80::1":         false,
+		"fd00::1":         false,
+		"0.0.0.0":         false,
+		"224.0.0.1":       false,
+	} {
+		assert.Equal(t, public, PublicIP(net.ParseIP(address)), address)
+	}
+}
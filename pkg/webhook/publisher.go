@@ -0,0 +1,27 @@
+package webhook
+
+import (
+	"context"
+
+	"muzz-backend-challenge/pkg/outbox"
+	"muzz-backend-challenge/pkg/repository"
+)
+
+// Publisher is an outbox.EventPublisher that queues match events for webhook delivery.
+type Publisher struct {
+	store repository.WebhookRepository
+}
+
+// NewPublisher creates a publisher queueing deliveries in store.
+func NewPublisher(store repository.WebhookRepository) *Publisher {
+	return &Publisher{store: store}
+}
+
+// Publish queues a delivery of match events to every enabled endpoint. Other events are ignored.
+// Publishing the same event again does not queue duplicate deliveries.
+func (p *Publisher) Publish(ctx context.Context, event repository.OutboxEvent) error {
+	if event.EventType != outbox.EventMatchCreated {
+		return nil
+	}
+	return p.store.EnqueueWebhookDeliveries(ctx, event.ID, event.EventType, event.Payload)
+}
@@ -0,0 +1,46 @@
+// Package webhook delivers match events to registered HTTP endpoints.
+//
+// The outbox relay hands events to a Publisher, which queues one delivery per enabled endpoint.
+// A Dispatcher then POSTs each delivery as signed JSON, retrying failures with exponential
+// backoff until the delivery succeeds or runs out of attempts and is dead-lettered.
+//
+// Every request carries the headers below. Receivers should recompute the signature with their
+// secret, compare it in constant time, and reject stale timestamps to prevent replays.
+//
+//	X-Webhook-Event:     event type, e.g. match.created
+//	X-Webhook-Delivery:  delivery ID, stable across retries
+//	X-Webhook-Timestamp: Unix time the request was signed
+//	X-Webhook-Signature: sha256=<hex HMAC-SHA256 of "<timestamp>.<body>" keyed by the secret>
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// Headers set on every webhook request.
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// signaturePrefix names the algorithm in the signature header.
+const signaturePrefix = "sha256="
+
+// Sign returns the X-Webhook-Signature value for a body sent at the given Unix timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is valid for the body sent at the given Unix timestamp.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}